@@ -0,0 +1,43 @@
+// Package awscli chama o AWS CLI v2 com saída JSON. Usamos o binário oficial (como
+// no doctor) para não adicionar o SDK como dependência; health e rollout montam
+// seus clients sobre estas funções.
+package awscli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// RunFunc executa um binário e retorna o stdout; os testes injetam uma versão fake.
+type RunFunc func(name string, args ...string) ([]byte, error)
+
+// Run executa o comando e inclui o stderr na mensagem de erro.
+func Run(name string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command(name, args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w: %s", name, strings.Join(args[:min(2, len(args))], " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// Call roda `aws <args> [--region region] --output json` e decodifica a resposta em out.
+func Call(run RunFunc, region string, out any, args ...string) error {
+	if region != "" {
+		args = append(args, "--region", region)
+	}
+	args = append(args, "--output", "json")
+	raw, err := run("aws", args...)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("parse aws %s %s output: %w", args[0], args[1], err)
+	}
+	return nil
+}
@@ -7,7 +7,8 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
-	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -58,11 +59,14 @@ module "app" {
   app_user_data_mode  = "{{ .EC2.UserDataMode }}"
   app_user_data_base64 = "{{ .AppUserDataB64 }}"
   imds_v2_required    = {{ .EC2.IMDSv2Required }}
+  app_os              = "{{ .EC2.OS }}"
   allowed_rdp_cidr    = "{{ .EC2.AllowedRDPCIDR }}"
+  allowed_ssh_cidr    = "{{ .EC2.AllowedSSHCIDR }}"
 
   enable_db           = {{ .DB.Enabled }}
   db_mode             = "{{ .DB.Mode }}"
   db_instance_type    = "{{ .DB.InstanceType }}"
+  db_os               = "{{ or .DB.OS .EC2.OS }}"
   db_ami_id           = "{{ .DB.AMI }}"
   db_user_data_mode   = "{{ .DB.UserDataMode }}"
   db_user_data_base64 = "{{ .DBUserDataB64 }}"
@@ -87,6 +91,44 @@ module "app" {
   lb_listener_port = {{ .LB.ListenerPort }}
   app_port         = {{ .LB.TargetPort }}
   lb_allowed_cidr  = "{{ .LB.AllowedCIDR }}"
+  lb_target_protocol = "{{ or .LB.TargetProtocol "HTTP" }}"
+
+  lb_certificate_arn               = "{{ .LB.TLS.CertificateARN }}"
+  lb_tls_domain                    = "{{ .LB.TLS.Domain }}"
+  lb_tls_subject_alternative_names = [{{- range $i, $s := .LB.TLS.SubjectAlternativeNames -}}{{- if $i }}, {{ end }}"{{ $s }}"{{- end -}}]
+  lb_tls_hosted_zone_id            = "{{ .LB.TLS.HostedZoneID }}"
+  lb_ssl_policy                    = "{{ .LB.TLS.SSLPolicy }}"
+  lb_redirect_http                 = {{ if .LB.TLS.RedirectHTTP }}{{ .LB.TLS.RedirectHTTP }}{{ else }}true{{ end }}
+
+  lb_health_check_path                = "{{ or .LB.HealthCheck.Path "/" }}"
+  lb_health_check_port                = {{ .LB.HealthCheck.Port }}
+  lb_health_check_matcher             = "{{ or .LB.HealthCheck.Matcher "200-399" }}"
+  lb_health_check_interval            = {{ or .LB.HealthCheck.Interval 30 }}
+  lb_health_check_timeout             = {{ or .LB.HealthCheck.Timeout 5 }}
+  lb_health_check_healthy_threshold   = {{ or .LB.HealthCheck.HealthyThreshold 2 }}
+  lb_health_check_unhealthy_threshold = {{ or .LB.HealthCheck.UnhealthyThreshold 2 }}
+  lb_deregistration_delay             = {{ if .LB.DeregistrationDelay }}{{ .LB.DeregistrationDelay }}{{ else }}300{{ end }}
+  lb_stickiness_enabled               = {{ .LB.Stickiness.Enabled }}
+  lb_stickiness_type                  = "{{ or .LB.Stickiness.Type "lb_cookie" }}"
+  lb_stickiness_cookie_name           = "{{ .LB.Stickiness.CookieName }}"
+  lb_stickiness_duration              = {{ or .LB.Stickiness.DurationSeconds 86400 }}
+  lb_routes                           = [{{ .LBRoutesHCL }}]
+
+  lb_waf_enabled                = {{ .LB.WAF.Enabled }}
+  lb_waf_web_acl_arn            = "{{ .LB.WAF.WebACLARN }}"
+{{- if .LB.WAF.ManagedRules }}
+  lb_waf_managed_rules          = [{{- range $i, $r := .LB.WAF.ManagedRules -}}{{- if $i }}, {{ end }}"{{ $r }}"{{- end -}}]
+{{- end }}
+  lb_waf_rate_limit             = {{ .LB.WAF.RateLimit }}
+  lb_waf_blocked_requests_alarm = {{ .LB.WAF.BlockedRequestsAlarm }}
+
+  enable_dns           = {{ .DNS.Enabled }}
+  dns_zone_id          = "{{ .DNS.ZoneID }}"
+  dns_zone_name        = "{{ .DNS.ZoneName }}"
+  dns_private_zone     = {{ .DNS.Private }}
+  dns_record_name      = "{{ .DNS.RecordName }}"
+  dns_ttl              = {{ .DNS.TTL }}
+  dns_instance_records = {{ .DNS.InstanceRecords }}
 
   enable_app_asg         = {{ .AppScaling.Enabled }}
   app_asg_subnet_ids     = [{{- range $i, $s := .AppScaling.SubnetIDs -}}{{- if $i }}, {{ end }}"{{ $s }}"{{- end -}}]
@@ -94,6 +136,29 @@ module "app" {
   app_asg_max_size       = {{ .AppScaling.MaxSize }}
   app_asg_desired_capacity = {{ .AppScaling.DesiredCapacity }}
   app_asg_cpu_target     = {{ .AppScaling.CPUTarget }}
+  app_asg_request_count_target = {{ .AppScaling.RequestsPerTarget }}
+  app_asg_schedules            = [{{ .SchedulesHCL }}]
+  app_asg_step_policies        = [{{ .StepPoliciesHCL }}]
+
+  app_asg_instance_types           = [{{- range $i, $t := .AppScaling.Instances.Types -}}{{- if $i }}, {{ end }}"{{ $t }}"{{- end -}}]
+  app_asg_on_demand_base_capacity  = {{ .AppScaling.Instances.OnDemandBaseCapacity }}
+  app_asg_on_demand_percentage     = {{ if .AppScaling.Instances.OnDemandPercentage }}{{ .AppScaling.Instances.OnDemandPercentage }}{{ else }}100{{ end }}
+  app_asg_spot_allocation_strategy = "{{ or .AppScaling.Instances.SpotAllocationStrategy "price-capacity-optimized" }}"
+  app_asg_capacity_rebalance       = {{ .AppScaling.Instances.CapacityRebalance }}
+
+  app_asg_warm_pool_enabled               = {{ .AppScaling.WarmPool.Enabled }}
+  app_asg_warm_pool_min_size              = {{ .AppScaling.WarmPool.MinSize }}
+  app_asg_warm_pool_max_prepared_capacity = {{ if .AppScaling.WarmPool.MaxPreparedCapacity }}{{ .AppScaling.WarmPool.MaxPreparedCapacity }}{{ else }}null{{ end }}
+  app_asg_warm_pool_state                 = "{{ or .AppScaling.WarmPool.PoolState "Stopped" }}"
+  app_asg_warm_pool_reuse_on_scale_in     = {{ .AppScaling.WarmPool.ReuseOnScaleIn }}
+
+  app_asg_rollout_enabled                = {{ .AppScaling.Rollout.Enabled }}
+  app_asg_rollout_min_healthy_percentage = {{ with .AppScaling.Rollout.MinHealthyPercentage }}{{ . }}{{ else }}0{{ end }}
+  app_asg_rollout_instance_warmup        = {{ with .AppScaling.Rollout.InstanceWarmup }}{{ . }}{{ else }}0{{ end }}
+  app_asg_rollout_checkpoint_percentages = [{{- range $i, $p := .AppScaling.Rollout.CheckpointPercentages -}}{{- if $i }}, {{ end }}{{ $p }}{{- end -}}]
+  app_asg_rollout_checkpoint_delay       = {{ with .AppScaling.Rollout.CheckpointDelay }}{{ . }}{{ else }}0{{ end }}
+  app_asg_rollout_auto_rollback          = {{ with .AppScaling.Rollout.AutoRollback }}{{ . }}{{ else }}false{{ end }}
+  app_asg_rollout_alarm_names            = [{{- range $i, $a := .AppScaling.Rollout.RollbackAlarms -}}{{- if $i }}, {{ end }}"{{ $a }}"{{- end -}}]
 
   enable_observability = {{ .ObservabilityEnabled }}
   enable_ssm_endpoints = {{ .ObservabilityEnableSSMEndpoints }}
@@ -363,6 +428,9 @@ type renderData struct {
 	AppExtraIngressHCL                 string
 	DBExtraIngressHCL                  string
 	ALBExtraIngressHCL                 string
+	LBRoutesHCL                        string
+	SchedulesHCL                       string
+	StepPoliciesHCL                    string
 }
 
 // Generate monta workspace Terraform completo para o workload ec2-app.
@@ -405,13 +473,16 @@ func Generate(wsDir string, cfg *config.AppConfig) error {
 		AppExtraIngressHCL:                 buildIngressRulesHCL(cfg.RuntimeOverrides.AppExtraIngress),
 		DBExtraIngressHCL:                  buildIngressRulesHCL(cfg.RuntimeOverrides.DBExtraIngress),
 		ALBExtraIngressHCL:                 buildIngressRulesHCL(cfg.RuntimeOverrides.ALBExtraIngress),
+		LBRoutesHCL:                        buildLBRoutesHCL(cfg.LB.Routes),
+		SchedulesHCL:                       buildSchedulesHCL(cfg.AppScaling.Schedules),
+		StepPoliciesHCL:                    buildStepPoliciesHCL(cfg.AppScaling.StepPolicies),
 	}
 
-	appUserData, err := sanitizePowerShellUserData(cfg.EC2.UserData)
+	appUserData, err := config.NormalizeUserData(cfg.EC2.OS, cfg.EC2.UserData)
 	if err != nil {
 		return fmt.Errorf("ec2.user_data: %w", err)
 	}
-	dbUserData, err := sanitizePowerShellUserData(cfg.DB.UserData)
+	dbUserData, err := config.NormalizeUserData(cfg.DB.OS, cfg.DB.UserData)
 	if err != nil {
 		return fmt.Errorf("db.user_data: %w", err)
 	}
@@ -445,47 +516,75 @@ func buildIngressRulesHCL(rules []config.IngressRule) string {
 	return strings.Join(parts, ", ")
 }
 
-func encodeBase64(v string) string {
-	if v == "" {
+func buildLBRoutesHCL(routes []config.LBRouteConfig) string {
+	if len(routes) == 0 {
 		return ""
 	}
-	return base64.StdEncoding.EncodeToString([]byte(v))
+	parts := make([]string, 0, len(routes))
+	for _, r := range routes {
+		patterns := make([]string, 0, len(r.PathPatterns))
+		for _, p := range r.PathPatterns {
+			patterns = append(patterns, fmt.Sprintf("\"%s\"", p))
+		}
+		parts = append(parts, fmt.Sprintf("{ name = %q, path_patterns = [%s], target_port = %d, priority = %d, health_check_path = %q }", r.Name, strings.Join(patterns, ", "), r.TargetPort, r.Priority, r.HealthCheckPath))
+	}
+	return strings.Join(parts, ", ")
 }
 
-var (
-	powershellOpenTag  = regexp.MustCompile(`(?i)<\s*powershell\s*>`)
-	powershellCloseTag = regexp.MustCompile(`(?i)</\s*powershell\s*>`)
-)
-
-// sanitizePowerShellUserData garante payload sem wrapper duplicado para o modo merge.
-// Se vier encapsulado com <powershell>...</powershell>, remove o wrapper e retorna apenas o corpo.
-func sanitizePowerShellUserData(raw string) (string, error) {
-	trimmed := strings.TrimSpace(raw)
-	if trimmed == "" {
-		return "", nil
+// buildSchedulesHCL usa -1 para tamanhos omitidos ("não alterar" no aws_autoscaling_schedule).
+func buildSchedulesHCL(schedules []config.ScalingSchedule) string {
+	if len(schedules) == 0 {
+		return ""
 	}
-
-	openIndexes := powershellOpenTag.FindAllStringIndex(trimmed, -1)
-	closeIndexes := powershellCloseTag.FindAllStringIndex(trimmed, -1)
-
-	if len(openIndexes) == 0 && len(closeIndexes) == 0 {
-		return trimmed, nil
+	size := func(v *int) int {
+		if v == nil {
+			return -1
+		}
+		return *v
 	}
-	if len(openIndexes) != 1 || len(closeIndexes) != 1 {
-		return "", fmt.Errorf("must contain at most one <powershell> wrapper")
+	parts := make([]string, 0, len(schedules))
+	for _, sc := range schedules {
+		parts = append(parts, fmt.Sprintf("{ name = %q, recurrence = %q, time_zone = %q, min_size = %d, max_size = %d, desired_capacity = %d }", sc.Name, sc.Recurrence, sc.TimeZone, size(sc.MinSize), size(sc.MaxSize), size(sc.DesiredCapacity)))
 	}
+	return strings.Join(parts, ", ")
+}
 
-	open := openIndexes[0]
-	close := closeIndexes[0]
-	if open[0] != 0 || close[1] != len(trimmed) || open[1] > close[0] {
-		return "", fmt.Errorf("<powershell> wrapper must enclose the whole script")
+func buildStepPoliciesHCL(policies []config.StepScalingPolicy) string {
+	if len(policies) == 0 {
+		return ""
+	}
+	bound := func(v *float64) string {
+		if v == nil {
+			return "null"
+		}
+		return strconv.FormatFloat(*v, 'f', -1, 64)
+	}
+	parts := make([]string, 0, len(policies))
+	for _, p := range policies {
+		keys := make([]string, 0, len(p.Dimensions))
+		for k := range p.Dimensions {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		dims := make([]string, 0, len(keys))
+		for _, k := range keys {
+			dims = append(dims, fmt.Sprintf("%q = %q", k, p.Dimensions[k]))
+		}
+		steps := make([]string, 0, len(p.Steps))
+		for _, st := range p.Steps {
+			steps = append(steps, fmt.Sprintf("{ lower_bound = %s, upper_bound = %s, adjustment = %d }", bound(st.LowerBound), bound(st.UpperBound), st.Adjustment))
+		}
+		parts = append(parts, fmt.Sprintf("{ name = %q, metric_name = %q, namespace = %q, statistic = %q, dimensions = { %s }, comparison_operator = %q, threshold = %s, period = %d, evaluation_periods = %d, adjustment_type = %q, steps = [%s] }",
+			p.Name, p.MetricName, p.Namespace, p.Statistic, strings.Join(dims, ", "), p.ComparisonOperator, strconv.FormatFloat(p.Threshold, 'f', -1, 64), p.Period, p.EvaluationPeriods, p.AdjustmentType, strings.Join(steps, ", ")))
 	}
+	return strings.Join(parts, ", ")
+}
 
-	body := strings.TrimSpace(trimmed[open[1]:close[0]])
-	if body == "" {
-		return "", fmt.Errorf("script inside <powershell> wrapper is empty")
+func encodeBase64(v string) string {
+	if v == "" {
+		return ""
 	}
-	return body, nil
+	return base64.StdEncoding.EncodeToString([]byte(v))
 }
 
 // findRepoRoot sobe diretórios até localizar go.mod.
@@ -0,0 +1,16 @@
+package ec2app
+
+import "github.com/PydaVi/brainctl/internal/migrate"
+
+// Migrations lista os passos entre versões do contrato ec2-app (v1 é a primeira versão
+// com contrato estável). Um v2 deve adicionar {From: "v1", To: "v2", ...} aqui.
+var Migrations []migrate.Step
+
+// Deprecations são campos do contrato ec2-app agendados para remoção.
+var Deprecations = []migrate.Deprecation{
+	{
+		Path:      "ec2.imds_v2_required",
+		RemovedIn: "v2",
+		Message:   "IMDSv2 já é exigido em todas as instâncias (http_tokens=required); o campo não tem efeito",
+	},
+}
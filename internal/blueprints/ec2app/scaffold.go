@@ -0,0 +1,188 @@
+package ec2app
+
+import (
+	"strings"
+
+	"github.com/PydaVi/brainctl/internal/blueprints/scaffold"
+	"github.com/PydaVi/brainctl/internal/config"
+)
+
+// appYAMLTemplate é o contrato mínimo válido: uma instância sem ALB, banco,
+// observabilidade ou recovery. Os blocos opcionais ficam comentados como referência.
+const appYAMLTemplate = `workload:
+  type: ec2-app
+  version: v1
+
+terraform:
+  backend:
+    bucket: {{ quote .StateBucket }}
+    region: {{ quote .Region }}
+    use_lockfile: true
+
+app:
+  name: {{ .Name }}
+  environment: {{ .Environment }}
+  region: {{ .Region }}
+{{- if .Tags }}
+
+tags:
+{{- range $k, $v := .Tags }}
+  {{ $k }}: {{ quote $v }}
+{{- end }}
+{{- end }}
+
+infrastructure:
+  vpc_id: {{ .VpcID }}
+  vpc_cidr: {{ quote .VpcCIDR }}
+  subnet_id: {{ first .SubnetIDs }}
+  subnet_ids:
+{{- range .SubnetIDs }}
+    - {{ . }}
+{{- end }}
+  allowed_egress_cidrs:
+    - {{ quote .VpcCIDR }}
+
+ec2:
+  instance_type: t3.micro
+  os: {{ .OS }}
+{{- if .UserData }}
+  user_data_mode: merge
+  user_data: file://scripts/{{ if eq .OS "windows-2022" }}app-user-data.ps1{{ else }}app-user-data.sh{{ end }}
+{{- else }}
+  user_data_mode: default
+{{- end }}
+{{- if eq .OS "windows-2022" }}
+  allowed_rdp_cidr: {{ quote .VpcCIDR }}
+{{- else }}
+  allowed_ssh_cidr: {{ quote .VpcCIDR }}
+{{- end }}
+
+db:
+  enabled: false
+  # mode: ec2 # ec2 | rds
+  # instance_type: t3.micro
+  # port: {{ if eq .OS "windows-2022" }}1433{{ else }}5432{{ end }}
+
+lb:
+  enabled: false
+  # scheme: private
+  # subnet_ids: [{{ join .SubnetIDs ", " }}]
+  # listener_port: 80 # 443 requer tls
+  # target_port: 80
+  # target_protocol: HTTP # HTTP | HTTPS
+  # health_check:
+  #   path: /healthz
+  #   matcher: "200-399"
+  # waf:
+  #   enabled: true # web ACL com core, known_bad_inputs e ip_reputation
+  #   rate_limit: 2000 # req/5min por IP
+  # routes:
+  #   - name: api
+  #     path_patterns: ["/api/*"]
+  #     target_port: 8081
+  # tls:
+  #   certificate_arn: arn:aws:acm:... # ou domain + hosted_zone_id (ACM com validação DNS)
+  #   ssl_policy: ELBSecurityPolicy-TLS13-1-2-2021-06
+  #   redirect_http: true
+  # allowed_cidr: {{ quote .VpcCIDR }}
+
+dns:
+  enabled: false
+  # zone_name: example.internal # ou zone_id
+  # private: true
+  # record_name: {{ .Name }}-{{ .Environment }}
+  # instance_records: false # sem LB: um registro A por instância
+
+app_scaling:
+  enabled: false # requer lb.enabled=true
+  # rollout:
+  #   enabled: true # instance refresh quando AMI/user data mudam
+  #   min_healthy_percentage: 90
+  #   checkpoint_percentages: [50, 100]
+  # requests_per_target: 1000 # target tracking ALBRequestCountPerTarget
+  # schedules: # ex.: dev desligado fora do horário comercial
+  #   - name: night-off
+  #     recurrence: "0 20 * * 1-5"
+  #     time_zone: America/Sao_Paulo
+  #     min_size: 0
+  #     desired_capacity: 0
+  #   - name: morning-on
+  #     recurrence: "0 8 * * 1-5"
+  #     time_zone: America/Sao_Paulo
+  #     min_size: 1
+  #     desired_capacity: 1
+  # instances: # mixed instances + Spot (dev/staging)
+  #   types: [t3.medium, t3a.medium]
+  #   on_demand_base_capacity: 1
+  #   on_demand_percentage: 0 # acima da base, todo o resto é Spot
+  #   capacity_rebalance: true
+  # warm_pool:
+  #   enabled: true
+  #   pool_state: Stopped
+
+observability:
+  enabled: false
+  # log_kms_key_id: "alias/brainctl-logs" # obrigatório com enabled=true
+  # alert_email: ""
+
+recovery:
+  enabled: false
+`
+
+const userDataTemplate = `# user data do app ({{ .Name }}/{{ .Environment }}).
+# Com user_data_mode=merge, este script roda depois do bootstrap padrão do brainctl.
+Write-Output "brainctl: {{ .Name }} {{ .Environment }} pronto"
+`
+
+// linuxUserDataTemplate é o equivalente bash; o shebang é removido na geração e o
+// módulo concatena o script ao bootstrap padrão.
+const linuxUserDataTemplate = `#!/bin/bash
+# user data do app ({{ .Name }}/{{ .Environment }}).
+# Com user_data_mode=merge, este script roda depois do bootstrap padrão do brainctl.
+echo "brainctl: {{ .Name }} {{ .Environment }} pronto"
+`
+
+// securityGroupTemplate usa {{ . }} como marcador do grupo (app, db ou alb).
+const securityGroupTemplate = `group: {{ . }}
+# regras extras de ingress para o SG {{ . }}; exemplo:
+# - description: "HTTPS interno"
+#   from_port: 443
+#   to_port: 443
+#   protocol: tcp
+#   cidr_blocks:
+#     - "10.0.0.0/16"
+ingress: []
+`
+
+// Scaffold gera os arquivos de uma nova stack ec2-app.
+func Scaffold(p scaffold.Params) ([]scaffold.File, error) {
+	if p.OS == "" {
+		p.OS = config.OSWindows2022
+	}
+	appYAML, err := scaffold.Render("app.yaml", appYAMLTemplate, p)
+	if err != nil {
+		return nil, err
+	}
+	files := []scaffold.File{{Path: "app.yaml", Content: appYAML}}
+
+	if p.UserData {
+		name, text := "app-user-data.ps1", userDataTemplate
+		if !config.IsWindowsOS(p.OS) {
+			name, text = "app-user-data.sh", linuxUserDataTemplate
+		}
+		b, err := scaffold.Render(name, text, p)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, scaffold.File{Path: "scripts/" + name, Content: b})
+	}
+	if p.SecurityGroups {
+		for _, group := range []string{"alb", "app", "db"} {
+			files = append(files, scaffold.File{
+				Path:    "security-groups/" + group + ".yaml",
+				Content: []byte(strings.ReplaceAll(securityGroupTemplate, "{{ . }}", group)),
+			})
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,129 @@
+package ec2app
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/PydaVi/brainctl/internal/outputs"
+)
+
+// RenderStatus renderiza os recursos do ec2-app a partir dos outputs do terraform.
+func RenderStatus(w io.Writer, v map[string]any) {
+	fmt.Fprintln(w, "Resources:")
+
+	fmt.Fprintln(w, "  APP")
+	if asgName := outputs.AsString(v["app_asg_name"], ""); asgName != "" {
+		fmt.Fprintf(w, "    asg_name   : %s\n", asgName)
+		fmt.Fprintf(w, "    asg_size   : min=%s desired=%s max=%s\n", outputs.AsString(v["app_asg_min_size"], "?"), outputs.AsString(v["app_asg_desired_capacity"], "?"), outputs.AsString(v["app_asg_max_size"], "?"))
+	} else {
+		fmt.Fprintf(w, "    instance_id: %s\n", outputs.AsString(v["instance_id"], "(none)"))
+		fmt.Fprintf(w, "    private_ip : %s\n", outputs.AsString(v["private_ip"], "(none)"))
+		fmt.Fprintf(w, "    public_ip  : %s\n", outputs.AsString(v["public_ip"], "(none)"))
+	}
+	fmt.Fprintf(w, "    sg         : %s (%s)\n", outputs.AsString(v["security_group_name"], "(none)"), outputs.AsString(v["security_group_id"], "(none)"))
+	fmt.Fprintln(w)
+
+	if dbID := outputs.AsString(v["db_instance_id"], ""); dbID != "" {
+		fmt.Fprintln(w, "  DB")
+		fmt.Fprintf(w, "    instance_id: %s\n", dbID)
+		fmt.Fprintf(w, "    private_ip : %s\n", outputs.AsString(v["db_private_ip"], "(none)"))
+		fmt.Fprintf(w, "    sg         : %s (%s)\n", outputs.AsString(v["db_security_group_name"], "(none)"), outputs.AsString(v["db_security_group_id"], "(none)"))
+		fmt.Fprintln(w)
+	}
+
+	if albDNS := outputs.AsString(v["alb_dns_name"], ""); albDNS != "" {
+		fmt.Fprintln(w, "  ALB")
+		fmt.Fprintf(w, "    dns_name   : %s\n", albDNS)
+		if url := outputs.AsString(v["alb_url"], ""); url != "" {
+			fmt.Fprintf(w, "    url        : %s\n", url)
+		}
+		if cert := outputs.AsString(v["alb_certificate_arn"], ""); cert != "" {
+			fmt.Fprintf(w, "    cert       : %s\n", cert)
+		}
+		if waf := outputs.AsString(v["alb_waf_web_acl_arn"], ""); waf != "" {
+			fmt.Fprintf(w, "    waf        : %s\n", waf)
+		}
+		fmt.Fprintln(w)
+	}
+
+	dnsFQDN := outputs.AsString(v["dns_record_fqdn"], "")
+	instanceFQDNs := outputs.AsStringSlice(v["dns_instance_fqdns"])
+	if dnsFQDN != "" || len(instanceFQDNs) > 0 {
+		fmt.Fprintln(w, "  DNS")
+		if dnsFQDN != "" {
+			fmt.Fprintf(w, "    record     : %s -> ALB\n", dnsFQDN)
+		}
+		for _, fqdn := range instanceFQDNs {
+			fmt.Fprintf(w, "    instance   : %s\n", fqdn)
+		}
+		fmt.Fprintln(w)
+	}
+
+	appDash := outputs.AsString(v["observability_app_dashboard_name"], "")
+	dbDash := outputs.AsString(v["observability_db_dashboard_name"], "")
+	sreDash := outputs.AsString(v["observability_sre_dashboard_name"], "")
+	sloDash := outputs.AsString(v["observability_slo_dashboard_name"], "")
+	execDash := outputs.AsString(v["observability_executive_dashboard_name"], "")
+	infraDash := outputs.AsString(v["observability_infra_dashboard_name"], "")
+	if appDash != "" || dbDash != "" || sreDash != "" || sloDash != "" || execDash != "" || infraDash != "" {
+		fmt.Fprintln(w, "  OBSERVABILITY")
+		if appDash != "" {
+			fmt.Fprintf(w, "    app_dash   : %s\n", appDash)
+			fmt.Fprintf(w, "    app_url    : %s\n", outputs.AsString(v["observability_app_dashboard_url"], "(none)"))
+		}
+		if dbDash != "" {
+			fmt.Fprintf(w, "    db_dash    : %s\n", dbDash)
+			fmt.Fprintf(w, "    db_url     : %s\n", outputs.AsString(v["observability_db_dashboard_url"], "(none)"))
+		}
+		if sreDash != "" {
+			fmt.Fprintf(w, "    sre_dash   : %s\n", sreDash)
+			fmt.Fprintf(w, "    sre_url    : %s\n", outputs.AsString(v["observability_sre_dashboard_url"], "(none)"))
+		}
+		if sloDash != "" {
+			fmt.Fprintf(w, "    slo_dash   : %s\n", sloDash)
+			fmt.Fprintf(w, "    slo_url    : %s\n", outputs.AsString(v["observability_slo_dashboard_url"], "(none)"))
+		}
+		if execDash != "" {
+			fmt.Fprintf(w, "    exec_dash  : %s\n", execDash)
+			fmt.Fprintf(w, "    exec_url   : %s\n", outputs.AsString(v["observability_executive_dashboard_url"], "(none)"))
+		}
+		if infraDash != "" {
+			fmt.Fprintf(w, "    infra_dash : %s\n", infraDash)
+			fmt.Fprintf(w, "    infra_url  : %s\n", outputs.AsString(v["observability_infra_dashboard_url"], "(none)"))
+		}
+
+		snsTopic := outputs.AsString(v["observability_sns_topic_arn"], "")
+		email := outputs.AsString(v["observability_alert_email"], "")
+		if snsTopic != "" {
+			fmt.Fprintf(w, "    sns_topic  : %s\n", snsTopic)
+			fmt.Fprintf(w, "    sns_sev1   : %s\n", outputs.AsString(v["observability_sns_topic_sev1_arn"], "(none)"))
+			fmt.Fprintf(w, "    sns_sev2   : %s\n", outputs.AsString(v["observability_sns_topic_sev2_arn"], "(none)"))
+			fmt.Fprintf(w, "    sns_sev3   : %s\n", outputs.AsString(v["observability_sns_topic_sev3_arn"], "(none)"))
+			fmt.Fprintf(w, "    alert_email: %s\n", email)
+		}
+		fmt.Fprintf(w, "    log_group  : %s\n", outputs.AsString(v["observability_log_group_name"], "(none)"))
+		fmt.Fprintf(w, "    ssm_profile: %s\n", outputs.AsString(v["observability_ssm_profile_name"], "(none)"))
+
+		alarmNames := outputs.AsStringSlice(v["observability_alarm_names"])
+		if len(alarmNames) == 0 {
+			fmt.Fprintln(w, "    alarms     : (none)")
+		} else {
+			fmt.Fprintln(w, "    alarms:")
+			for _, name := range alarmNames {
+				fmt.Fprintf(w, "      - %s\n", name)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+	recoveryEnabled := outputs.AsString(v["recovery_enabled"], "")
+	if recoveryEnabled == "true" {
+		fmt.Fprintln(w, "  RECOVERY")
+		fmt.Fprintf(w, "    snapshot_utc : %s\n", outputs.AsString(v["recovery_snapshot_time_utc"], "(none)"))
+		fmt.Fprintf(w, "    retention    : %s days\n", outputs.AsString(v["recovery_retention_days"], "(none)"))
+		fmt.Fprintf(w, "    app_policy   : %s\n", outputs.AsString(v["recovery_app_policy_id"], "(none)"))
+		fmt.Fprintf(w, "    db_policy    : %s\n", outputs.AsString(v["recovery_db_policy_id"], "(none)"))
+		fmt.Fprintf(w, "    app_runbook  : %s\n", outputs.AsString(v["recovery_app_runbook_name"], "(none)"))
+		fmt.Fprintf(w, "    db_runbook   : %s\n", outputs.AsString(v["recovery_db_runbook_name"], "(none)"))
+		fmt.Fprintln(w)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"text/template"
 
 	"github.com/PydaVi/brainctl/internal/config"
+	"github.com/PydaVi/brainctl/internal/generator"
 )
 
 const mainTF = `
@@ -42,8 +43,9 @@ module "k8s_workers" {
   control_plane_ami      = "{{ .K8s.ControlPlaneAMI }}"
   worker_ami             = "{{ .K8s.WorkerAMI }}"
   control_plane_type     = "{{ .K8s.ControlPlaneInstanceType }}"
-  worker_type            = "{{ .K8s.WorkerInstanceType }}"
-  worker_count           = {{ .K8s.WorkerCount }}
+  control_plane_count    = {{ or .K8s.ControlPlaneCount 1 }}
+  join_token_ttl_minutes = {{ .K8s.JoinTokenTTLMinutes }}
+  node_pools             = {{ .NodePoolsHCL }}
   kubernetes_version     = "{{ .K8s.KubernetesVersion }}"
   pod_cidr               = "{{ .K8s.PodCIDR }}"
   key_name                 = "{{ .K8s.KeyName }}"
@@ -80,11 +82,36 @@ output "control_plane_public_dns" {
   description = "DNS público do control-plane"
 }
 
+output "control_plane_instance_ids" {
+  value       = module.k8s_workers.control_plane_instance_ids
+  description = "IDs de todos os control-planes"
+}
+
+output "control_plane_private_ips" {
+  value       = module.k8s_workers.control_plane_private_ips
+  description = "IPs privados de todos os control-planes"
+}
+
+output "control_plane_endpoint" {
+  value       = module.k8s_workers.control_plane_endpoint
+  description = "Endpoint do API server (NLB interno em HA)"
+}
+
+output "join_parameter_names" {
+  value       = module.k8s_workers.join_parameter_names
+  description = "Parâmetros SSM (SecureString) com os comandos de join"
+}
+
 output "worker_instance_ids" {
   value       = module.k8s_workers.worker_instance_ids
   description = "IDs das instâncias worker"
 }
 
+output "worker_asg_names" {
+  value       = module.k8s_workers.worker_asg_names
+  description = "ASG de cada node pool"
+}
+
 output "kubeconfig_retrieve_instructions" {
   value       = module.k8s_workers.kubeconfig_retrieve_instructions
   description = "Como obter o kubeconfig do cluster"
@@ -105,6 +132,7 @@ type renderData struct {
 	EnableSSMVPCEndpoints    bool
 	EnableDetailedMonitoring bool
 	EndpointSubnetIDs        []string
+	NodePoolsHCL             string
 }
 
 func Generate(wsDir string, cfg *config.AppConfig) error {
@@ -144,6 +172,7 @@ func Generate(wsDir string, cfg *config.AppConfig) error {
 		EnableSSMVPCEndpoints:    cfg.K8s.EnableSSMVPCEndpoints != nil && *cfg.K8s.EnableSSMVPCEndpoints,
 		EnableDetailedMonitoring: cfg.K8s.EnableDetailedMonitoring != nil && *cfg.K8s.EnableDetailedMonitoring,
 		EndpointSubnetIDs:        endpointSubnetIDs,
+		NodePoolsHCL:             generator.HCLNodePools(cfg.K8s.EffectiveNodePools()),
 	}
 
 	if err := tpl.Execute(f, data); err != nil {
@@ -0,0 +1,70 @@
+package k8sworkers
+
+import "github.com/PydaVi/brainctl/internal/blueprints/scaffold"
+
+// appYAMLTemplate é o contrato mínimo do lab kubeadm: 1 control-plane + 2 workers,
+// acesso via SSM e NAT Gateway criado pelo blueprint.
+const appYAMLTemplate = `workload:
+  type: k8s-workers
+  version: v1
+
+terraform:
+  backend:
+    bucket: {{ quote .StateBucket }}
+    region: {{ quote .Region }}
+    use_lockfile: true
+
+app:
+  name: {{ .Name }}
+  environment: {{ .Environment }}
+  region: {{ .Region }}
+{{- if .Tags }}
+
+tags:
+{{- range $k, $v := .Tags }}
+  {{ $k }}: {{ quote $v }}
+{{- end }}
+{{- end }}
+
+infrastructure:
+  vpc_id: {{ .VpcID }}
+  vpc_cidr: {{ quote .VpcCIDR }}
+  subnet_id: {{ first .SubnetIDs }}
+  allowed_egress_cidrs:
+    - {{ quote .VpcCIDR }}
+
+k8s:
+  control_plane_instance_type: t3.medium
+  control_plane_count: 1 # ímpar; 3 = HA com etcd empilhado atrás de NLB interno (usa infrastructure.subnet_ids)
+  worker_instance_type: t3.medium
+  worker_count: 2 # shorthand de um node pool "default"; para vários pools use node_pools
+  # node_pools:
+  #   - name: general
+  #     min_size: 2
+  #     max_size: 4
+  #   - name: batch
+  #     instance_types: [m5.large, m6i.large]
+  #     min_size: 0
+  #     max_size: 6
+  #     spot: true
+  #     labels: {workload: batch}
+  #     taints:
+  #       - {key: dedicated, value: batch, effect: NoSchedule}
+  kubernetes_version: "1.30"
+  pod_cidr: "10.244.0.0/16"
+  admin_cidr: {{ quote .VpcCIDR }}
+  enable_nat_gateway: true
+  public_subnet_cidr: "10.0.254.0/24" # usado apenas quando public_subnet_id estiver vazio
+  enable_ssm: true
+  enable_ssm_vpc_endpoints: true
+`
+
+// Scaffold gera os arquivos de uma nova stack k8s-workers. O blueprint não usa
+// security-groups/ nem user data customizado, então só o app.yaml é criado.
+func Scaffold(p scaffold.Params) ([]scaffold.File, error) {
+	appYAML, err := scaffold.Render("app.yaml", appYAMLTemplate, p)
+	if err != nil {
+		return nil, err
+	}
+	return []scaffold.File{{Path: "app.yaml", Content: appYAML}}, nil
+}
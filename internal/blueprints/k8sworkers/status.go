@@ -0,0 +1,54 @@
+package k8sworkers
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/PydaVi/brainctl/internal/outputs"
+)
+
+// RenderStatus renderiza control-plane, workers e comandos de acesso do cluster kubeadm.
+func RenderStatus(w io.Writer, v map[string]any) {
+	fmt.Fprintln(w, "Resources:")
+
+	// Com HA (control_plane_count > 1) lista todos os nós atrás do endpoint do NLB.
+	if ids := outputs.AsStringSlice(v["control_plane_instance_ids"]); len(ids) > 1 {
+		ips := outputs.AsStringSlice(v["control_plane_private_ips"])
+		fmt.Fprintf(w, "  CONTROL PLANE (%d, etcd empilhado)\n", len(ids))
+		fmt.Fprintf(w, "    endpoint   : %s\n", outputs.AsString(v["control_plane_endpoint"], "(none)"))
+		for i, id := range ids {
+			ip := "(none)"
+			if i < len(ips) {
+				ip = ips[i]
+			}
+			fmt.Fprintf(w, "    - %s (%s)\n", id, ip)
+		}
+	} else {
+		fmt.Fprintln(w, "  CONTROL PLANE")
+		fmt.Fprintf(w, "    instance_id: %s\n", outputs.AsString(v["control_plane_instance_id"], "(none)"))
+		fmt.Fprintf(w, "    private_ip : %s\n", outputs.AsString(v["control_plane_private_ip"], "(none)"))
+		fmt.Fprintf(w, "    public_ip  : %s\n", outputs.AsString(v["control_plane_public_ip"], "(none)"))
+		fmt.Fprintf(w, "    public_dns : %s\n", outputs.AsString(v["control_plane_public_dns"], "(none)"))
+	}
+	if params := outputs.AsStringSlice(v["join_parameter_names"]); len(params) > 0 {
+		fmt.Fprintf(w, "    join (SSM) : %s\n", strings.Join(params, ", "))
+	}
+	fmt.Fprintln(w)
+
+	workers := outputs.AsStringSlice(v["worker_instance_ids"])
+	fmt.Fprintf(w, "  WORKERS (%d)\n", len(workers))
+	if len(workers) == 0 {
+		fmt.Fprintln(w, "    instances  : (none)")
+	} else {
+		for _, id := range workers {
+			fmt.Fprintf(w, "    - %s\n", id)
+		}
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "  ACCESS")
+	fmt.Fprintf(w, "    kubeconfig : %s\n", outputs.AsString(v["kubeconfig_retrieve_instructions"], "(none)"))
+	fmt.Fprintf(w, "    validate   : %s\n", outputs.AsString(v["validation_command"], "(none)"))
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,46 @@
+package k8sworkers
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRenderStatus(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	RenderStatus(&buf, map[string]any{
+		"control_plane_instance_id":        "i-cp",
+		"control_plane_public_dns":         "ec2-1-2-3-4.compute.amazonaws.com",
+		"worker_instance_ids":              []any{"i-w1", "i-w2"},
+		"kubeconfig_retrieve_instructions": "scp ubuntu@host:/home/ubuntu/.kube/config ./kubeconfig",
+		"validation_command":               "ssh ubuntu@host 'kubectl get nodes -o wide'",
+	})
+
+	out := buf.String()
+	for _, want := range []string{"i-cp", "WORKERS (2)", "- i-w2", "kubectl get nodes -o wide", "./kubeconfig"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in status output:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderStatusHAControlPlane(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	RenderStatus(&buf, map[string]any{
+		"control_plane_instance_ids": []any{"i-cp1", "i-cp2", "i-cp3"},
+		"control_plane_private_ips":  []any{"10.0.1.10", "10.0.2.10", "10.0.3.10"},
+		"control_plane_endpoint":     "lab-dev-cp-123.elb.us-east-1.amazonaws.com:6443",
+		"join_parameter_names":       []any{"/brainctl/lab-dev/k8s/worker-join", "/brainctl/lab-dev/k8s/control-plane-join"},
+	})
+
+	out := buf.String()
+	for _, want := range []string{"CONTROL PLANE (3, etcd empilhado)", "elb.us-east-1.amazonaws.com:6443", "- i-cp3 (10.0.3.10)", "/brainctl/lab-dev/k8s/worker-join"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in status output:\n%s", want, out)
+		}
+	}
+}
@@ -2,11 +2,14 @@ package blueprints
 
 import (
 	"fmt"
+	"io"
 	"sort"
 
 	"github.com/PydaVi/brainctl/internal/blueprints/ec2app"
 	"github.com/PydaVi/brainctl/internal/blueprints/k8sworkers"
+	"github.com/PydaVi/brainctl/internal/blueprints/scaffold"
 	"github.com/PydaVi/brainctl/internal/config"
+	"github.com/PydaVi/brainctl/internal/migrate"
 )
 
 const (
@@ -20,20 +23,34 @@ type Definition struct {
 	Version     string
 	Description string
 	Generate    func(wsDir string, cfg *config.AppConfig) error
+	// RenderStatus imprime os recursos do blueprint a partir dos outputs do terraform.
+	RenderStatus func(w io.Writer, outputs map[string]any)
+	// Scaffold gera os arquivos de uma nova stack para `brainctl init`.
+	Scaffold func(p scaffold.Params) ([]scaffold.File, error)
+	// Migrations levam contratos de versões anteriores até esta (ver `brainctl migrate`).
+	Migrations []migrate.Step
+	// Deprecations geram avisos em plan/apply e são corrigidas pelo migrate.
+	Deprecations []migrate.Deprecation
 }
 
 var catalog = []Definition{
 	{
-		Type:        "ec2-app",
-		Version:     "v1",
-		Description: "EC2 app com opções de ALB/ASG, observabilidade e recovery",
-		Generate:    ec2app.Generate,
+		Type:         "ec2-app",
+		Version:      "v1",
+		Description:  "EC2 app com opções de ALB/ASG, observabilidade e recovery",
+		Generate:     ec2app.Generate,
+		RenderStatus: ec2app.RenderStatus,
+		Scaffold:     ec2app.Scaffold,
+		Migrations:   ec2app.Migrations,
+		Deprecations: ec2app.Deprecations,
 	},
 	{
-		Type:        "k8s-workers",
-		Version:     "v1",
-		Description: "Kubernetes kubeadm em EC2 (1 control-plane + N workers) para laboratório",
-		Generate:    k8sworkers.Generate,
+		Type:         "k8s-workers",
+		Version:      "v1",
+		Description:  "Kubernetes kubeadm em EC2 (1 control-plane + N workers) para laboratório",
+		Generate:     k8sworkers.Generate,
+		RenderStatus: k8sworkers.RenderStatus,
+		Scaffold:     k8sworkers.Scaffold,
 	},
 }
 
@@ -66,3 +83,24 @@ func Resolve(workloadType, version string) (Definition, error) {
 	}
 	return Definition{}, fmt.Errorf("unsupported workload %q version %q", workloadType, version)
 }
+
+// MigrationTarget monta a visão do migrate para o tipo de workload: versão mais recente
+// do catálogo, passos de todas as versões e obsolescências da versão mais recente.
+func MigrationTarget(workloadType string) (migrate.Blueprint, error) {
+	if workloadType == "" {
+		workloadType = DefaultWorkloadType
+	}
+	out := migrate.Blueprint{Type: workloadType}
+	for _, b := range List() {
+		if b.Type != workloadType {
+			continue
+		}
+		out.Latest = b.Version
+		out.Steps = append(out.Steps, b.Migrations...)
+		out.Deprecations = b.Deprecations
+	}
+	if out.Latest == "" {
+		return migrate.Blueprint{}, fmt.Errorf("unsupported workload %q", workloadType)
+	}
+	return out, nil
+}
@@ -1,6 +1,14 @@
 package blueprints
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/PydaVi/brainctl/internal/blueprints/scaffold"
+	"github.com/PydaVi/brainctl/internal/config"
+)
 
 func TestResolve_K8sWorkers(t *testing.T) {
 	t.Parallel()
@@ -13,3 +21,57 @@ func TestResolve_K8sWorkers(t *testing.T) {
 		t.Fatalf("unexpected type: %s", bp.Type)
 	}
 }
+
+func TestCatalogRendersStatus(t *testing.T) {
+	t.Parallel()
+
+	for _, bp := range List() {
+		if bp.RenderStatus == nil {
+			t.Fatalf("blueprint %s@%s has no status renderer", bp.Type, bp.Version)
+		}
+	}
+}
+
+func TestCatalogScaffoldsValidContracts(t *testing.T) {
+	t.Parallel()
+
+	p := scaffold.Params{
+		Name:           "billing",
+		Environment:    "dev",
+		Region:         "us-east-1",
+		StateBucket:    "brainctl-state",
+		VpcID:          "vpc-123",
+		VpcCIDR:        "10.0.0.0/16",
+		SubnetIDs:      []string{"subnet-a", "subnet-b"},
+		SecurityGroups: true,
+		UserData:       true,
+		Tags:           map[string]string{"owner": "time-billing"},
+	}
+	for _, bp := range List() {
+		if bp.Scaffold == nil {
+			t.Fatalf("blueprint %s@%s has no scaffold", bp.Type, bp.Version)
+		}
+		files, err := bp.Scaffold(p)
+		if err != nil {
+			t.Fatalf("scaffold %s: %v", bp.Type, err)
+		}
+		if len(files) == 0 || files[0].Path != "app.yaml" {
+			t.Fatalf("scaffold %s must start with app.yaml, got %+v", bp.Type, files)
+		}
+
+		var cfg config.AppConfig
+		if err := yaml.Unmarshal(files[0].Content, &cfg); err != nil {
+			t.Fatalf("scaffold %s: invalid yaml: %v\n%s", bp.Type, err, files[0].Content)
+		}
+		// o user data é resolvido a partir do disco; aqui só validamos o contrato
+		if strings.HasPrefix(cfg.EC2.UserData, "file://") {
+			cfg.EC2.UserData = "Write-Output ok"
+		}
+		if err := cfg.Validate(); err != nil {
+			t.Fatalf("scaffold %s: invalid contract: %v\n%s", bp.Type, err, files[0].Content)
+		}
+		if cfg.Workload.Type != bp.Type || cfg.App.Name != "billing" || cfg.Tags["owner"] != "time-billing" {
+			t.Fatalf("scaffold %s: unexpected contract %+v", bp.Type, cfg.Workload)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Package scaffold define os tipos compartilhados pelos templates de `brainctl init`.
+// Cada blueprint gera os próprios arquivos; este pacote evita que eles importem o registry.
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Params são os valores coletados por `brainctl init` (flags ou prompts).
+type Params struct {
+	Name        string
+	Environment string
+	Region      string
+	StateBucket string
+	VpcID       string
+	VpcCIDR     string
+	SubnetIDs   []string
+	// OS do workload quando o blueprint permite escolher (vazio = default do blueprint).
+	OS string
+	// SecurityGroups gera security-groups/<grupo>.yaml vazios (quando o blueprint suporta).
+	SecurityGroups bool
+	// UserData gera o stub de user data referenciado pelo app.yaml (quando o blueprint suporta).
+	UserData bool
+	// Tags vira o bloco tags do contrato (ex: as chaves exigidas por policies/tags.yaml).
+	Tags map[string]string
+}
+
+// File é um arquivo da nova stack, com caminho relativo ao diretório da stack.
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// Missing lista os valores obrigatórios ainda vazios, na ordem em que aparecem no contrato.
+func (p Params) Missing() []string {
+	var missing []string
+	for _, f := range []struct {
+		name  string
+		empty bool
+	}{
+		{"name", p.Name == ""},
+		{"env", p.Environment == ""},
+		{"region", p.Region == ""},
+		{"state-bucket", p.StateBucket == ""},
+		{"vpc-id", p.VpcID == ""},
+		{"vpc-cidr", p.VpcCIDR == ""},
+		{"subnet-ids", len(p.SubnetIDs) == 0},
+	} {
+		if f.empty {
+			missing = append(missing, f.name)
+		}
+	}
+	return missing
+}
+
+// Render executa um template de scaffold com os Params.
+func Render(name, text string, p Params) ([]byte, error) {
+	tmpl, err := template.New(name).Funcs(template.FuncMap{
+		"quote": func(s string) string { return fmt.Sprintf("%q", s) },
+		"first": func(s []string) string { return s[0] },
+		"join":  strings.Join,
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse scaffold template %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, p); err != nil {
+		return nil, fmt.Errorf("render scaffold template %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
@@ -17,6 +17,7 @@ type tfPlan struct {
 type tfPlanResourceChange struct {
 	Address string `json:"address"`
 	Type    string `json:"type"`
+	Name    string `json:"name"`
 	Change  struct {
 		Actions []string `json:"actions"`
 	} `json:"change"`
@@ -40,6 +41,35 @@ func detectModifiedInstances(planJSON []byte) ([]string, error) {
 	return out, nil
 }
 
+// detectFleetChanges lista mudanças que afetam a frota do ASG app do ec2-app:
+// update/replace do launch template ou replace do próprio grupo. Sem instance
+// refresh elas só valem para instâncias novas; com rollout, substituem todas.
+// Outros ASGs (ex: node pools do k8s-workers) não usam app_scaling.rollout.
+func detectFleetChanges(planJSON []byte) ([]string, error) {
+	var p tfPlan
+	if err := json.Unmarshal(planJSON, &p); err != nil {
+		return nil, err
+	}
+
+	var out []string
+	for _, rc := range p.ResourceChanges {
+		if rc.Name != "app" {
+			continue
+		}
+		switch rc.Type {
+		case "aws_launch_template":
+			if containsAction(rc.Change.Actions, "update") || isReplaceAction(rc.Change.Actions) {
+				out = append(out, rc.Address)
+			}
+		case "aws_autoscaling_group":
+			if isReplaceAction(rc.Change.Actions) {
+				out = append(out, rc.Address)
+			}
+		}
+	}
+	return out, nil
+}
+
 func containsAction(actions []string, target string) bool {
 	for _, a := range actions {
 		if a == target {
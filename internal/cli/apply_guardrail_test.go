@@ -64,3 +64,25 @@ func TestIsApprovedValue(t *testing.T) {
 		}
 	}
 }
+
+func TestDetectFleetChanges(t *testing.T) {
+	t.Parallel()
+
+	raw := []byte(`{
+  "resource_changes": [
+    {"address":"aws_launch_template.app[0]","type":"aws_launch_template","name":"app","change":{"actions":["update"]}},
+    {"address":"aws_autoscaling_group.app[0]","type":"aws_autoscaling_group","name":"app","change":{"actions":["update"]}},
+    {"address":"aws_instance.app[0]","type":"aws_instance","name":"app","change":{"actions":["update"]}},
+    {"address":"aws_launch_template.workers[\"default\"]","type":"aws_launch_template","name":"workers","change":{"actions":["update"]}},
+    {"address":"aws_autoscaling_group.workers[\"default\"]","type":"aws_autoscaling_group","name":"workers","change":{"actions":["delete","create"]}}
+  ]
+}`)
+
+	got, err := detectFleetChanges(raw)
+	if err != nil {
+		t.Fatalf("detectFleetChanges failed: %v", err)
+	}
+	if len(got) != 1 || got[0] != "aws_launch_template.app[0]" {
+		t.Fatalf("expected only the launch template, got %v", got)
+	}
+}
@@ -3,53 +3,169 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 
+	"github.com/PydaVi/brainctl/internal/config"
 	"github.com/PydaVi/brainctl/internal/cost"
 )
 
 func newCostCommand(opts *RuntimeOptions) *cobra.Command {
+	runStack := withRuntime(*opts, false, costRun)
 	cmd := &cobra.Command{
 		Use:   "cost",
 		Short: "Estimate infra base cost using Infracost (service/hour and service/month)",
-		RunE: withRuntime(*opts, false, func(cmd *cobra.Command, args []string, ctx *runtimeContext) error {
-			if _, err := exec.LookPath("infracost"); err != nil {
-				return fmt.Errorf("infracost não encontrado no PATH. Instale em https://www.infracost.io/docs/ ou via curl -fsSL https://raw.githubusercontent.com/infracost/infracost/master/scripts/install.sh | sh")
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if root, _ := cmd.Flags().GetString("all"); root != "" {
+				return costRollupRun(cmd, root)
 			}
+			return runStack(cmd, args)
+		},
+	}
 
-			if err := ctx.Runner.Init(); err != nil {
-				return err
-			}
+	applyCommonFlags(cmd, opts)
+	cmd.Flags().Bool("json", false, "Print report in JSON format")
+	cmd.Flags().Bool("diff", false, "Compare the planned change against the deployed state (per-service deltas)")
+	cmd.Flags().Bool("offline", false, "Estimate from the contract using the embedded price table (no infracost/API needed)")
+	cmd.Flags().String("all", "", "Estimate every stack (app.yaml) under this directory and print a consolidated rollup")
+	cmd.Flags().String("group-by", "environment", "Rollup dimension with --all: app|environment|workload")
+	cmd.Flags().String("csv", "", "With --all, also write the rollup as CSV to this file")
+	return cmd
+}
 
-			report, err := cost.EstimateInfraBase(ctx.WSDir)
+func costRun(cmd *cobra.Command, args []string, ctx *runtimeContext) error {
+	asJSON, _ := cmd.Flags().GetBool("json")
+	showDiff, _ := cmd.Flags().GetBool("diff")
+
+	if offline, _ := cmd.Flags().GetBool("offline"); offline {
+		if showDiff {
+			return fmt.Errorf("--diff requires infracost and cannot be combined with --offline")
+		}
+		report, err := cost.EstimateOffline(ctx.Config.App)
+		if err != nil {
+			return err
+		}
+		return printCost(report, asJSON)
+	}
+
+	if err := requireInfracost(); err != nil {
+		return err
+	}
+
+	if err := ctx.Runner.Init(); err != nil {
+		return err
+	}
+
+	if showDiff {
+		diff, err := estimateCostDiff(ctx)
+		if err != nil {
+			return err
+		}
+		if asJSON {
+			b, err := json.MarshalIndent(diff, "", "  ")
 			if err != nil {
 				return err
 			}
+			fmt.Println(string(b))
+			return nil
+		}
+		printCostDiff(diff)
+		return nil
+	}
 
-			asJSON, _ := cmd.Flags().GetBool("json")
-			if asJSON {
-				b, err := json.MarshalIndent(report, "", "  ")
-				if err != nil {
-					return err
-				}
-				fmt.Println(string(b))
-				return nil
-			}
+	report, err := estimateInfraBase(ctx.Config.App, ctx.WSDir)
+	if err != nil {
+		return err
+	}
+	return printCost(report, asJSON)
+}
 
-			printCostReport(report)
-			return nil
-		}),
+func requireInfracost() error {
+	if _, err := exec.LookPath("infracost"); err != nil {
+		return fmt.Errorf("infracost não encontrado no PATH. Instale em https://www.infracost.io/docs/ ou via curl -fsSL https://raw.githubusercontent.com/infracost/infracost/master/scripts/install.sh | sh (ou use --offline para a tabela de preços embarcada)")
 	}
+	return nil
+}
 
-	applyCommonFlags(cmd, opts)
-	cmd.Flags().Bool("json", false, "Print report in JSON format")
-	return cmd
+// estimateInfraBase roda o Infracost no workspace já inicializado, com o usage file do contrato.
+func estimateInfraBase(cfg *config.AppConfig, wsDir string) (*cost.Report, error) {
+	usageFile, err := cost.WriteUsageFile(wsDir, cfg)
+	if err != nil {
+		return nil, err
+	}
+	report, err := cost.EstimateInfraBase(wsDir, usageFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := cost.ApplySnapshotUsage(report, cfg); err != nil {
+		return nil, err
+	}
+	if report.SpotSavingsMonthly, err = cost.SpotSavings(cfg); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// estimateCostDiff gera um plano, exporta o JSON no workspace e deixa o Infracost
+// comparar o state atual (pastBreakdown) com o proposto.
+func estimateCostDiff(ctx *runtimeContext) (*cost.DiffReport, error) {
+	planFile := "brainctl.cost.tfplan"
+	if err := ctx.Runner.PlanOut(planFile); err != nil {
+		return nil, err
+	}
+	planJSON, err := ctx.Runner.ShowPlanJSON(planFile)
+	if err != nil {
+		return nil, err
+	}
+	return estimateCostDiffFromPlanJSON(ctx, planJSON)
+}
+
+// estimateCostDiffFromPlanJSON grava o plan JSON e o usage file no workspace para o Infracost ler.
+func estimateCostDiffFromPlanJSON(ctx *runtimeContext, planJSON []byte) (*cost.DiffReport, error) {
+	planJSONPath := filepath.Join(ctx.WSDir, "brainctl.cost.plan.json")
+	if err := os.WriteFile(planJSONPath, planJSON, 0o644); err != nil {
+		return nil, fmt.Errorf("write plan json: %w", err)
+	}
+	usageFile, err := cost.WriteUsageFile(ctx.WSDir, ctx.Config.App)
+	if err != nil {
+		return nil, err
+	}
+	diff, err := cost.EstimatePlanDiff(planJSONPath, usageFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := cost.ApplySnapshotUsage(&diff.Baseline, ctx.Config.App); err != nil {
+		return nil, err
+	}
+	if err := cost.ApplySnapshotUsage(&diff.Planned, ctx.Config.App); err != nil {
+		return nil, err
+	}
+	diff.Delta = *cost.DiffReports(&diff.Baseline, &diff.Planned)
+	return diff, nil
+}
+
+func printCost(r *cost.Report, asJSON bool) error {
+	if asJSON {
+		b, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+	printCostReport(r)
+	return nil
 }
 
 func printCostReport(r *cost.Report) {
-	fmt.Println("== brainctl cost (infra base) ==")
+	if r.PriceTable != "" {
+		fmt.Printf("== brainctl cost (infra base, offline price table %s) ==\n", r.PriceTable)
+	} else {
+		fmt.Println("== brainctl cost (infra base) ==")
+	}
 	fmt.Println("Service              Hourly (USD)    Monthly (USD)")
 	fmt.Println("----------------------------------------------------")
 	for _, s := range r.Services {
@@ -57,4 +173,25 @@ func printCostReport(r *cost.Report) {
 	}
 	fmt.Println("----------------------------------------------------")
 	fmt.Printf("%-20s %12.4f %14.2f\n", "TOTAL", r.TotalHourly, r.TotalMonthly)
+	if r.SpotSavingsMonthly > 0 {
+		fmt.Printf("Spot savings vs on-demand (est.): %.2f USD/month\n", r.SpotSavingsMonthly)
+	}
+}
+
+func printCostDiff(d *cost.DiffReport) {
+	fmt.Println("== brainctl cost (diff: planned vs deployed) ==")
+	fmt.Printf("%-20s %12s %14s\n", "", "Hourly (USD)", "Monthly (USD)")
+	fmt.Printf("%-20s %12.4f %14.2f\n", "Baseline", d.Baseline.TotalHourly, d.Baseline.TotalMonthly)
+	fmt.Printf("%-20s %12.4f %14.2f\n", "Planned", d.Planned.TotalHourly, d.Planned.TotalMonthly)
+	fmt.Println()
+	fmt.Println("Delta by service     Hourly (USD)    Monthly (USD)")
+	fmt.Println("----------------------------------------------------")
+	if len(d.Delta.Services) == 0 {
+		fmt.Println("(no cost change)")
+	}
+	for _, s := range d.Delta.Services {
+		fmt.Printf("%-20s %+12.4f %+14.2f\n", s.Service, s.Hourly, s.Monthly)
+	}
+	fmt.Println("----------------------------------------------------")
+	fmt.Printf("%-20s %+12.4f %+14.2f\n", "TOTAL DELTA", d.Delta.TotalHourly, d.Delta.TotalMonthly)
 }
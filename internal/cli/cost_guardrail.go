@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/PydaVi/brainctl/internal/cost"
+)
+
+const costBudgetApprovalEnv = "BRAINCTL_COST_BUDGET_APPROVED"
+
+// evaluatePlanBudget estima o custo do plano e compara com o orçamento do contrato.
+// Retorna nil quando o contrato não define orçamento para o ambiente. Sem Infracost no PATH,
+// usa a estimativa offline do contrato (sem baseline, então max_delta não é avaliado).
+func evaluatePlanBudget(ctx *runtimeContext, planJSON []byte) (*cost.BudgetResult, error) {
+	cfg := ctx.Config.App
+	if !cfg.Cost.BudgetEnabled(cfg.App.Environment) {
+		return nil, nil
+	}
+	if _, err := exec.LookPath("infracost"); err != nil {
+		report, offlineErr := cost.EstimateOffline(cfg)
+		if offlineErr != nil {
+			return nil, fmt.Errorf("infracost não encontrado no PATH e estimativa offline falhou; não foi possível avaliar cost.budget: %w", offlineErr)
+		}
+		fmt.Printf("[cost] infracost não encontrado; usando tabela de preços offline %s (max_delta não avaliado)\n", report.PriceTable)
+		return cost.EvaluateBudget(&cost.DiffReport{Planned: *report}, cfg.Cost.EffectiveBudget(cfg.App.Environment), 0), nil
+	}
+
+	diff, err := estimateCostDiffFromPlanJSON(ctx, planJSON)
+	if err != nil {
+		return nil, err
+	}
+	return cost.EvaluateBudget(diff, cfg.Cost.EffectiveBudget(cfg.App.Environment), cfg.Cost.MaxDeltaMonthlyUSD), nil
+}
+
+func printBudgetResult(r *cost.BudgetResult) {
+	fmt.Printf("\n[cost] projetado: $%.2f/mês (delta %+.2f)", r.ProjectedMonthly, r.DeltaMonthly)
+	if r.BudgetMonthly > 0 {
+		fmt.Printf(" | orçamento: $%.2f", r.BudgetMonthly)
+	}
+	if r.MaxDeltaMonthly > 0 {
+		fmt.Printf(" | delta máximo: $%.2f", r.MaxDeltaMonthly)
+	}
+	fmt.Println()
+	for _, v := range r.Violations {
+		fmt.Printf("[cost] %s\n", v)
+	}
+}
+
+// confirmCostBudget segue o mesmo padrão de confirmInstanceModify: variável de ambiente,
+// confirmação interativa ou falha em modo não interativo. Com budget_action=block não há confirmação.
+func confirmCostBudget(r *cost.BudgetResult, action string) (bool, error) {
+	printBudgetResult(r)
+
+	if action == "block" {
+		return false, fmt.Errorf("apply bloqueado pelo orçamento (cost.budget_action=block); ajuste o contrato/orçamento ou execute com --force-cost-budget")
+	}
+
+	if isApprovedValue(os.Getenv(costBudgetApprovalEnv)) {
+		fmt.Printf("[guardrail] Aprovação automática recebida via variável de ambiente %s.\n", costBudgetApprovalEnv)
+		return true, nil
+	}
+
+	if !isInteractiveInput() {
+		return false, fmt.Errorf("guardrail de custo requer confirmação manual, mas stdin não é interativo; execute com --force-cost-budget ou defina %s=SIM", costBudgetApprovalEnv)
+	}
+
+	fmt.Print("[guardrail] O plano excede o orçamento. Digite 'SIM' para continuar: ")
+
+	reader := bufio.NewReader(os.Stdin)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return false, err
+	}
+	return strings.EqualFold(strings.TrimSpace(line), "SIM"), nil
+}
@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/PydaVi/brainctl/internal/cost"
+)
+
+func TestConfirmCostBudget_Block(t *testing.T) {
+	t.Setenv(costBudgetApprovalEnv, "SIM")
+
+	r := &cost.BudgetResult{BudgetMonthly: 100, ProjectedMonthly: 180, Violations: []string{"over budget"}}
+	ok, err := confirmCostBudget(r, "block")
+	if ok || err == nil {
+		t.Fatalf("expected block action to ignore env approval, got ok=%t err=%v", ok, err)
+	}
+}
+
+func TestConfirmCostBudget_AutoApprovedByEnv(t *testing.T) {
+	t.Setenv(costBudgetApprovalEnv, "true")
+
+	r := &cost.BudgetResult{BudgetMonthly: 100, ProjectedMonthly: 180, Violations: []string{"over budget"}}
+	ok, err := confirmCostBudget(r, "confirm")
+	if err != nil || !ok {
+		t.Fatalf("expected env approval, got ok=%t err=%v", ok, err)
+	}
+}
@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/PydaVi/brainctl/internal/cost"
+	"github.com/PydaVi/brainctl/internal/terragrunt"
+)
+
+// costRollupRun estima todas as stacks sob root e consolida por app, ambiente ou workload.
+// Uma stack que falha não interrompe as demais: o erro aparece no rollup e no exit code.
+func costRollupRun(cmd *cobra.Command, root string) error {
+	asJSON, _ := cmd.Flags().GetBool("json")
+	offline, _ := cmd.Flags().GetBool("offline")
+	groupBy, _ := cmd.Flags().GetString("group-by")
+	csvPath, _ := cmd.Flags().GetString("csv")
+	if diff, _ := cmd.Flags().GetBool("diff"); diff {
+		return fmt.Errorf("--diff cannot be combined with --all")
+	}
+	if err := cost.ValidateGroupBy(groupBy); err != nil {
+		return err
+	}
+
+	base := optionsFromFlags(cmd)
+	if !offline {
+		if err := requireInfracost(); err != nil {
+			return err
+		}
+	}
+
+	dirs, err := findStackDirs(root, filepath.Base(base.File))
+	if err != nil {
+		return err
+	}
+	if len(dirs) == 0 {
+		return fmt.Errorf("no stacks (%s) found under %s", filepath.Base(base.File), root)
+	}
+
+	stacks := make([]cost.StackCost, 0, len(dirs))
+	failed := 0
+	for _, dir := range dirs {
+		opts := RuntimeOptions{File: filepath.Base(base.File), StackDir: dir, SecurityGroupsDir: base.SecurityGroupsDir}
+		sc := estimateStack(opts, offline)
+		if sc.Error != "" {
+			failed++
+		}
+		stacks = append(stacks, sc)
+	}
+
+	rollup, err := cost.BuildRollup(stacks, groupBy)
+	if err != nil {
+		return err
+	}
+
+	if csvPath != "" {
+		f, err := os.Create(csvPath)
+		if err != nil {
+			return fmt.Errorf("create csv: %w", err)
+		}
+		if err := rollup.WriteCSV(f); err != nil {
+			f.Close()
+			return fmt.Errorf("write csv: %w", err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("write csv: %w", err)
+		}
+	}
+
+	if asJSON {
+		b, err := json.MarshalIndent(rollup, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	} else {
+		printCostRollup(rollup)
+		if csvPath != "" {
+			fmt.Printf("\nCSV: %s\n", csvPath)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d stack(s) could not be estimated", failed, len(stacks))
+	}
+	return nil
+}
+
+// estimateStack carrega o contrato da stack e estima o custo, registrando o erro da etapa.
+func estimateStack(opts RuntimeOptions, offline bool) cost.StackCost {
+	sc := cost.StackCost{StackDir: opts.StackDir}
+	rc, err := LoadRuntimeConfig(opts)
+	if err != nil {
+		sc.Error = err.Error()
+		return sc
+	}
+	cfg := rc.App
+	sc.App, sc.Environment, sc.Workload = cfg.App.Name, cfg.App.Environment, cfg.Workload.Type
+
+	var report *cost.Report
+	if offline {
+		report, err = cost.EstimateOffline(cfg)
+	} else {
+		report, err = func() (*cost.Report, error) {
+			wsDir, err := rc.PrepareWorkspace()
+			if err != nil {
+				return nil, err
+			}
+			if err := terragrunt.NewRunner(wsDir).Init(); err != nil {
+				return nil, err
+			}
+			return estimateInfraBase(cfg, wsDir)
+		}()
+	}
+	if err != nil {
+		sc.Error = err.Error()
+		return sc
+	}
+	sc.Report = report
+	return sc
+}
+
+// findStackDirs retorna os diretórios sob root que contêm o contrato, ignorando diretórios
+// ocultos e subdiretórios de uma stack (security-groups/ também usa app.yaml por tipo de SG).
+func findStackDirs(root, contractName string) ([]string, error) {
+	var dirs []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() == contractName {
+			dirs = append(dirs, filepath.Dir(path))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan stacks under %s: %w", root, err)
+	}
+
+	sort.Strings(dirs)
+	var stacks []string
+	for _, dir := range dirs {
+		if n := len(stacks); n > 0 && strings.HasPrefix(dir, stacks[n-1]+string(filepath.Separator)) {
+			continue
+		}
+		stacks = append(stacks, dir)
+	}
+	return stacks, nil
+}
+
+func printCostRollup(r *cost.Rollup) {
+	fmt.Printf("== brainctl cost (rollup by %s, %d stacks) ==\n", r.GroupBy, len(r.Stacks))
+	fmt.Printf("%-28s %12s %14s\n", strings.ToUpper(r.GroupBy)+" / Service", "Hourly (USD)", "Monthly (USD)")
+	fmt.Println("--------------------------------------------------------")
+	for _, g := range r.Groups {
+		fmt.Printf("%-28s %12.4f %14.2f\n", fmt.Sprintf("%s (%d stacks)", g.Key, len(g.Stacks)), g.TotalHourly, g.TotalMonthly)
+		for _, s := range g.Services {
+			fmt.Printf("  %-26s %12.4f %14.2f\n", s.Service, s.Hourly, s.Monthly)
+		}
+	}
+	fmt.Println("--------------------------------------------------------")
+	fmt.Printf("%-28s %12.4f %14.2f\n", "TOTAL", r.TotalHourly, r.TotalMonthly)
+
+	for _, s := range r.Stacks {
+		if s.Error != "" {
+			fmt.Printf("\n[erro] %s: %s\n", s.StackDir, s.Error)
+		}
+	}
+}
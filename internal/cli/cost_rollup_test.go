@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFindStackDirs(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	for _, p := range []string{
+		"billing/dev/app.yaml",
+		"billing/dev/security-groups/app.yaml",
+		"billing/prod/app.yaml",
+		"lab/dev/app.yaml",
+		".cache/app.yaml",
+		"docs/README.md",
+	} {
+		path := filepath.Join(root, p)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	got, err := findStackDirs(root, "app.yaml")
+	if err != nil {
+		t.Fatalf("findStackDirs: %v", err)
+	}
+	want := []string{
+		filepath.Join(root, "billing/dev"),
+		filepath.Join(root, "billing/prod"),
+		filepath.Join(root, "lab/dev"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected stacks:\n got=%v\nwant=%v", got, want)
+	}
+}
+
+func TestCostRollupRunValidatesGroupByFirst(t *testing.T) {
+	t.Parallel()
+
+	cmd := newCostCommand(&RuntimeOptions{File: "app.yaml"})
+	if err := cmd.Flags().Set("group-by", "team"); err != nil {
+		t.Fatalf("set flag: %v", err)
+	}
+	// sem stacks nem infracost: o erro precisa ser o do --group-by
+	err := costRollupRun(cmd, t.TempDir())
+	if err == nil || err.Error() != `invalid --group-by "team" (allowed: app, environment, workload)` {
+		t.Fatalf("expected group-by error before estimating stacks, got: %v", err)
+	}
+}
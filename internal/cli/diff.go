@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/PydaVi/brainctl/internal/config"
+)
+
+// contractChange é uma mudança efetiva anotada com o impacto esperado.
+// Em campos secretos (config.SecretFields) Old/New ficam vazios e Redacted indica a mudança.
+type contractChange struct {
+	config.FieldChange `yaml:",inline"`
+	Impact             config.Impact `json:"impact" yaml:"impact"`
+	Redacted           bool          `json:"redacted,omitempty" yaml:"redacted,omitempty"`
+}
+
+// contractDiff é a saída de `brainctl diff`.
+type contractDiff struct {
+	Old     string           `json:"old"`
+	New     string           `json:"new"`
+	Changes []contractChange `json:"changes"`
+}
+
+func newDiffCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <old.yaml> <new.yaml> | diff --git-ref <ref> <app.yaml>",
+		Short: "Compare two contracts by effective (defaulted) values and annotate the expected infra impact",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			gitRef, _ := cmd.Flags().GetString("git-ref")
+			format, _ := cmd.Flags().GetString("format")
+			exitCode, _ := cmd.Flags().GetBool("exit-code")
+			if format != "text" && format != "json" {
+				return fmt.Errorf("invalid --format %q (allowed: text, json)", format)
+			}
+
+			var oldName, newName string
+			var oldData, newData []byte
+			var err error
+			switch {
+			case gitRef != "" && len(args) == 1:
+				oldName, newName = gitRef+":"+args[0], args[0]
+				if oldData, err = gitShowFile(gitRef, args[0]); err != nil {
+					return err
+				}
+			case gitRef == "" && len(args) == 2:
+				oldName, newName = args[0], args[1]
+				if oldData, err = os.ReadFile(oldName); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("usage: brainctl diff <old.yaml> <new.yaml> or brainctl diff --git-ref <ref> <app.yaml>")
+			}
+			if newData, err = os.ReadFile(newName); err != nil {
+				return err
+			}
+
+			policy, err := loadRepoTagPolicy(newName)
+			if err != nil {
+				return err
+			}
+			d, err := diffContracts(oldName, oldData, newName, newData, policy)
+			if err != nil {
+				return err
+			}
+			if format == "json" {
+				b, err := json.MarshalIndent(d, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(b))
+			} else {
+				printContractDiff(os.Stdout, d)
+			}
+
+			if exitCode && len(d.Changes) > 0 {
+				return fmt.Errorf("%d effective change(s)", len(d.Changes))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("git-ref", "", "Compare the working copy of <app.yaml> against this git ref (ex: main, HEAD~1)")
+	cmd.Flags().String("format", "text", "Output format: text|json")
+	cmd.Flags().Bool("exit-code", false, "Exit with an error when there are effective changes (CI)")
+	return cmd
+}
+
+// diffContracts compara os contratos efetivos. A política de tags do repositório só vale
+// para o novo: o antigo (ex: uma revisão do git) pode ser anterior à política.
+func diffContracts(oldName string, oldData []byte, newName string, newData []byte, policy config.TagPolicyConfig) (*contractDiff, error) {
+	oldCfg, err := config.ParseEffectiveConfig(oldName, oldData, config.TagPolicyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	newCfg, err := config.ParseEffectiveConfig(newName, newData, policy)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := config.DiffConfigs(oldCfg, newCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &contractDiff{Old: oldName, New: newName, Changes: []contractChange{}}
+	for _, c := range changes {
+		cc := contractChange{FieldChange: c, Impact: config.FieldImpact(c.Path)}
+		if config.IsSecretField(c.Path) {
+			cc.Old, cc.New, cc.Redacted = nil, nil, true
+		}
+		d.Changes = append(d.Changes, cc)
+	}
+	return d, nil
+}
+
+// gitShowFile lê o arquivo na revisão informada; o caminho é relativo ao diretório atual.
+func gitShowFile(ref, path string) ([]byte, error) {
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	cmd := exec.Command("git", "-C", dir, "show", ref+":./"+base)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git show %s:%s failed: %w\n%s", ref, path, err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+func printContractDiff(w io.Writer, d *contractDiff) {
+	fmt.Fprintf(w, "== brainctl diff %s -> %s (effective contract) ==\n", d.Old, d.New)
+	if len(d.Changes) == 0 {
+		fmt.Fprintln(w, "(no effective changes)")
+		return
+	}
+	for _, c := range d.Changes {
+		if c.Redacted {
+			fmt.Fprintf(w, "~ %s: (changed)\n", c.Path)
+		} else {
+			fmt.Fprintf(w, "~ %s: %s -> %s\n", c.Path, config.FormatValue(c.Old), config.FormatValue(c.New))
+		}
+		if c.Impact.Detail != "" {
+			fmt.Fprintf(w, "    [%s] %s\n", c.Impact.Level, c.Impact.Detail)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/PydaVi/brainctl/internal/config"
+)
+
+func TestDiffContractsComparesEffectiveValues(t *testing.T) {
+	t.Parallel()
+
+	oldYAML := strings.Replace(testAppYAML, "observability:\n  enabled: true\n", "observability:\n  enabled: true\n  cpu_high_threshold: 80\n", 1)
+	newYAML := strings.Replace(testAppYAML, "instance_type: t3.micro", "instance_type: t3.large", 1)
+	newYAML = strings.Replace(newYAML, "observability:\n  enabled: true\n", "observability:\n", 1)
+
+	d, err := diffContracts("old.yaml", []byte(oldYAML), "new.yaml", []byte(newYAML), config.TagPolicyConfig{})
+	if err != nil {
+		t.Fatalf("diffContracts: %v", err)
+	}
+	// cpu_high_threshold explícito = default e observability.enabled omitido = default true:
+	// nenhum dos dois muda o contrato efetivo.
+	if len(d.Changes) != 1 {
+		t.Fatalf("expected only the instance type change, got %+v", d.Changes)
+	}
+	c := d.Changes[0]
+	if c.Path != "ec2.instance_type" || c.Impact.Level != config.ImpactRestart {
+		t.Fatalf("unexpected change: %+v", c)
+	}
+}
+
+func TestDiffContractsRedactsSecrets(t *testing.T) {
+	t.Parallel()
+
+	rds := func(password string) string {
+		contract := strings.Replace(testAppYAML, "  subnet_id: subnet-1\n", "  subnet_id: subnet-1\n  subnet_ids: [subnet-1, subnet-2]\n", 1)
+		return strings.Replace(contract, "db:\n  enabled: false\n", "db:\n  enabled: true\n  mode: rds\n  rds:\n    password: "+password+"\n", 1)
+	}
+	d, err := diffContracts("old.yaml", []byte(rds("old-s3cret")), "new.yaml", []byte(rds("new-s3cret")), config.TagPolicyConfig{})
+	if err != nil {
+		t.Fatalf("diffContracts: %v", err)
+	}
+	if len(d.Changes) != 1 || d.Changes[0].Path != "db.rds.password" || !d.Changes[0].Redacted {
+		t.Fatalf("expected a single redacted password change, got %+v", d.Changes)
+	}
+
+	var text bytes.Buffer
+	printContractDiff(&text, d)
+	raw, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	for _, out := range []string{text.String(), string(raw)} {
+		if strings.Contains(out, "s3cret") {
+			t.Fatalf("password leaked in output:\n%s", out)
+		}
+	}
+	if !strings.Contains(text.String(), "~ db.rds.password: (changed)") {
+		t.Fatalf("expected redacted text line, got:\n%s", text.String())
+	}
+}
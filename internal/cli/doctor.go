@@ -0,0 +1,447 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/PydaVi/brainctl/internal/generator"
+)
+
+const (
+	doctorOK   = "ok"
+	doctorWarn = "warn"
+	doctorFail = "fail"
+)
+
+// doctorCheck é o resultado de uma verificação do preflight.
+type doctorCheck struct {
+	Name   string
+	Status string
+	Detail string
+	Fix    string
+}
+
+// toolRequirement descreve um binário externo usado pelo brainctl.
+type toolRequirement struct {
+	Binary      string
+	VersionArgs []string
+	MinVersion  string
+	Required    bool
+	InstallHint string
+}
+
+var doctorTools = []toolRequirement{
+	{
+		Binary:      "terragrunt",
+		VersionArgs: []string{"--version"},
+		MinVersion:  "0.50.0",
+		Required:    true,
+		InstallHint: "instale via https://terragrunt.gruntwork.io/docs/getting-started/install/ (o CI usa vars.TERRAGRUNT_VERSION)",
+	},
+	{
+		Binary:      "terraform",
+		VersionArgs: []string{"version"},
+		MinVersion:  "1.6.0",
+		Required:    true,
+		InstallHint: "instale via https://developer.hashicorp.com/terraform/install ou tfenv",
+	},
+	{
+		Binary:      "infracost",
+		VersionArgs: []string{"--version"},
+		MinVersion:  "0.10.0",
+		Required:    false,
+		InstallHint: "necessário apenas para `brainctl cost`: curl -fsSL https://raw.githubusercontent.com/infracost/infracost/master/scripts/install.sh | sh",
+	},
+	{
+		Binary:      "aws",
+		VersionArgs: []string{"--version"},
+		MinVersion:  "2.0.0",
+		Required:    false,
+		InstallHint: "usado pelo doctor (credenciais e bucket) e pelo `status --live`: https://docs.aws.amazon.com/cli/latest/userguide/getting-started-install.html",
+	},
+}
+
+// doctorEnv isola o acesso ao sistema para que as checagens sejam testáveis.
+type doctorEnv struct {
+	lookPath func(file string) (string, error)
+	output   func(name string, args ...string) ([]byte, error)
+	getenv   func(key string) string
+	homeDir  func() (string, error)
+}
+
+func defaultDoctorEnv() doctorEnv {
+	return doctorEnv{
+		lookPath: exec.LookPath,
+		output: func(name string, args ...string) ([]byte, error) {
+			var stdout, stderr bytes.Buffer
+			cmd := exec.Command(name, args...)
+			cmd.Stdout = &stdout
+			cmd.Stderr = &stderr
+			if err := cmd.Run(); err != nil {
+				return stdout.Bytes(), fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+			}
+			// Alguns binários (ex.: aws v1) escrevem a versão em stderr.
+			return append(stdout.Bytes(), stderr.Bytes()...), nil
+		},
+		getenv:  os.Getenv,
+		homeDir: os.UserHomeDir,
+	}
+}
+
+func newDoctorCommand(opts *RuntimeOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check toolchain, repo layout, AWS credentials and backend before plan/apply",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			checks := runDoctor(defaultDoctorEnv(), optionsFromFlags(cmd))
+			failed := printDoctorReport(checks)
+			if failed > 0 {
+				return fmt.Errorf("doctor found %d problem(s)", failed)
+			}
+			return nil
+		},
+	}
+	applyCommonFlags(cmd, opts)
+	return cmd
+}
+
+// runDoctor executa as checagens em ordem de dependência: sem contrato válido
+// não há como inspecionar workspace nem backend.
+func runDoctor(env doctorEnv, opts RuntimeOptions) []doctorCheck {
+	var checks []doctorCheck
+	available := map[string]bool{}
+	for _, tool := range doctorTools {
+		c := checkTool(env, tool)
+		available[tool.Binary] = c.Status == doctorOK
+		checks = append(checks, c)
+	}
+
+	contractPath := opts.File
+	if !filepath.IsAbs(contractPath) {
+		contractPath = filepath.Join(opts.StackDir, opts.File)
+	}
+	checks = append(checks, checkRepoRoot(contractPath))
+
+	cfg, err := LoadRuntimeConfig(opts)
+	if err != nil {
+		checks = append(checks, doctorCheck{
+			Name:   "contract",
+			Status: doctorFail,
+			Detail: err.Error(),
+			Fix:    fmt.Sprintf("corrija %s (ou aponte --stack-dir/--file para o contrato certo)", contractPath),
+		})
+		return append(checks, checkAWSCredentials(env, available["aws"]))
+	}
+	checks = append(checks, doctorCheck{
+		Name:   "contract",
+		Status: doctorOK,
+		Detail: fmt.Sprintf("%s (%s@%s)", contractPath, cfg.App.Workload.Type, cfg.App.Workload.Version),
+	})
+	checks = append(checks, checkModuleLink(cfg, contractPath))
+
+	creds := checkAWSCredentials(env, available["aws"])
+	checks = append(checks, creds)
+	if creds.Status == doctorFail {
+		return checks
+	}
+	return append(checks, checkBackendBucket(env, available["aws"], cfg.App.Terraform.Backend.Bucket, cfg.App.Terraform.Backend.Region))
+}
+
+func checkTool(env doctorEnv, tool toolRequirement) doctorCheck {
+	c := doctorCheck{Name: tool.Binary}
+	missingStatus := doctorWarn
+	if tool.Required {
+		missingStatus = doctorFail
+	}
+
+	if _, err := env.lookPath(tool.Binary); err != nil {
+		c.Status = missingStatus
+		c.Detail = "not found in PATH"
+		c.Fix = tool.InstallHint
+		return c
+	}
+
+	out, err := env.output(tool.Binary, tool.VersionArgs...)
+	version := extractVersion(string(out))
+	if err != nil && version == "" {
+		c.Status = missingStatus
+		c.Detail = fmt.Sprintf("could not read version: %v", err)
+		c.Fix = tool.InstallHint
+		return c
+	}
+	if version == "" {
+		c.Status = doctorWarn
+		c.Detail = "version not recognized; minimum is " + tool.MinVersion
+		return c
+	}
+
+	if compareVersions(version, tool.MinVersion) < 0 {
+		c.Status = missingStatus
+		c.Detail = fmt.Sprintf("v%s is older than required v%s", version, tool.MinVersion)
+		c.Fix = "atualize: " + tool.InstallHint
+		return c
+	}
+
+	c.Status = doctorOK
+	c.Detail = fmt.Sprintf("v%s (>= %s)", version, tool.MinVersion)
+	return c
+}
+
+func checkRepoRoot(contractPath string) doctorCheck {
+	c := doctorCheck{Name: "repo root"}
+	absContract, err := filepath.Abs(contractPath)
+	if err != nil {
+		c.Status = doctorFail
+		c.Detail = err.Error()
+		return c
+	}
+	root, err := generator.FindRepoRoot(filepath.Dir(absContract))
+	if err != nil {
+		c.Status = doctorFail
+		c.Detail = err.Error()
+		c.Fix = "o contrato precisa estar dentro do repositório brainctl (diretório com go.mod e modules/)"
+		return c
+	}
+	c.Status = doctorOK
+	c.Detail = root
+	return c
+}
+
+func checkModuleLink(cfg *RuntimeConfig, contractPath string) doctorCheck {
+	c := doctorCheck{Name: "workspace module"}
+	st, err := generator.InspectModuleLink(cfg.App, contractPath)
+	if err != nil {
+		c.Status = doctorFail
+		c.Detail = err.Error()
+		return c
+	}
+
+	switch st.State {
+	case "missing":
+		c.Status = doctorOK
+		c.Detail = fmt.Sprintf("%s not created yet (will link to %s)", st.LinkPath, st.Target)
+	case "ok":
+		c.Status = doctorOK
+		c.Detail = fmt.Sprintf("%s -> %s", st.LinkPath, st.Target)
+	case "copied":
+		c.Status = doctorWarn
+		c.Detail = fmt.Sprintf("%s is a copy (symlink unavailable); changes in %s are not picked up", st.LinkPath, st.Target)
+		c.Fix = fmt.Sprintf("rm -rf %s e rode o comando novamente", st.LinkPath)
+	default:
+		c.Status = doctorFail
+		c.Detail = fmt.Sprintf("%s is %s (expected symlink to %s)", st.LinkPath, st.State, st.Target)
+		c.Fix = fmt.Sprintf("rm -rf %s e rode o comando novamente", st.LinkPath)
+	}
+	return c
+}
+
+// awsCredentialSource identifica de onde o SDK/CLI vai obter credenciais,
+// seguindo a mesma precedência da cadeia padrão da AWS.
+func awsCredentialSource(env doctorEnv) (string, error) {
+	if env.getenv("AWS_ACCESS_KEY_ID") != "" && env.getenv("AWS_SECRET_ACCESS_KEY") != "" {
+		if env.getenv("AWS_SESSION_TOKEN") != "" {
+			return "environment (temporary session, e.g. OIDC via configure-aws-credentials)", nil
+		}
+		return "environment (static access keys)", nil
+	}
+
+	if tokenFile := env.getenv("AWS_WEB_IDENTITY_TOKEN_FILE"); tokenFile != "" {
+		if env.getenv("AWS_ROLE_ARN") == "" {
+			return "", fmt.Errorf("AWS_WEB_IDENTITY_TOKEN_FILE is set but AWS_ROLE_ARN is empty")
+		}
+		if _, err := os.Stat(tokenFile); err != nil {
+			return "", fmt.Errorf("web identity token file %s not readable: %w", tokenFile, err)
+		}
+		return "web identity (OIDC) role " + env.getenv("AWS_ROLE_ARN"), nil
+	}
+
+	if env.getenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI") != "" || env.getenv("AWS_CONTAINER_CREDENTIALS_FULL_URI") != "" {
+		return "container credentials endpoint", nil
+	}
+
+	profile := env.getenv("AWS_PROFILE")
+	if profile == "" {
+		profile = "default"
+	}
+	home, err := env.homeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home dir: %w", err)
+	}
+	files := []string{filepath.Join(home, ".aws", "credentials"), filepath.Join(home, ".aws", "config")}
+	if f := env.getenv("AWS_SHARED_CREDENTIALS_FILE"); f != "" {
+		files[0] = f
+	}
+	if f := env.getenv("AWS_CONFIG_FILE"); f != "" {
+		files[1] = f
+	}
+	for _, f := range files {
+		if profileDefined(f, profile) {
+			return fmt.Sprintf("profile %q (%s)", profile, f), nil
+		}
+	}
+
+	return "", fmt.Errorf("no credentials found in environment, web identity or profile %q", profile)
+}
+
+// profileDefined procura a seção do profile em arquivos INI da AWS.
+// No config o header é "[profile x]" (exceto default); no credentials é "[x]".
+func profileDefined(path, profile string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "["+profile+"]" || line == "[profile "+profile+"]" {
+			return true
+		}
+	}
+	return false
+}
+
+func checkAWSCredentials(env doctorEnv, awsCLI bool) doctorCheck {
+	c := doctorCheck{Name: "aws credentials"}
+	source, err := awsCredentialSource(env)
+	if err != nil {
+		c.Status = doctorFail
+		c.Detail = err.Error()
+		c.Fix = "exporte AWS_PROFILE (aws configure sso / aws configure) ou, no CI, use aws-actions/configure-aws-credentials com OIDC (docs/oidc)"
+		return c
+	}
+
+	if !awsCLI {
+		c.Status = doctorWarn
+		c.Detail = source + "; not verified (aws CLI unavailable)"
+		return c
+	}
+
+	out, err := env.output("aws", "sts", "get-caller-identity", "--output", "json")
+	if err != nil {
+		c.Status = doctorFail
+		c.Detail = fmt.Sprintf("%s; sts get-caller-identity failed: %v", source, err)
+		c.Fix = "credenciais expiradas ou inválidas: renove a sessão (aws sso login) ou revise a trust policy do role OIDC"
+		return c
+	}
+
+	var identity struct {
+		Account string `json:"Account"`
+		Arn     string `json:"Arn"`
+	}
+	if err := json.Unmarshal(out, &identity); err != nil {
+		c.Status = doctorWarn
+		c.Detail = source + "; could not parse caller identity"
+		return c
+	}
+	c.Status = doctorOK
+	c.Detail = fmt.Sprintf("%s; account %s (%s)", source, identity.Account, identity.Arn)
+	return c
+}
+
+func checkBackendBucket(env doctorEnv, awsCLI bool, bucket, region string) doctorCheck {
+	c := doctorCheck{Name: "backend bucket"}
+	if !awsCLI {
+		c.Status = doctorWarn
+		c.Detail = fmt.Sprintf("s3://%s not verified (aws CLI unavailable)", bucket)
+		return c
+	}
+
+	out, err := env.output("aws", "s3api", "get-bucket-location", "--bucket", bucket, "--region", region, "--output", "json")
+	if err != nil {
+		c.Status = doctorFail
+		c.Detail = fmt.Sprintf("s3://%s unreachable: %v", bucket, err)
+		c.Fix = "confira terraform.backend.bucket e se o role tem s3:ListBucket/s3:GetBucketLocation no bucket de state"
+		return c
+	}
+
+	var loc struct {
+		LocationConstraint *string `json:"LocationConstraint"`
+	}
+	if err := json.Unmarshal(out, &loc); err != nil {
+		c.Status = doctorWarn
+		c.Detail = fmt.Sprintf("s3://%s reachable; could not parse bucket location", bucket)
+		return c
+	}
+	actual := normalizeBucketRegion(loc.LocationConstraint)
+	if actual != region {
+		c.Status = doctorFail
+		c.Detail = fmt.Sprintf("s3://%s is in %s but terraform.backend.region is %s", bucket, actual, region)
+		c.Fix = fmt.Sprintf("defina terraform.backend.region: %s no app.yaml", actual)
+		return c
+	}
+
+	c.Status = doctorOK
+	c.Detail = fmt.Sprintf("s3://%s (%s)", bucket, actual)
+	return c
+}
+
+// normalizeBucketRegion trata os valores legados de GetBucketLocation.
+func normalizeBucketRegion(constraint *string) string {
+	if constraint == nil || *constraint == "" {
+		return "us-east-1"
+	}
+	if *constraint == "EU" {
+		return "eu-west-1"
+	}
+	return *constraint
+}
+
+func printDoctorReport(checks []doctorCheck) int {
+	failed := 0
+	fmt.Println("== brainctl doctor ==")
+	for _, c := range checks {
+		fmt.Printf("[%-4s] %-17s %s\n", c.Status, c.Name, c.Detail)
+		if c.Fix != "" && c.Status != doctorOK {
+			fmt.Printf("       fix: %s\n", c.Fix)
+		}
+		if c.Status == doctorFail {
+			failed++
+		}
+	}
+	return failed
+}
+
+var versionPattern = regexp.MustCompile(`v?(\d+)\.(\d+)(?:\.(\d+))?`)
+
+// extractVersion pega a primeira versão semântica da saída de --version.
+func extractVersion(out string) string {
+	m := versionPattern.FindStringSubmatch(out)
+	if m == nil {
+		return ""
+	}
+	patch := m[3]
+	if patch == "" {
+		patch = "0"
+	}
+	return fmt.Sprintf("%s.%s.%s", m[1], m[2], patch)
+}
+
+// compareVersions compara versões major.minor.patch numericamente.
+func compareVersions(a, b string) int {
+	pa := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	pb := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < 3; i++ {
+		var va, vb int
+		if i < len(pa) {
+			va, _ = strconv.Atoi(pa[i])
+		}
+		if i < len(pb) {
+			vb, _ = strconv.Atoi(pb[i])
+		}
+		if va != vb {
+			if va < vb {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
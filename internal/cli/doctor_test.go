@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func fakeDoctorEnv(vars map[string]string, home string) doctorEnv {
+	return doctorEnv{
+		lookPath: func(file string) (string, error) { return "/usr/bin/" + file, nil },
+		output:   func(name string, args ...string) ([]byte, error) { return nil, nil },
+		getenv:   func(key string) string { return vars[key] },
+		homeDir:  func() (string, error) { return home, nil },
+	}
+}
+
+func TestExtractVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"Terraform v1.9.5\non linux_amd64":          "1.9.5",
+		"terragrunt version v0.67.0":                "0.67.0",
+		"Infracost v0.10.39":                        "0.10.39",
+		"aws-cli/2.15.30 Python/3.11.8 Linux/6.5.0": "2.15.30",
+		"tool 1.2":        "1.2.0",
+		"no version here": "",
+	}
+	for in, want := range tests {
+		if got := extractVersion(in); got != want {
+			t.Fatalf("extractVersion(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	t.Parallel()
+
+	if compareVersions("0.67.0", "0.50.0") <= 0 {
+		t.Fatal("expected 0.67.0 > 0.50.0")
+	}
+	if compareVersions("1.10.0", "1.6.0") <= 0 {
+		t.Fatal("expected numeric comparison (1.10.0 > 1.6.0)")
+	}
+	if compareVersions("1.5.7", "1.6.0") >= 0 {
+		t.Fatal("expected 1.5.7 < 1.6.0")
+	}
+	if compareVersions("v1.6.0", "1.6.0") != 0 {
+		t.Fatal("expected v-prefixed versions to compare equal")
+	}
+}
+
+func TestCheckTool(t *testing.T) {
+	t.Parallel()
+
+	tool := toolRequirement{Binary: "terraform", VersionArgs: []string{"version"}, MinVersion: "1.6.0", Required: true, InstallHint: "install terraform"}
+
+	env := fakeDoctorEnv(nil, t.TempDir())
+	env.output = func(name string, args ...string) ([]byte, error) { return []byte("Terraform v1.5.7"), nil }
+	if c := checkTool(env, tool); c.Status != doctorFail {
+		t.Fatalf("expected old required tool to fail, got %s (%s)", c.Status, c.Detail)
+	}
+
+	env.lookPath = func(file string) (string, error) { return "", errors.New("not found") }
+	if c := checkTool(env, tool); c.Status != doctorFail || c.Fix == "" {
+		t.Fatalf("expected missing required tool to fail with fix, got %+v", c)
+	}
+
+	tool.Required = false
+	if c := checkTool(env, tool); c.Status != doctorWarn {
+		t.Fatalf("expected missing optional tool to warn, got %s", c.Status)
+	}
+}
+
+func TestAWSCredentialSource(t *testing.T) {
+	t.Parallel()
+
+	home := t.TempDir()
+	if _, err := awsCredentialSource(fakeDoctorEnv(nil, home)); err == nil {
+		t.Fatal("expected error without any credential source")
+	}
+
+	src, err := awsCredentialSource(fakeDoctorEnv(map[string]string{
+		"AWS_ACCESS_KEY_ID":     "AKIA",
+		"AWS_SECRET_ACCESS_KEY": "secret",
+		"AWS_SESSION_TOKEN":     "token",
+	}, home))
+	if err != nil || !strings.Contains(src, "temporary session") {
+		t.Fatalf("unexpected env source: %q (%v)", src, err)
+	}
+
+	if _, err := awsCredentialSource(fakeDoctorEnv(map[string]string{
+		"AWS_WEB_IDENTITY_TOKEN_FILE": filepath.Join(home, "missing-token"),
+		"AWS_ROLE_ARN":                "arn:aws:iam::123:role/gh",
+	}, home)); err == nil {
+		t.Fatal("expected error for missing web identity token file")
+	}
+
+	if err := os.MkdirAll(filepath.Join(home, ".aws"), 0o755); err != nil {
+		t.Fatalf("mkdir .aws: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".aws", "config"), []byte("[profile platform]\nregion = us-east-1\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	src, err = awsCredentialSource(fakeDoctorEnv(map[string]string{"AWS_PROFILE": "platform"}, home))
+	if err != nil || !strings.Contains(src, `profile "platform"`) {
+		t.Fatalf("unexpected profile source: %q (%v)", src, err)
+	}
+}
+
+func TestCheckBackendBucketRegionMismatch(t *testing.T) {
+	t.Parallel()
+
+	env := fakeDoctorEnv(nil, t.TempDir())
+	env.output = func(name string, args ...string) ([]byte, error) {
+		return []byte(`{"LocationConstraint": "sa-east-1"}`), nil
+	}
+	c := checkBackendBucket(env, true, "state-bucket", "us-east-1")
+	if c.Status != doctorFail || !strings.Contains(c.Fix, "sa-east-1") {
+		t.Fatalf("expected region mismatch failure, got %+v", c)
+	}
+
+	env.output = func(name string, args ...string) ([]byte, error) {
+		return []byte(`{"LocationConstraint": null}`), nil
+	}
+	if c := checkBackendBucket(env, true, "state-bucket", "us-east-1"); c.Status != doctorOK {
+		t.Fatalf("expected null location to mean us-east-1, got %+v", c)
+	}
+}
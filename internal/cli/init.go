@@ -0,0 +1,269 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/PydaVi/brainctl/internal/blueprints"
+	"github.com/PydaVi/brainctl/internal/blueprints/scaffold"
+	"github.com/PydaVi/brainctl/internal/config"
+)
+
+func newInitCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Scaffold a new stack (app.yaml, security-groups/, scripts/) from a blueprint and validate it",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			blueprintType, _ := cmd.Flags().GetString("blueprint")
+			blueprintVersion, _ := cmd.Flags().GetString("blueprint-version")
+			bp, err := blueprints.Resolve(blueprintType, blueprintVersion)
+			if err != nil {
+				return err
+			}
+			if bp.Scaffold == nil {
+				return fmt.Errorf("blueprint %s@%s does not support init", bp.Type, bp.Version)
+			}
+
+			p := initParamsFromFlags(cmd)
+			in := bufio.NewReader(os.Stdin)
+			if missing := p.Missing(); len(missing) > 0 {
+				if !isInteractiveInput() {
+					return fmt.Errorf("missing required flags for non-interactive init: --%s", strings.Join(missing, ", --"))
+				}
+				fmt.Printf("== brainctl init (%s@%s) ==\n", bp.Type, bp.Version)
+				if err := promptInitParams(in, os.Stdout, &p); err != nil {
+					return err
+				}
+			}
+
+			dir, _ := cmd.Flags().GetString("dir")
+			if dir == "" {
+				dir = filepath.Join("stacks", p.Name, p.Environment)
+			}
+			force, _ := cmd.Flags().GetBool("force")
+
+			// As tags exigidas pela política do repositório não têm default: vêm de --tag ou do prompt.
+			policy, err := loadRepoTagPolicy(filepath.Join(dir, "app.yaml"))
+			if err != nil {
+				return err
+			}
+			if missing := missingTags(policy.Required, p.Tags); len(missing) > 0 {
+				if !isInteractiveInput() {
+					return fmt.Errorf("missing tags required by %s for non-interactive init: --tag %s=<value>", config.RepoTagPolicyFile, strings.Join(missing, "=<value>, --tag "))
+				}
+				if err := promptInitTags(in, os.Stdout, &p, missing); err != nil {
+					return err
+				}
+			}
+
+			written, err := initStack(bp, p, dir, force)
+			if err != nil {
+				return err
+			}
+			for _, path := range written {
+				fmt.Printf("[init] criado %s\n", path)
+			}
+			fmt.Printf("[init] contrato válido. Próximo passo: brainctl doctor --stack-dir %s && brainctl plan --stack-dir %s\n", dir, dir)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("blueprint", blueprints.DefaultWorkloadType, "Blueprint type (see `brainctl blueprints`)")
+	cmd.Flags().String("blueprint-version", blueprints.DefaultWorkloadVersion, "Blueprint version")
+	cmd.Flags().String("name", "", "app.name")
+	cmd.Flags().String("env", "", "app.environment (ex: dev, prod)")
+	cmd.Flags().String("region", "us-east-1", "app.region")
+	cmd.Flags().String("state-bucket", "", "S3 bucket for terraform state (terraform.backend.bucket)")
+	cmd.Flags().String("vpc-id", "", "infrastructure.vpc_id")
+	cmd.Flags().String("vpc-cidr", "", "infrastructure.vpc_cidr")
+	cmd.Flags().StringSlice("subnet-ids", nil, "Private subnet ids (the first one becomes infrastructure.subnet_id)")
+	cmd.Flags().String("os", "", "Operating system when the blueprint supports it (ec2-app: windows-2022, amazon-linux-2023, ubuntu-22.04)")
+	cmd.Flags().String("dir", "", "Stack directory to create (default: stacks/<name>/<env>)")
+	cmd.Flags().Bool("security-groups", true, "Create empty security-groups/ rule files when the blueprint supports them")
+	cmd.Flags().Bool("user-data", true, "Create a user data stub under scripts/ when the blueprint supports it")
+	cmd.Flags().StringToString("tag", nil, "Contract tag key=value (repeatable); keys required by policies/tags.yaml are prompted when missing")
+	cmd.Flags().Bool("force", false, "Overwrite an existing app.yaml in --dir")
+	return cmd
+}
+
+func initParamsFromFlags(cmd *cobra.Command) scaffold.Params {
+	var p scaffold.Params
+	p.Name, _ = cmd.Flags().GetString("name")
+	p.Environment, _ = cmd.Flags().GetString("env")
+	p.Region, _ = cmd.Flags().GetString("region")
+	p.StateBucket, _ = cmd.Flags().GetString("state-bucket")
+	p.VpcID, _ = cmd.Flags().GetString("vpc-id")
+	p.VpcCIDR, _ = cmd.Flags().GetString("vpc-cidr")
+	p.SubnetIDs, _ = cmd.Flags().GetStringSlice("subnet-ids")
+	p.OS, _ = cmd.Flags().GetString("os")
+	p.SecurityGroups, _ = cmd.Flags().GetBool("security-groups")
+	p.UserData, _ = cmd.Flags().GetBool("user-data")
+	p.Tags, _ = cmd.Flags().GetStringToString("tag")
+	return p
+}
+
+// askInitValue lê um valor obrigatório; Enter mantém o atual.
+func askInitValue(in *bufio.Reader, out io.Writer, label, current string) (string, error) {
+	for {
+		if current != "" {
+			fmt.Fprintf(out, "%s [%s]: ", label, current)
+		} else {
+			fmt.Fprintf(out, "%s: ", label)
+		}
+		line, err := in.ReadString('\n')
+		line = strings.TrimSpace(line)
+		if line == "" {
+			line = current
+		}
+		if line != "" {
+			return line, nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("%s is required", label)
+		}
+	}
+}
+
+// promptInitParams pergunta apenas pelos valores que não vieram por flag.
+func promptInitParams(in *bufio.Reader, out io.Writer, p *scaffold.Params) error {
+	ask := func(label, current string) (string, error) {
+		return askInitValue(in, out, label, current)
+	}
+
+	var err error
+	if p.Name, err = ask("app.name", p.Name); err != nil {
+		return err
+	}
+	if p.Environment, err = ask("app.environment", p.Environment); err != nil {
+		return err
+	}
+	if p.Region, err = ask("app.region", p.Region); err != nil {
+		return err
+	}
+	if p.StateBucket, err = ask("terraform.backend.bucket", p.StateBucket); err != nil {
+		return err
+	}
+	if p.VpcID, err = ask("infrastructure.vpc_id", p.VpcID); err != nil {
+		return err
+	}
+	if p.VpcCIDR, err = ask("infrastructure.vpc_cidr", p.VpcCIDR); err != nil {
+		return err
+	}
+	subnets, err := ask("subnet ids (separados por vírgula)", strings.Join(p.SubnetIDs, ","))
+	if err != nil {
+		return err
+	}
+	p.SubnetIDs = nil
+	for _, s := range strings.Split(subnets, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			p.SubnetIDs = append(p.SubnetIDs, s)
+		}
+	}
+	return nil
+}
+
+// missingTags lista as chaves exigidas que não vieram em tags, na ordem da política.
+func missingTags(required []string, tags map[string]string) []string {
+	var missing []string
+	for _, k := range required {
+		if strings.TrimSpace(tags[k]) == "" {
+			missing = append(missing, k)
+		}
+	}
+	return missing
+}
+
+// promptInitTags pergunta o valor de cada tag exigida pela política do repositório.
+func promptInitTags(in *bufio.Reader, out io.Writer, p *scaffold.Params, keys []string) error {
+	if p.Tags == nil {
+		p.Tags = map[string]string{}
+	}
+	for _, k := range keys {
+		v, err := askInitValue(in, out, "tags."+k, "")
+		if err != nil {
+			return err
+		}
+		p.Tags[k] = v
+	}
+	return nil
+}
+
+// initStack valida o contrato gerado da mesma forma que plan/apply (incluindo
+// security-groups/ e user data via file://) e só então grava os arquivos em dir.
+// A validação roda num diretório temporário ao lado de dir, no mesmo repo, para que
+// policies/tags.yaml também valha; se falhar, nada fica no disco.
+func initStack(bp blueprints.Definition, p scaffold.Params, dir string, force bool) ([]string, error) {
+	files, err := bp.Scaffold(p)
+	if err != nil {
+		return nil, err
+	}
+
+	contractPath := filepath.Join(dir, "app.yaml")
+	if _, err := os.Stat(contractPath); err == nil && !force {
+		return nil, fmt.Errorf("%s already exists (use --force to overwrite)", contractPath)
+	}
+
+	parent := filepath.Dir(dir)
+	created := firstMissingDir(parent)
+	if err := os.MkdirAll(parent, 0o755); err != nil {
+		return nil, fmt.Errorf("create %s: %w", parent, err)
+	}
+	tmp, err := os.MkdirTemp(parent, "."+filepath.Base(dir)+".init-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	for _, f := range files {
+		path := filepath.Join(tmp, filepath.FromSlash(f.Path))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, fmt.Errorf("create %s: %w", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, f.Content, 0o644); err != nil {
+			return nil, fmt.Errorf("write %s: %w", path, err)
+		}
+	}
+
+	if _, err := LoadRuntimeConfig(RuntimeOptions{File: "app.yaml", StackDir: tmp, SecurityGroupsDir: "security-groups"}); err != nil {
+		if created != "" {
+			os.RemoveAll(created)
+		}
+		return nil, fmt.Errorf("generated contract %s is invalid, nothing written: %w", contractPath, err)
+	}
+
+	written := make([]string, 0, len(files))
+	for _, f := range files {
+		path := filepath.Join(dir, filepath.FromSlash(f.Path))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return written, fmt.Errorf("create %s: %w", filepath.Dir(path), err)
+		}
+		if err := os.Rename(filepath.Join(tmp, filepath.FromSlash(f.Path)), path); err != nil {
+			return written, fmt.Errorf("write %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+// firstMissingDir devolve o ancestral mais alto de dir que ainda não existe (o primeiro
+// diretório que MkdirAll criaria), ou "" se dir já existe.
+func firstMissingDir(dir string) string {
+	missing := ""
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			return missing
+		}
+		missing = dir
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return missing
+		}
+		dir = parent
+	}
+}
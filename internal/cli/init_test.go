@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PydaVi/brainctl/internal/blueprints"
+	"github.com/PydaVi/brainctl/internal/blueprints/scaffold"
+)
+
+func TestInitStackWritesValidStack(t *testing.T) {
+	t.Parallel()
+
+	bp, err := blueprints.Resolve("ec2-app", "v1")
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	p := scaffold.Params{
+		Name: "billing", Environment: "dev", Region: "us-east-1", StateBucket: "state",
+		VpcID: "vpc-1", VpcCIDR: "10.0.0.0/16", SubnetIDs: []string{"subnet-a", "subnet-b"},
+		SecurityGroups: true, UserData: true,
+	}
+	dir := filepath.Join(t.TempDir(), "stacks", "billing", "dev")
+
+	written, err := initStack(bp, p, dir, false)
+	if err != nil {
+		t.Fatalf("initStack: %v", err)
+	}
+	if len(written) != 5 {
+		t.Fatalf("expected app.yaml, user data and 3 SG files, got %v", written)
+	}
+	rc, err := LoadRuntimeConfig(RuntimeOptions{File: "app.yaml", StackDir: dir, SecurityGroupsDir: "security-groups"})
+	if err != nil {
+		t.Fatalf("load scaffolded stack: %v", err)
+	}
+	if !strings.Contains(rc.App.EC2.UserData, "billing dev") {
+		t.Fatalf("expected user data stub to be resolved, got %q", rc.App.EC2.UserData)
+	}
+
+	if _, err := initStack(bp, p, dir, false); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected refusal to overwrite, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "app.yaml")); err != nil {
+		t.Fatalf("app.yaml should still exist: %v", err)
+	}
+}
+
+func TestInitStackLinux(t *testing.T) {
+	t.Parallel()
+
+	bp, err := blueprints.Resolve("ec2-app", "v1")
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	p := scaffold.Params{
+		Name: "billing", Environment: "dev", Region: "us-east-1", StateBucket: "state",
+		VpcID: "vpc-1", VpcCIDR: "10.0.0.0/16", SubnetIDs: []string{"subnet-a"},
+		OS: "amazon-linux-2023", UserData: true,
+	}
+	dir := filepath.Join(t.TempDir(), "billing")
+
+	if _, err := initStack(bp, p, dir, false); err != nil {
+		t.Fatalf("initStack: %v", err)
+	}
+	rc, err := LoadRuntimeConfig(RuntimeOptions{File: "app.yaml", StackDir: dir})
+	if err != nil {
+		t.Fatalf("load scaffolded stack: %v", err)
+	}
+	if rc.App.EC2.OS != "amazon-linux-2023" || rc.App.EC2.AllowedSSHCIDR != "10.0.0.0/16" {
+		t.Fatalf("unexpected ec2 block: %+v", rc.App.EC2)
+	}
+	if !strings.HasPrefix(rc.App.EC2.UserData, "#!/bin/bash") {
+		t.Fatalf("expected bash user data stub, got %q", rc.App.EC2.UserData)
+	}
+	if len(rc.Warnings) != 0 {
+		t.Fatalf("scaffolded contract should not warn, got %v", rc.Warnings)
+	}
+}
+
+func TestPromptInitParamsKeepsFlagValues(t *testing.T) {
+	t.Parallel()
+
+	p := scaffold.Params{Name: "billing", Region: "us-east-1"}
+	in := bufio.NewReader(strings.NewReader("\nprod\n\nstate\nvpc-1\n10.0.0.0/16\nsubnet-a, subnet-b\n"))
+	if err := promptInitParams(in, io.Discard, &p); err != nil {
+		t.Fatalf("promptInitParams: %v", err)
+	}
+	if p.Name != "billing" || p.Environment != "prod" || p.Region != "us-east-1" || p.StateBucket != "state" {
+		t.Fatalf("unexpected params: %+v", p)
+	}
+	if !reflect.DeepEqual(p.SubnetIDs, []string{"subnet-a", "subnet-b"}) {
+		t.Fatalf("unexpected subnets: %v", p.SubnetIDs)
+	}
+	if len(p.Missing()) != 0 {
+		t.Fatalf("expected no missing values, got %v", p.Missing())
+	}
+}
+
+func TestInitStackRepoTagPolicy(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	for path, content := range map[string]string{
+		"go.mod":             "module example.com/infra\n",
+		"modules/.keep":      "",
+		"policies/tags.yaml": "required: [owner]\n",
+	} {
+		full := filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	bp, err := blueprints.Resolve("ec2-app", "v1")
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	p := scaffold.Params{
+		Name: "billing", Environment: "dev", Region: "us-east-1", StateBucket: "state",
+		VpcID: "vpc-1", VpcCIDR: "10.0.0.0/16", SubnetIDs: []string{"subnet-a"},
+		SecurityGroups: true, UserData: true,
+	}
+	dir := filepath.Join(root, "stacks", "billing", "dev")
+
+	if _, err := initStack(bp, p, dir, false); err == nil || !strings.Contains(err.Error(), "tags.owner is required") {
+		t.Fatalf("expected repository tag policy error, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "stacks")); !os.IsNotExist(err) {
+		t.Fatalf("failed init must not leave files behind, stat: %v", err)
+	}
+
+	p.Tags = map[string]string{"owner": "time-billing"}
+	if _, err := initStack(bp, p, dir, false); err != nil {
+		t.Fatalf("initStack: %v", err)
+	}
+	rc, err := LoadRuntimeConfig(RuntimeOptions{File: "app.yaml", StackDir: dir, SecurityGroupsDir: "security-groups"})
+	if err != nil {
+		t.Fatalf("load scaffolded stack: %v", err)
+	}
+	if rc.App.Tags["owner"] != "time-billing" {
+		t.Fatalf("expected owner tag, got %v", rc.App.Tags)
+	}
+	entries, err := os.ReadDir(filepath.Dir(dir))
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected only the stack dir next to it, got %v (%v)", entries, err)
+	}
+}
+
+func TestMissingTags(t *testing.T) {
+	t.Parallel()
+
+	got := missingTags([]string{"owner", "cost_center"}, map[string]string{"owner": "time-billing", "cost_center": " "})
+	if !reflect.DeepEqual(got, []string{"cost_center"}) {
+		t.Fatalf("unexpected missing tags: %v", got)
+	}
+}
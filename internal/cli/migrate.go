@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/PydaVi/brainctl/internal/blueprints"
+	"github.com/PydaVi/brainctl/internal/config"
+	"github.com/PydaVi/brainctl/internal/migrate"
+)
+
+func newMigrateCommand(opts *RuntimeOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Upgrade app.yaml to the latest blueprint version and fix deprecated fields (preserves comments)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runtimeOpts := optionsFromFlags(cmd)
+			target, _ := cmd.Flags().GetString("to")
+			write, _ := cmd.Flags().GetBool("write")
+
+			path := runtimeOpts.File
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(runtimeOpts.StackDir, runtimeOpts.File)
+			}
+			changes, out, err := migrateContract(path, target)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("== brainctl migrate %s ==\n", path)
+			if len(changes) == 0 {
+				fmt.Println("(contrato já está atualizado)")
+				return nil
+			}
+			for _, c := range changes {
+				fmt.Printf("  - %s\n", c)
+			}
+			if !write {
+				fmt.Println("\n(dry-run) execute novamente com --write para reescrever o contrato.")
+				return nil
+			}
+			if err := os.WriteFile(path, out, 0o644); err != nil {
+				return fmt.Errorf("write %s: %w", path, err)
+			}
+			fmt.Printf("\n[migrate] %s atualizado.\n", path)
+			return nil
+		},
+	}
+
+	applyCommonFlags(cmd, opts)
+	cmd.Flags().String("to", "", "Target blueprint version (default: latest in the catalog)")
+	cmd.Flags().Bool("write", false, "Rewrite the contract in place (default: dry-run)")
+	return cmd
+}
+
+// migrateContract aplica o migrate no documento e valida o resultado antes de devolvê-lo.
+func migrateContract(path, target string) ([]string, []byte, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return nil, nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	workloadType := ""
+	if n := config.LookupYAMLPath(&doc, "workload.type"); n != nil {
+		workloadType = n.Value
+	}
+	bp, err := blueprints.MigrationTarget(workloadType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changes, err := migrate.Run(&doc, bp, target)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(changes) == 0 {
+		return nil, raw, nil
+	}
+
+	out, err := config.EncodeYAMLDocument(&doc, raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encode %s: %w", path, err)
+	}
+	policy, err := loadRepoTagPolicy(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := config.ParseEffectiveConfig(path, out, policy); err != nil {
+		return nil, nil, fmt.Errorf("migrated contract would be invalid, nothing written: %w", err)
+	}
+	return changes, out, nil
+}
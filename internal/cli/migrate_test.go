@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMigrateContractPinsWorkloadAndDropsDeprecatedFields(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	contract := strings.Replace(testAppYAML, "  instance_type: t3.micro\n", "  instance_type: t3.micro\n  imds_v2_required: true\n", 1)
+	path := filepath.Join(dir, "app.yaml")
+	if err := os.WriteFile(path, []byte(contract), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	rc, err := LoadRuntimeConfig(RuntimeOptions{File: "app.yaml", StackDir: dir})
+	if err != nil {
+		t.Fatalf("LoadRuntimeConfig: %v", err)
+	}
+	if len(rc.Warnings) != 1 || !strings.Contains(rc.Warnings[0], "ec2.imds_v2_required") {
+		t.Fatalf("expected deprecation warning, got %v", rc.Warnings)
+	}
+
+	changes, out, err := migrateContract(path, "")
+	if err != nil {
+		t.Fatalf("migrateContract: %v", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("expected workload pin and deprecation fix, got %v", changes)
+	}
+	if !strings.HasPrefix(string(out), "workload:\n  type: ec2-app\n  version: v1\n") || strings.Contains(string(out), "imds_v2_required") {
+		t.Fatalf("unexpected migrated contract:\n%s", out)
+	}
+}
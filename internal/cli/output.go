@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/PydaVi/brainctl/internal/config"
+	"github.com/PydaVi/brainctl/internal/outputs"
+)
+
+func newOutputCommand(opts *RuntimeOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "output",
+		Short: "Print terragrunt outputs (json, env, shell, yaml, ansible-inventory, ssm-config)",
+		RunE: withRuntime(*opts, true, func(cmd *cobra.Command, args []string, ctx *runtimeContext) error {
+			format, _ := cmd.Flags().GetString("format")
+			if !isExportFormat(format) {
+				return fmt.Errorf("invalid --format %q (allowed: %s)", format, strings.Join(outputs.ExportFormats, ", "))
+			}
+
+			out, err := ctx.Runner.OutputJSON()
+			if err != nil {
+				return err
+			}
+			// json continua sendo o output bruto do terragrunt (compatível com scripts existentes).
+			if format == "json" {
+				fmt.Println(string(out))
+				return nil
+			}
+
+			vals, err := outputs.ParseTerraformOutputJSON(out)
+			if err != nil {
+				return fmt.Errorf("parse outputs: %w", err)
+			}
+			return outputs.Export(os.Stdout, format, vals, exportOptions(ctx.Config.App))
+		}),
+	}
+
+	applyCommonFlags(cmd, opts)
+	cmd.Flags().String("format", "json", "Output format: "+strings.Join(outputs.ExportFormats, ", "))
+	return cmd
+}
+
+func isExportFormat(format string) bool {
+	for _, f := range outputs.ExportFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// exportOptions deriva prefixo de host, região e usuário SSH do contrato.
+func exportOptions(cfg *config.AppConfig) outputs.ExportOptions {
+	opts := outputs.ExportOptions{
+		HostPrefix: cfg.App.Name + "-" + cfg.App.Environment,
+		Region:     cfg.App.Region,
+	}
+	if cfg.Workload.Type == "k8s-workers" {
+		opts.SSHUser = "ubuntu"
+	} else {
+		opts.SSHUser = config.DefaultSSHUser(cfg.EC2.OS)
+	}
+	return opts
+}
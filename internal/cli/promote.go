@@ -0,0 +1,309 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/PydaVi/brainctl/internal/config"
+)
+
+// defaultPromoteExcludes são campos que pertencem ao ambiente e nunca devem ser copiados
+// entre stacks: identidade, rede, backend, destinatários de alerta, horários de
+// scaling, mix on-demand/Spot e limites de custo.
+var defaultPromoteExcludes = []string{
+	"app.name",
+	"app.environment",
+	"terraform.backend",
+	"infrastructure",
+	"*.subnet_ids",
+	"*.allowed_cidr",
+	"ec2.allowed_rdp_cidr",
+	"ec2.allowed_ssh_cidr",
+	"lb.tls.certificate_arn",
+	"lb.tls.domain",
+	"lb.tls.subject_alternative_names",
+	"lb.tls.hosted_zone_id",
+	"lb.waf.web_acl_arn",
+	"dns.zone_id",
+	"dns.zone_name",
+	"dns.record_name",
+	"db.rds.password",
+	"app_scaling.schedules",
+	"app_scaling.instances",
+	"observability.alert_email",
+	"observability.log_kms_key_id",
+	"k8s.admin_cidr",
+	"k8s.key_name",
+	"k8s.public_subnet_id",
+	"k8s.public_subnet_cidr",
+	"k8s.internet_gateway_id",
+	"k8s.private_route_table_id",
+	"cost.budget_monthly_usd",
+	"cost.max_delta_monthly_usd",
+}
+
+// promotedDirs são os diretórios da stack copiados junto com o contrato.
+var promotedDirs = []string{"security-groups", "scripts"}
+
+// promoteFile é um arquivo de security-groups/ ou scripts/ que difere no destino.
+type promoteFile struct {
+	Path   string
+	Status string // new | changed
+}
+
+// promotePlan é o patch revisável: Changes usa Old=destino e New=origem.
+type promotePlan struct {
+	FromDir, ToDir string
+	ContractFile   string
+	Changes        []config.FieldChange
+	Excluded       []config.FieldChange
+	Files          []promoteFile
+	// TagPolicy é a política de tags do repositório, revalidada antes do --write.
+	TagPolicy config.TagPolicyConfig
+}
+
+func newPromoteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "promote",
+		Short: "Promote contract changes, security-group files and scripts from one stack to another",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, _ := cmd.Flags().GetString("from")
+			to, _ := cmd.Flags().GetString("to")
+			file, _ := cmd.Flags().GetString("file")
+			extra, _ := cmd.Flags().GetStringSlice("exclude")
+			include, _ := cmd.Flags().GetStringSlice("include")
+			write, _ := cmd.Flags().GetBool("write")
+			if from == "" || to == "" {
+				return fmt.Errorf("--from and --to are required")
+			}
+
+			plan, err := planPromotion(from, to, file, promoteExcludes(extra, include))
+			if err != nil {
+				return err
+			}
+			printPromotePlan(os.Stdout, plan)
+
+			if len(plan.Changes) == 0 && len(plan.Files) == 0 {
+				return nil
+			}
+			if !write {
+				fmt.Println("\n(dry-run) revise o patch acima e execute novamente com --write para aplicar.")
+				return nil
+			}
+			if err := plan.apply(); err != nil {
+				return err
+			}
+			fmt.Printf("\n[promote] %s atualizado. Próximo passo: brainctl diff ou brainctl plan --stack-dir %s\n", to, to)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("from", "", "Source stack directory (ex: stacks/ec2-app/dev)")
+	cmd.Flags().String("to", "", "Target stack directory (ex: stacks/ec2-app/prod)")
+	cmd.Flags().StringP("file", "f", "app.yaml", "Contract file name inside both stacks")
+	cmd.Flags().StringSlice("exclude", nil, "Extra field paths to keep from the target (dotted, globs per segment: lb.*, *.subnet_ids)")
+	cmd.Flags().StringSlice("include", nil, "Default exclusions to promote anyway (ex: observability.alert_email)")
+	cmd.Flags().Bool("write", false, "Apply the patch to the target stack (default: dry-run)")
+	return cmd
+}
+
+// promoteExcludes combina a lista padrão com --exclude, removendo o que veio em --include.
+func promoteExcludes(extra, include []string) []string {
+	skip := map[string]bool{}
+	for _, p := range include {
+		skip[p] = true
+	}
+	var out []string
+	for _, p := range append(append([]string{}, defaultPromoteExcludes...), extra...) {
+		if !skip[p] {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func planPromotion(fromDir, toDir, file string, excludes []string) (*promotePlan, error) {
+	policy, err := loadRepoTagPolicy(filepath.Join(toDir, file))
+	if err != nil {
+		return nil, err
+	}
+	fromCfg, err := config.LoadEffectiveConfig(filepath.Join(fromDir, file), policy)
+	if err != nil {
+		return nil, err
+	}
+	toCfg, err := config.LoadEffectiveConfig(filepath.Join(toDir, file), policy)
+	if err != nil {
+		return nil, err
+	}
+	if fromCfg.Workload != toCfg.Workload {
+		return nil, fmt.Errorf("cannot promote %s@%s into %s@%s", fromCfg.Workload.Type, fromCfg.Workload.Version, toCfg.Workload.Type, toCfg.Workload.Version)
+	}
+
+	changes, err := config.DiffConfigs(toCfg, fromCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &promotePlan{FromDir: fromDir, ToDir: toDir, ContractFile: file, TagPolicy: policy}
+	for _, c := range changes {
+		if isExcluded(c.Path, excludes) {
+			plan.Excluded = append(plan.Excluded, c)
+		} else {
+			plan.Changes = append(plan.Changes, c)
+		}
+	}
+
+	plan.Files, err = diffPromotedFiles(fromDir, toDir)
+	if err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+func isExcluded(field string, excludes []string) bool {
+	for _, p := range excludes {
+		if config.MatchFieldPath(p, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// diffPromotedFiles compara security-groups/ e scripts/; arquivos que só existem no
+// destino são preservados.
+func diffPromotedFiles(fromDir, toDir string) ([]promoteFile, error) {
+	var files []promoteFile
+	for _, dir := range promotedDirs {
+		root := filepath.Join(fromDir, dir)
+		err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && path == root {
+					return filepath.SkipDir
+				}
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			rel, err := filepath.Rel(fromDir, path)
+			if err != nil {
+				return err
+			}
+			src, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			dst, err := os.ReadFile(filepath.Join(toDir, rel))
+			switch {
+			case os.IsNotExist(err):
+				files = append(files, promoteFile{Path: rel, Status: "new"})
+			case err != nil:
+				return err
+			case !bytes.Equal(src, dst):
+				files = append(files, promoteFile{Path: rel, Status: "changed"})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("compare %s: %w", dir, err)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+// apply edita o contrato do destino via yaml.Node (preservando comentários e ordem),
+// valida o resultado antes de gravar e copia os arquivos.
+func (p *promotePlan) apply() error {
+	target := filepath.Join(p.ToDir, p.ContractFile)
+	raw, err := os.ReadFile(target)
+	if err != nil {
+		return err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return fmt.Errorf("parse %s: %w", target, err)
+	}
+	for _, c := range p.Changes {
+		if err := config.SetYAMLPath(&doc, c.Path, c.New); err != nil {
+			return err
+		}
+	}
+
+	out, err := config.EncodeYAMLDocument(&doc, raw)
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", target, err)
+	}
+
+	var check config.AppConfig
+	if err := yaml.Unmarshal(out, &check); err != nil {
+		return fmt.Errorf("promoted contract is not valid yaml: %w", err)
+	}
+	check.RepoTagPolicy = p.TagPolicy
+	if err := check.Validate(); err != nil {
+		return fmt.Errorf("promoted contract would be invalid, nothing written: %w", err)
+	}
+
+	if len(p.Changes) > 0 {
+		if err := os.WriteFile(target, out, 0o644); err != nil {
+			return fmt.Errorf("write %s: %w", target, err)
+		}
+	}
+	for _, f := range p.Files {
+		b, err := os.ReadFile(filepath.Join(p.FromDir, f.Path))
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(p.ToDir, f.Path)
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dst, b, 0o644); err != nil {
+			return fmt.Errorf("write %s: %w", dst, err)
+		}
+	}
+	return nil
+}
+
+func printPromotePlan(w io.Writer, p *promotePlan) {
+	fmt.Fprintf(w, "== brainctl promote %s -> %s ==\n", p.FromDir, p.ToDir)
+	fmt.Fprintf(w, "contract (%s):\n", filepath.Join(p.ToDir, p.ContractFile))
+	if len(p.Changes) == 0 {
+		fmt.Fprintln(w, "  (no promotable changes)")
+	}
+	for _, c := range p.Changes {
+		if config.IsSecretField(c.Path) {
+			fmt.Fprintf(w, "  ~ %s: (changed)\n", c.Path)
+			continue
+		}
+		fmt.Fprintf(w, "  ~ %s: %s -> %s\n", c.Path, config.FormatValue(c.Old), config.FormatValue(c.New))
+	}
+	if len(p.Files) > 0 {
+		fmt.Fprintln(w, "files:")
+		for _, f := range p.Files {
+			mark := "~"
+			if f.Status == "new" {
+				mark = "+"
+			}
+			fmt.Fprintf(w, "  %s %s (%s)\n", mark, filepath.ToSlash(f.Path), f.Status)
+		}
+	}
+	if len(p.Excluded) > 0 {
+		fmt.Fprintln(w, "kept in target (environment-specific):")
+		for _, c := range p.Excluded {
+			// segredos (config.SecretFields, mesma lista do diff) nunca vão para o stdout
+			if config.IsSecretField(c.Path) {
+				fmt.Fprintf(w, "  = %s: (redacted)\n", c.Path)
+				continue
+			}
+			fmt.Fprintf(w, "  = %s: %s (source: %s)\n", c.Path, config.FormatValue(c.Old), config.FormatValue(c.New))
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PydaVi/brainctl/internal/config"
+)
+
+func writePromoteStack(t *testing.T, dir, env, instanceType, script string) {
+	t.Helper()
+	contract := strings.NewReplacer(
+		"environment: dev", "environment: "+env,
+		"instance_type: t3.micro", "instance_type: "+instanceType,
+		"subnet_id: subnet-1", "subnet_id: subnet-"+env,
+	).Replace(testAppYAML)
+	files := map[string]string{
+		"app.yaml":                 contract,
+		"scripts/bootstrap.ps1":    script,
+		"security-groups/app.yaml": "group: app\ningress: []\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+}
+
+func TestPlanAndApplyPromotion(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	from, to := filepath.Join(root, "dev"), filepath.Join(root, "prod")
+	writePromoteStack(t, from, "dev", "t3.large", "Write-Output v2")
+	writePromoteStack(t, to, "prod", "t3.micro", "Write-Output v1")
+
+	plan, err := planPromotion(from, to, "app.yaml", promoteExcludes(nil, nil))
+	if err != nil {
+		t.Fatalf("planPromotion: %v", err)
+	}
+	if len(plan.Changes) != 1 || plan.Changes[0].Path != "ec2.instance_type" {
+		t.Fatalf("expected only instance_type to be promoted, got %+v", plan.Changes)
+	}
+	excluded := map[string]bool{}
+	for _, c := range plan.Excluded {
+		excluded[c.Path] = true
+	}
+	if !excluded["app.environment"] || !excluded["infrastructure.subnet_id"] {
+		t.Fatalf("expected environment-specific fields to be kept, got %+v", plan.Excluded)
+	}
+	if len(plan.Files) != 1 || plan.Files[0].Path != filepath.Join("scripts", "bootstrap.ps1") || plan.Files[0].Status != "changed" {
+		t.Fatalf("unexpected files: %+v", plan.Files)
+	}
+
+	if err := plan.apply(); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	cfg, err := config.LoadEffectiveConfig(filepath.Join(to, "app.yaml"), config.TagPolicyConfig{})
+	if err != nil {
+		t.Fatalf("load promoted contract: %v", err)
+	}
+	if cfg.EC2.InstanceType != "t3.large" || cfg.App.Environment != "prod" || cfg.Infrastructure.SubnetID != "subnet-prod" {
+		t.Fatalf("unexpected promoted contract: %+v", cfg.EC2)
+	}
+	script, _ := os.ReadFile(filepath.Join(to, "scripts", "bootstrap.ps1"))
+	if string(script) != "Write-Output v2" {
+		t.Fatalf("script not promoted: %q", script)
+	}
+
+	// com --include, o campo sai da lista de exclusão
+	plan, err = planPromotion(from, to, "app.yaml", promoteExcludes(nil, []string{"app.environment"}))
+	if err != nil {
+		t.Fatalf("planPromotion include: %v", err)
+	}
+	if len(plan.Changes) != 1 || plan.Changes[0].Path != "app.environment" {
+		t.Fatalf("expected app.environment to be promotable with --include, got %+v", plan.Changes)
+	}
+}
+
+func TestPrintPromotePlanRedactsSecrets(t *testing.T) {
+	t.Parallel()
+
+	secret := config.FieldChange{Path: "db.rds.password", Old: "prod-s3cret", New: "dev-s3cret"}
+	plan := &promotePlan{FromDir: "dev", ToDir: "prod", ContractFile: "app.yaml",
+		Changes: []config.FieldChange{secret}, Excluded: []config.FieldChange{secret}}
+
+	var out bytes.Buffer
+	printPromotePlan(&out, plan)
+	if strings.Contains(out.String(), "s3cret") {
+		t.Fatalf("password leaked in promote plan:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "~ db.rds.password: (changed)") || !strings.Contains(out.String(), "= db.rds.password: (redacted)") {
+		t.Fatalf("expected redacted password lines, got:\n%s", out.String())
+	}
+}
+
+func TestPlanPromotionAppliesRepoTagPolicy(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	for path, content := range map[string]string{
+		"go.mod":             "module example.com/infra\n",
+		"modules/.keep":      "",
+		"policies/tags.yaml": "required: [owner]\n",
+	} {
+		full := filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	from, to := filepath.Join(root, "stacks", "dev"), filepath.Join(root, "stacks", "prod")
+	writePromoteStack(t, from, "dev", "t3.large", "Write-Output v2")
+	writePromoteStack(t, to, "prod", "t3.micro", "Write-Output v1")
+
+	_, err := planPromotion(from, to, "app.yaml", promoteExcludes(nil, nil))
+	if err == nil || !strings.Contains(err.Error(), "tags.owner is required by the repository tag policy") {
+		t.Fatalf("expected repository tag policy error, got: %v", err)
+	}
+}
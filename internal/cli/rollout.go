@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/PydaVi/brainctl/internal/outputs"
+	"github.com/PydaVi/brainctl/internal/rollout"
+)
+
+// newRolloutClient isola a criação do client AWS usado pelo rollout (substituível por rollout.FakeClient).
+var newRolloutClient = func(region string) rollout.Client {
+	return rollout.NewCLIClient(region)
+}
+
+func newRolloutCommand(opts *RuntimeOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollout",
+		Short: "Track or cancel the app ASG instance refresh (app_scaling.rollout)",
+	}
+
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the latest instance refresh of the app ASG (--watch follows until it finishes)",
+		RunE: withRuntime(*opts, true, func(cmd *cobra.Command, args []string, ctx *runtimeContext) error {
+			asgName, err := rolloutASGName(ctx)
+			if err != nil {
+				return err
+			}
+			client := newRolloutClient(ctx.Config.App.App.Region)
+			watch, _ := cmd.Flags().GetBool("watch")
+			interval, _ := cmd.Flags().GetDuration("interval")
+			return rolloutStatus(os.Stdout, client, asgName, watch, interval)
+		}),
+	}
+	applyCommonFlags(statusCmd, opts)
+	statusCmd.Flags().Bool("watch", false, "Poll until the instance refresh reaches a final state")
+	statusCmd.Flags().Duration("interval", 15*time.Second, "Polling interval used with --watch")
+
+	cancelCmd := &cobra.Command{
+		Use:   "cancel",
+		Short: "Cancel the active instance refresh of the app ASG (--rollback restores the previous launch template)",
+		RunE: withRuntime(*opts, true, func(cmd *cobra.Command, args []string, ctx *runtimeContext) error {
+			asgName, err := rolloutASGName(ctx)
+			if err != nil {
+				return err
+			}
+			rollback, _ := cmd.Flags().GetBool("rollback")
+			return rolloutCancel(os.Stdout, newRolloutClient(ctx.Config.App.App.Region), asgName, rollback)
+		}),
+	}
+	applyCommonFlags(cancelCmd, opts)
+	cancelCmd.Flags().Bool("rollback", false, "Roll back instances already replaced instead of only stopping the refresh")
+
+	cmd.AddCommand(statusCmd, cancelCmd)
+	return cmd
+}
+
+// rolloutASGName lê o nome do ASG dos outputs; rollout só existe com app_scaling.enabled.
+func rolloutASGName(ctx *runtimeContext) (string, error) {
+	cfg := ctx.Config.App
+	if cfg.Workload.Type != "ec2-app" || !cfg.AppScaling.Enabled {
+		return "", fmt.Errorf("rollout requires workload ec2-app with app_scaling.enabled=true")
+	}
+	raw, err := ctx.Runner.OutputJSON()
+	if err != nil {
+		return "", err
+	}
+	vals, err := outputs.ParseTerraformOutputJSON(raw)
+	if err != nil {
+		return "", fmt.Errorf("parse outputs: %w", err)
+	}
+	name := outputs.AsString(vals["app_asg_name"], "")
+	if name == "" {
+		return "", fmt.Errorf("output app_asg_name is empty; run apply first")
+	}
+	return name, nil
+}
+
+func rolloutStatus(w io.Writer, c rollout.Client, asgName string, watch bool, interval time.Duration) error {
+	if !watch {
+		r, err := rollout.Latest(c, asgName)
+		if err != nil {
+			return err
+		}
+		rollout.Render(w, asgName, r)
+		return nil
+	}
+
+	final, err := rollout.Watch(c, asgName, interval, func(r *rollout.Refresh) {
+		fmt.Fprintf(w, "[rollout] %s %s %d%%\n", r.ID, r.Status, r.PercentageComplete)
+	})
+	if err != nil {
+		return err
+	}
+	rollout.Render(w, asgName, final)
+	if final != nil && final.Status != "Successful" {
+		return fmt.Errorf("instance refresh %s finished with status %s", final.ID, final.Status)
+	}
+	return nil
+}
+
+func rolloutCancel(w io.Writer, c rollout.Client, asgName string, rollback bool) error {
+	if rollback {
+		id, err := c.RollbackInstanceRefresh(asgName)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "[rollout] rollback iniciado para o refresh %s (ASG %s)\n", id, asgName)
+		return nil
+	}
+	id, err := c.CancelInstanceRefresh(asgName)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "[rollout] refresh %s cancelado (ASG %s); instâncias já substituídas permanecem\n", id, asgName)
+	return nil
+}
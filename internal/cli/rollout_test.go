@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PydaVi/brainctl/internal/rollout"
+)
+
+func TestRolloutStatusWatchFailsOnRollback(t *testing.T) {
+	t.Parallel()
+
+	fake := &rollout.FakeClient{Refreshes: [][]rollout.Refresh{
+		{{ID: "r-1", Status: "InProgress", PercentageComplete: 40}},
+		{{ID: "r-1", Status: "RollbackSuccessful", PercentageComplete: 40, StatusReason: "alarm sev1-app-tg-unhealthy-hosts in ALARM"}},
+	}}
+
+	var out bytes.Buffer
+	err := rolloutStatus(&out, fake, "billing-dev-asg", true, time.Millisecond)
+	if err == nil || err.Error() != "instance refresh r-1 finished with status RollbackSuccessful" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "[rollout] r-1 InProgress 40%") || !strings.Contains(out.String(), "motivo    : alarm") {
+		t.Fatalf("unexpected output:\n%s", out.String())
+	}
+}
+
+func TestRolloutCancel(t *testing.T) {
+	t.Parallel()
+
+	fake := &rollout.FakeClient{Refreshes: [][]rollout.Refresh{{{ID: "r-1", Status: "InProgress"}}}}
+	var out bytes.Buffer
+	if err := rolloutCancel(&out, fake, "billing-dev-asg", false); err != nil {
+		t.Fatalf("rolloutCancel: %v", err)
+	}
+	if len(fake.Cancelled) != 1 || len(fake.RolledBack) != 0 || !strings.Contains(out.String(), "refresh r-1 cancelado") {
+		t.Fatalf("unexpected cancel result: %+v\n%s", fake, out.String())
+	}
+}
@@ -2,11 +2,11 @@ package cli
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 
 	"github.com/PydaVi/brainctl/internal/blueprints"
-	"github.com/PydaVi/brainctl/internal/outputs"
 	"github.com/PydaVi/brainctl/internal/terragrunt"
 )
 
@@ -29,7 +29,7 @@ func NewRootCommand() *cobra.Command {
 	planCmd := &cobra.Command{
 		Use:   "plan",
 		Short: "Generate workspace and run terragrunt init/plan",
-		RunE:  withRuntime(opts, true, func(cmd *cobra.Command, args []string, ctx *runtimeContext) error { return ctx.Runner.Plan() }),
+		RunE:  withRuntime(opts, true, planRun),
 	}
 	applyCommonFlags(planCmd, &opts)
 
@@ -39,6 +39,7 @@ func NewRootCommand() *cobra.Command {
 		RunE: withRuntime(opts, true, func(cmd *cobra.Command, args []string, ctx *runtimeContext) error {
 			autoApprove, _ := cmd.Flags().GetBool("auto-approve")
 			forceInstanceModify, _ := cmd.Flags().GetBool("force-instance-modify")
+			forceCostBudget, _ := cmd.Flags().GetBool("force-cost-budget")
 
 			planFile := "brainctl.apply.tfplan"
 			if err := ctx.Runner.PlanOut(planFile); err != nil {
@@ -54,6 +55,23 @@ func NewRootCommand() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("parse terraform plan json: %w", err)
 			}
+			var fleet []string
+			if ctx.Config.App.Workload.Type == "ec2-app" {
+				fleet, err = detectFleetChanges(planJSON)
+				if err != nil {
+					return fmt.Errorf("parse terraform plan json: %w", err)
+				}
+			}
+			if len(fleet) > 0 {
+				if ctx.Config.App.AppScaling.Rollout.Enabled {
+					// O instance refresh substitui a frota inteira: entra no guardrail como as instâncias fixas.
+					for _, addr := range fleet {
+						resources = append(resources, addr+" (instance refresh: substituição gradual do ASG)")
+					}
+				} else {
+					fmt.Println("[aviso] launch template do ASG alterado sem app_scaling.rollout: só instâncias novas usarão a nova versão.")
+				}
+			}
 
 			if len(resources) > 0 && !forceInstanceModify {
 				ok, err := confirmInstanceModify(resources)
@@ -65,12 +83,32 @@ func NewRootCommand() *cobra.Command {
 				}
 			}
 
+			if !forceCostBudget {
+				budget, err := evaluatePlanBudget(ctx, planJSON)
+				if err != nil {
+					// Com budget_action=block, não avaliar o orçamento também bloqueia.
+					if ctx.Config.App.Cost.BudgetAction == "block" {
+						return err
+					}
+					fmt.Printf("[cost] aviso: %v\n", err)
+				} else if budget != nil && budget.Exceeded() {
+					ok, err := confirmCostBudget(budget, ctx.Config.App.Cost.BudgetAction)
+					if err != nil {
+						return err
+					}
+					if !ok {
+						return fmt.Errorf("apply aborted by guardrail (cost budget not confirmed)")
+					}
+				}
+			}
+
 			return ctx.Runner.ApplyPlan(planFile, autoApprove)
 		}),
 	}
 	applyCommonFlags(applyCmd, &opts)
 	applyCmd.Flags().Bool("auto-approve", true, "Skip interactive approval (default: true)")
 	applyCmd.Flags().Bool("force-instance-modify", false, "Bypass guardrail confirmation when plan includes instance update/replace")
+	applyCmd.Flags().Bool("force-cost-budget", false, "Bypass cost budget guardrail (cost.budget_monthly_usd / cost.max_delta_monthly_usd)")
 
 	destroyCmd := &cobra.Command{
 		Use:   "destroy",
@@ -83,28 +121,18 @@ func NewRootCommand() *cobra.Command {
 	applyCommonFlags(destroyCmd, &opts)
 	destroyCmd.Flags().Bool("auto-approve", true, "Skip interactive approval (default: true)")
 
-	statusCmd := &cobra.Command{
-		Use:   "status",
-		Short: "Show workspace/backend/state and key resource info (pretty)",
-		RunE:  withRuntime(opts, true, statusRun),
-	}
-	applyCommonFlags(statusCmd, &opts)
+	statusCmd := newStatusCommand(&opts)
 
-	outputCmd := &cobra.Command{
-		Use:   "output",
-		Short: "Print terragrunt outputs (json)",
-		RunE: withRuntime(opts, true, func(cmd *cobra.Command, args []string, ctx *runtimeContext) error {
-			out, err := ctx.Runner.OutputJSON()
-			if err != nil {
-				return err
-			}
-			fmt.Println(string(out))
-			return nil
-		}),
-	}
-	applyCommonFlags(outputCmd, &opts)
+	outputCmd := newOutputCommand(&opts)
 
 	costCmd := newCostCommand(&opts)
+	doctorCmd := newDoctorCommand(&opts)
+
+	initCmd := newInitCommand()
+	promoteCmd := newPromoteCommand()
+	diffCmd := newDiffCommand()
+	migrateCmd := newMigrateCommand(&opts)
+	rolloutCmd := newRolloutCommand(&opts)
 
 	blueprintsCmd := &cobra.Command{
 		Use:   "blueprints",
@@ -117,7 +145,7 @@ func NewRootCommand() *cobra.Command {
 		},
 	}
 
-	root.AddCommand(planCmd, applyCmd, destroyCmd, statusCmd, outputCmd, costCmd, blueprintsCmd)
+	root.AddCommand(initCmd, planCmd, applyCmd, destroyCmd, statusCmd, outputCmd, costCmd, doctorCmd, diffCmd, promoteCmd, migrateCmd, rolloutCmd, blueprintsCmd)
 	return root
 }
 
@@ -128,6 +156,9 @@ func withRuntime(opts RuntimeOptions, initTerragrunt bool, run commandRunner) fu
 		if err != nil {
 			return err
 		}
+		for _, w := range cfg.Warnings {
+			fmt.Fprintf(os.Stderr, "[aviso] %s\n", w)
+		}
 
 		wsDir, err := cfg.PrepareWorkspace()
 		if err != nil {
@@ -146,6 +177,39 @@ func withRuntime(opts RuntimeOptions, initTerragrunt bool, run commandRunner) fu
 	}
 }
 
+// planRun executa o plan; com orçamento no contrato, salva o plano para avaliar o custo
+// (apenas aviso, o bloqueio acontece no apply).
+func planRun(cmd *cobra.Command, args []string, ctx *runtimeContext) error {
+	cfg := ctx.Config.App
+	if !cfg.Cost.BudgetEnabled(cfg.App.Environment) {
+		return ctx.Runner.Plan()
+	}
+
+	planFile := "brainctl.plan.tfplan"
+	if err := ctx.Runner.PlanOut(planFile); err != nil {
+		return err
+	}
+	planJSON, err := ctx.Runner.ShowPlanJSON(planFile)
+	if err != nil {
+		return err
+	}
+
+	budget, err := evaluatePlanBudget(ctx, planJSON)
+	if err != nil {
+		fmt.Printf("[cost] aviso: %v\n", err)
+		return nil
+	}
+	printBudgetResult(budget)
+	if budget.Exceeded() {
+		if cfg.Cost.BudgetAction == "block" {
+			fmt.Println("[cost] o apply será bloqueado (budget_action=block)")
+		} else {
+			fmt.Println("[cost] o apply vai exigir confirmação (budget_action=confirm)")
+		}
+	}
+	return nil
+}
+
 func applyCommonFlags(cmd *cobra.Command, opts *RuntimeOptions) {
 	cmd.Flags().StringVarP(&opts.File, "file", "f", "app.yaml", "Path to app.yaml (relative to --stack-dir when not absolute)")
 	cmd.Flags().StringVar(&opts.StackDir, "stack-dir", ".", "Stack directory (ex: stacks/dev)")
@@ -162,66 +226,3 @@ func optionsFromFlags(cmd *cobra.Command) RuntimeOptions {
 		SecurityGroupsDir: securityGroupsDir,
 	}
 }
-
-func statusRun(cmd *cobra.Command, args []string, ctx *runtimeContext) error {
-	cfg := ctx.Config.App
-	backendKey := cfg.TerraformBackendKey()
-
-	fmt.Println("== brainctl status ==")
-	fmt.Printf("App: %s (%s)\n", cfg.App.Name, cfg.App.Environment)
-	fmt.Printf("Workload: %s@%s\n", cfg.Workload.Type, cfg.Workload.Version)
-	fmt.Printf("Region: %s\n", cfg.App.Region)
-	fmt.Printf("Stack dir: %s\n", ctx.Config.Opts.StackDir)
-	fmt.Printf("Workspace: %s\n", ctx.WSDir)
-	fmt.Printf("Backend bucket: %s\n", cfg.Terraform.Backend.Bucket)
-	fmt.Printf("Backend region: %s\n", cfg.Terraform.Backend.Region)
-	fmt.Printf("Backend key: %s\n", backendKey)
-	if cfg.AppScaling.Enabled {
-		fmt.Printf("App scaling: enabled (min=%d desired=%d max=%d cpu_target=%.1f)\n", cfg.AppScaling.MinSize, cfg.AppScaling.DesiredCapacity, cfg.AppScaling.MaxSize, cfg.AppScaling.CPUTarget)
-	} else {
-		fmt.Println("App scaling: disabled")
-	}
-	if cfg.EC2.IMDSv2Required {
-		fmt.Println("IMDSv2: required")
-	} else {
-		fmt.Println("IMDSv2: optional")
-	}
-	if cfg.Observability.Enabled != nil && *cfg.Observability.Enabled {
-		fmt.Printf("Observability: enabled (cpu_high_threshold=%d)\n", cfg.Observability.CPUHighThreshold)
-	} else {
-		fmt.Println("Observability: disabled")
-	}
-	if len(cfg.RuntimeOverrides.AppExtraIngress)+len(cfg.RuntimeOverrides.DBExtraIngress)+len(cfg.RuntimeOverrides.ALBExtraIngress) > 0 {
-		fmt.Printf("Security group rules: app_extra_ingress_rules=%d db_extra_ingress_rules=%d alb_extra_ingress_rules=%d\n",
-			len(cfg.RuntimeOverrides.AppExtraIngress), len(cfg.RuntimeOverrides.DBExtraIngress), len(cfg.RuntimeOverrides.ALBExtraIngress))
-	}
-	if cfg.Recovery.Enabled {
-		fmt.Printf("Recovery: enabled (time_utc=%s retention_days=%d backup_app=%t backup_db=%t runbooks=%t)\n",
-			cfg.Recovery.SnapshotTimeUTC, cfg.Recovery.RetentionDays,
-			cfg.Recovery.BackupApp != nil && *cfg.Recovery.BackupApp,
-			cfg.Recovery.BackupDB != nil && *cfg.Recovery.BackupDB,
-			cfg.Recovery.EnableRunbooks != nil && *cfg.Recovery.EnableRunbooks)
-	} else {
-		fmt.Println("Recovery: disabled")
-	}
-
-	present, _ := ctx.Runner.StatePull()
-	if !present {
-		fmt.Println("State: missing/unreachable")
-		return nil
-	}
-	fmt.Println("State: present")
-	fmt.Println()
-
-	raw, err := ctx.Runner.OutputJSON()
-	if err != nil {
-		fmt.Println("Outputs: unavailable (terragrunt output failed)")
-		return nil
-	}
-	vals, err := outputs.ParseTerraformOutputJSON(raw)
-	if err != nil {
-		return fmt.Errorf("parse outputs: %w", err)
-	}
-	outputs.PrintPrettyStatus(vals)
-	return nil
-}
@@ -2,11 +2,16 @@ package cli
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 
+	"gopkg.in/yaml.v3"
+
+	"github.com/PydaVi/brainctl/internal/blueprints"
 	"github.com/PydaVi/brainctl/internal/config"
 	"github.com/PydaVi/brainctl/internal/generator"
+	"github.com/PydaVi/brainctl/internal/migrate"
 )
 
 type RuntimeOptions struct {
@@ -18,6 +23,8 @@ type RuntimeOptions struct {
 type RuntimeConfig struct {
 	App  *config.AppConfig
 	Opts RuntimeOptions
+	// Warnings são avisos não bloqueantes do contrato (campos obsoletos, versão antiga).
+	Warnings []string
 }
 
 func LoadRuntimeConfig(opts RuntimeOptions) (*RuntimeConfig, error) {
@@ -49,11 +56,61 @@ func LoadRuntimeConfig(opts RuntimeOptions) (*RuntimeConfig, error) {
 		}
 	}
 
+	if cfg.RepoTagPolicy, err = loadRepoTagPolicy(cfgPath); err != nil {
+		return nil, err
+	}
+
 	if err := cfg.Validate(); err != nil {
 		return nil, err
 	}
 
-	return &RuntimeConfig{App: cfg, Opts: opts}, nil
+	warnings, err := contractWarnings(cfgPath, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &RuntimeConfig{App: cfg, Opts: opts, Warnings: warnings}, nil
+}
+
+// loadRepoTagPolicy lê a política de tags do repositório que contém o contrato. Ela fica
+// fora da stack; sem raiz de repo (ex.: stack avulsa) não há política a aplicar, e o
+// generate falha depois de qualquer forma.
+func loadRepoTagPolicy(cfgPath string) (config.TagPolicyConfig, error) {
+	absCfg, err := filepath.Abs(cfgPath)
+	if err != nil {
+		return config.TagPolicyConfig{}, nil
+	}
+	root, err := generator.FindRepoRoot(filepath.Dir(absCfg))
+	if err != nil {
+		return config.TagPolicyConfig{}, nil
+	}
+	policy, err := config.LoadTagPolicy(filepath.Join(root, config.RepoTagPolicyFile))
+	if err != nil {
+		return config.TagPolicyConfig{}, fmt.Errorf("%s: %w", config.RepoTagPolicyFile, err)
+	}
+	return policy, nil
+}
+
+// contractWarnings relê o contrato como yaml.Node: obsolescência depende de o campo
+// estar escrito, não do valor efetivo após os defaults.
+func contractWarnings(path string, cfg *config.AppConfig) ([]string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	target, err := blueprints.MigrationTarget(cfg.Workload.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	if cfg.Workload.Version != target.Latest {
+		warnings = append(warnings, fmt.Sprintf("contrato em %s@%s; a versão mais recente é %s. Execute `brainctl migrate`.", cfg.Workload.Type, cfg.Workload.Version, target.Latest))
+	}
+	return append(warnings, migrate.CheckDeprecations(&doc, target.Deprecations)...), nil
 }
 
 func (r *RuntimeConfig) PrepareWorkspace() (string, error) {
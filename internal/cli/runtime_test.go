@@ -66,3 +66,28 @@ func TestLoadRuntimeConfigIgnoresMissingSecurityGroupsDir(t *testing.T) {
 		t.Fatalf("expected missing security-groups dir to be ignored, got: %v", err)
 	}
 }
+
+func TestLoadRuntimeConfigAppliesRepoTagPolicy(t *testing.T) {
+	root := t.TempDir()
+	stackDir := filepath.Join(root, "stacks", "app", "dev")
+	for _, dir := range []string{stackDir, filepath.Join(root, "modules"), filepath.Join(root, "policies")} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("mkdir %s: %v", dir, err)
+		}
+	}
+	files := map[string]string{
+		filepath.Join(root, "go.mod"):                "module example.com/infra\n",
+		filepath.Join(root, "policies", "tags.yaml"): "required: [owner]\n",
+		filepath.Join(stackDir, "app.yaml"):          testAppYAML,
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+
+	_, err := LoadRuntimeConfig(RuntimeOptions{File: "app.yaml", StackDir: stackDir})
+	if err == nil || err.Error() != "tags.owner is required by the repository tag policy (policies/tags.yaml)" {
+		t.Fatalf("expected repository tag policy error, got: %v", err)
+	}
+}
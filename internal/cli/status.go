@@ -0,0 +1,375 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/PydaVi/brainctl/internal/blueprints"
+	"github.com/PydaVi/brainctl/internal/health"
+	"github.com/PydaVi/brainctl/internal/outputs"
+)
+
+// statusSource é o subconjunto do runner usado pelo status (facilita testes sem terragrunt).
+type statusSource interface {
+	Init() error
+	StatePull() (bool, error)
+	OutputJSON() ([]byte, error)
+}
+
+// statusReport é a visão estruturada do status, consumida pelo texto e por --format json|yaml.
+type statusReport struct {
+	App           string               `json:"app" yaml:"app"`
+	Environment   string               `json:"environment" yaml:"environment"`
+	Region        string               `json:"region" yaml:"region"`
+	Workload      statusWorkload       `json:"workload" yaml:"workload"`
+	StackDir      string               `json:"stack_dir" yaml:"stack_dir"`
+	Workspace     string               `json:"workspace" yaml:"workspace"`
+	Backend       statusBackend        `json:"backend" yaml:"backend"`
+	Scaling       *statusScaling       `json:"scaling,omitempty" yaml:"scaling,omitempty"`
+	OS            string               `json:"os,omitempty" yaml:"os,omitempty"`
+	IMDSv2        string               `json:"imdsv2,omitempty" yaml:"imdsv2,omitempty"`
+	Observability *statusObservability `json:"observability,omitempty" yaml:"observability,omitempty"`
+	SGRules       *statusSGRules       `json:"security_group_rules,omitempty" yaml:"security_group_rules,omitempty"`
+	Recovery      *statusRecovery      `json:"recovery,omitempty" yaml:"recovery,omitempty"`
+	Cluster       *statusCluster       `json:"cluster,omitempty" yaml:"cluster,omitempty"`
+	State         statusState          `json:"state" yaml:"state"`
+	Outputs       map[string]any       `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+	Live          *health.Report       `json:"live,omitempty" yaml:"live,omitempty"`
+	Errors        []statusError        `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+type statusWorkload struct {
+	Type    string `json:"type" yaml:"type"`
+	Version string `json:"version" yaml:"version"`
+}
+
+type statusBackend struct {
+	Bucket string `json:"bucket" yaml:"bucket"`
+	Region string `json:"region" yaml:"region"`
+	Key    string `json:"key" yaml:"key"`
+}
+
+type statusScaling struct {
+	Enabled         bool    `json:"enabled" yaml:"enabled"`
+	MinSize         int     `json:"min_size,omitempty" yaml:"min_size,omitempty"`
+	DesiredCapacity int     `json:"desired_capacity,omitempty" yaml:"desired_capacity,omitempty"`
+	MaxSize         int     `json:"max_size,omitempty" yaml:"max_size,omitempty"`
+	CPUTarget       float64 `json:"cpu_target,omitempty" yaml:"cpu_target,omitempty"`
+}
+
+type statusObservability struct {
+	Enabled          bool `json:"enabled" yaml:"enabled"`
+	CPUHighThreshold int  `json:"cpu_high_threshold,omitempty" yaml:"cpu_high_threshold,omitempty"`
+}
+
+type statusSGRules struct {
+	App int `json:"app_extra_ingress_rules" yaml:"app_extra_ingress_rules"`
+	DB  int `json:"db_extra_ingress_rules" yaml:"db_extra_ingress_rules"`
+	ALB int `json:"alb_extra_ingress_rules" yaml:"alb_extra_ingress_rules"`
+}
+
+type statusRecovery struct {
+	Enabled         bool   `json:"enabled" yaml:"enabled"`
+	SnapshotTimeUTC string `json:"snapshot_time_utc,omitempty" yaml:"snapshot_time_utc,omitempty"`
+	RetentionDays   int    `json:"retention_days,omitempty" yaml:"retention_days,omitempty"`
+	BackupApp       bool   `json:"backup_app,omitempty" yaml:"backup_app,omitempty"`
+	BackupDB        bool   `json:"backup_db,omitempty" yaml:"backup_db,omitempty"`
+	Runbooks        bool   `json:"runbooks,omitempty" yaml:"runbooks,omitempty"`
+}
+
+type statusCluster struct {
+	KubernetesVersion        string           `json:"kubernetes_version" yaml:"kubernetes_version"`
+	ControlPlaneInstanceType string           `json:"control_plane_instance_type" yaml:"control_plane_instance_type"`
+	ControlPlaneCount        int              `json:"control_plane_count" yaml:"control_plane_count"`
+	WorkerInstanceType       string           `json:"worker_instance_type" yaml:"worker_instance_type"`
+	WorkerCount              int              `json:"worker_count" yaml:"worker_count"`
+	NodePools                []statusNodePool `json:"node_pools" yaml:"node_pools"`
+}
+
+type statusNodePool struct {
+	Name            string   `json:"name" yaml:"name"`
+	InstanceTypes   []string `json:"instance_types" yaml:"instance_types"`
+	MinSize         int      `json:"min_size" yaml:"min_size"`
+	MaxSize         int      `json:"max_size" yaml:"max_size"`
+	DesiredCapacity int      `json:"desired_capacity" yaml:"desired_capacity"`
+	Spot            bool     `json:"spot" yaml:"spot"`
+}
+
+type statusState struct {
+	Present bool `json:"present" yaml:"present"`
+}
+
+// newHealthClient isola a criação do client AWS usado pelo --live (substituível por health.FakeClient).
+var newHealthClient = func(region string) health.Client {
+	return health.NewCLIClient(region)
+}
+
+// statusError registra a etapa que falhou sem interromper o restante do relatório.
+type statusError struct {
+	Stage   string `json:"stage" yaml:"stage"`
+	Message string `json:"message" yaml:"message"`
+}
+
+func newStatusCommand(opts *RuntimeOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show workspace/backend/state and key resource info (text, json or yaml)",
+		RunE: withRuntime(*opts, false, func(cmd *cobra.Command, args []string, ctx *runtimeContext) error {
+			format, _ := cmd.Flags().GetString("format")
+			switch format {
+			case "text", "json", "yaml":
+			default:
+				return fmt.Errorf("invalid --format %q (allowed: text, json, yaml)", format)
+			}
+
+			report := buildStatusReport(ctx.Config, ctx.WSDir, ctx.Runner)
+			if live, _ := cmd.Flags().GetBool("live"); live {
+				attachLiveHealth(report, newHealthClient(report.Region))
+			}
+			switch format {
+			case "json":
+				b, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(b))
+				return report.exitError()
+			case "yaml":
+				b, err := yaml.Marshal(report)
+				if err != nil {
+					return err
+				}
+				fmt.Print(string(b))
+				return report.exitError()
+			}
+
+			// No modo texto, falha de init continua abortando o comando como antes.
+			for _, e := range report.Errors {
+				if e.Stage == "init" {
+					return fmt.Errorf("%s", e.Message)
+				}
+			}
+			return renderStatusText(os.Stdout, report)
+		}),
+	}
+
+	applyCommonFlags(cmd, opts)
+	cmd.Flags().String("format", "text", "Output format: text, json or yaml")
+	cmd.Flags().Bool("live", false, "Query AWS for instance state, ASG capacity, target health, alarms and last snapshot")
+	return cmd
+}
+
+// buildStatusReport monta o resumo do contrato e consulta state/outputs, acumulando erros por etapa.
+func buildStatusReport(rc *RuntimeConfig, wsDir string, src statusSource) *statusReport {
+	cfg := rc.App
+	r := &statusReport{
+		App:         cfg.App.Name,
+		Environment: cfg.App.Environment,
+		Region:      cfg.App.Region,
+		Workload:    statusWorkload{Type: cfg.Workload.Type, Version: cfg.Workload.Version},
+		StackDir:    rc.Opts.StackDir,
+		Workspace:   wsDir,
+		Backend: statusBackend{
+			Bucket: cfg.Terraform.Backend.Bucket,
+			Region: cfg.Terraform.Backend.Region,
+			Key:    cfg.TerraformBackendKey(),
+		},
+	}
+
+	if cfg.Workload.Type == "k8s-workers" {
+		r.Cluster = &statusCluster{
+			KubernetesVersion:        cfg.K8s.KubernetesVersion,
+			ControlPlaneInstanceType: cfg.K8s.ControlPlaneInstanceType,
+			ControlPlaneCount:        cfg.K8s.ControlPlaneCount,
+			WorkerInstanceType:       cfg.K8s.WorkerInstanceType,
+		}
+		for _, p := range cfg.K8s.EffectiveNodePools() {
+			r.Cluster.WorkerCount += p.DesiredCapacity
+			r.Cluster.NodePools = append(r.Cluster.NodePools, statusNodePool{
+				Name:            p.Name,
+				InstanceTypes:   p.InstanceTypes,
+				MinSize:         p.MinSize,
+				MaxSize:         p.MaxSize,
+				DesiredCapacity: p.DesiredCapacity,
+				Spot:            p.Spot,
+			})
+		}
+	} else {
+		r.Scaling = &statusScaling{Enabled: cfg.AppScaling.Enabled}
+		if cfg.AppScaling.Enabled {
+			r.Scaling.MinSize = cfg.AppScaling.MinSize
+			r.Scaling.DesiredCapacity = cfg.AppScaling.DesiredCapacity
+			r.Scaling.MaxSize = cfg.AppScaling.MaxSize
+			r.Scaling.CPUTarget = cfg.AppScaling.CPUTarget
+		}
+		r.OS = cfg.EC2.OS
+		// o módulo ec2-app fixa http_tokens=required (ec2.imds_v2_required está obsoleto)
+		r.IMDSv2 = "required"
+		r.Observability = &statusObservability{Enabled: cfg.Observability.Enabled != nil && *cfg.Observability.Enabled}
+		if r.Observability.Enabled {
+			r.Observability.CPUHighThreshold = cfg.Observability.CPUHighThreshold
+		}
+		ov := cfg.RuntimeOverrides
+		if len(ov.AppExtraIngress)+len(ov.DBExtraIngress)+len(ov.ALBExtraIngress) > 0 {
+			r.SGRules = &statusSGRules{App: len(ov.AppExtraIngress), DB: len(ov.DBExtraIngress), ALB: len(ov.ALBExtraIngress)}
+		}
+		r.Recovery = &statusRecovery{Enabled: cfg.Recovery.Enabled}
+		if cfg.Recovery.Enabled {
+			r.Recovery.SnapshotTimeUTC = cfg.Recovery.SnapshotTimeUTC
+			r.Recovery.RetentionDays = cfg.Recovery.RetentionDays
+			r.Recovery.BackupApp = cfg.Recovery.BackupApp != nil && *cfg.Recovery.BackupApp
+			r.Recovery.BackupDB = cfg.Recovery.BackupDB != nil && *cfg.Recovery.BackupDB
+			r.Recovery.Runbooks = cfg.Recovery.EnableRunbooks != nil && *cfg.Recovery.EnableRunbooks
+		}
+	}
+
+	if err := src.Init(); err != nil {
+		r.addError("init", err)
+		return r
+	}
+
+	present, err := src.StatePull()
+	if err != nil {
+		r.addError("state", err)
+	}
+	r.State.Present = present
+	if !present {
+		return r
+	}
+
+	raw, err := src.OutputJSON()
+	if err != nil {
+		r.addError("outputs", err)
+		return r
+	}
+	vals, err := outputs.ParseTerraformOutputJSON(raw)
+	if err != nil {
+		r.addError("outputs", fmt.Errorf("parse outputs: %w", err))
+		return r
+	}
+	r.Outputs = vals
+	return r
+}
+
+// attachLiveHealth consulta a AWS usando os outputs já coletados no relatório.
+func attachLiveHealth(r *statusReport, c health.Client) {
+	if r.Outputs == nil {
+		r.Errors = append(r.Errors, statusError{Stage: "live", Message: "live checks need terraform outputs (state missing or outputs unavailable)"})
+		return
+	}
+	r.Live = health.Check(c, health.Target{App: r.App, Environment: r.Environment, Outputs: r.Outputs})
+}
+
+func (r *statusReport) addError(stage string, err error) {
+	r.Errors = append(r.Errors, statusError{Stage: stage, Message: err.Error()})
+}
+
+// exitError faz --format json|yaml sair com erro depois de imprimir o report quando
+// ele registrou falhas, para que CI não trate um status parcial como sucesso.
+func (r *statusReport) exitError() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	stages := make([]string, 0, len(r.Errors))
+	for _, e := range r.Errors {
+		stages = append(stages, e.Stage)
+	}
+	return fmt.Errorf("status reported %d error(s) (%s)", len(r.Errors), strings.Join(stages, ", "))
+}
+
+func (r *statusReport) stageError(stage string) *statusError {
+	for i := range r.Errors {
+		if r.Errors[i].Stage == stage {
+			return &r.Errors[i]
+		}
+	}
+	return nil
+}
+
+// renderStatusText mantém o layout humano do status, delegando os recursos ao blueprint.
+func renderStatusText(w io.Writer, r *statusReport) error {
+	fmt.Fprintln(w, "== brainctl status ==")
+	fmt.Fprintf(w, "App: %s (%s)\n", r.App, r.Environment)
+	fmt.Fprintf(w, "Workload: %s@%s\n", r.Workload.Type, r.Workload.Version)
+	fmt.Fprintf(w, "Region: %s\n", r.Region)
+	fmt.Fprintf(w, "Stack dir: %s\n", r.StackDir)
+	fmt.Fprintf(w, "Workspace: %s\n", r.Workspace)
+	fmt.Fprintf(w, "Backend bucket: %s\n", r.Backend.Bucket)
+	fmt.Fprintf(w, "Backend region: %s\n", r.Backend.Region)
+	fmt.Fprintf(w, "Backend key: %s\n", r.Backend.Key)
+	if c := r.Cluster; c != nil {
+		fmt.Fprintf(w, "Cluster: kubernetes=%s control_plane=%d x %s workers=%d\n",
+			c.KubernetesVersion, max(c.ControlPlaneCount, 1), c.ControlPlaneInstanceType, c.WorkerCount)
+		for _, p := range c.NodePools {
+			spot := ""
+			if p.Spot {
+				spot = " spot"
+			}
+			fmt.Fprintf(w, "  node pool %s: %d (min %d, max %d) x %s%s\n", p.Name, p.DesiredCapacity, p.MinSize, p.MaxSize, strings.Join(p.InstanceTypes, "|"), spot)
+		}
+	}
+	if s := r.Scaling; s != nil {
+		if s.Enabled {
+			fmt.Fprintf(w, "App scaling: enabled (min=%d desired=%d max=%d cpu_target=%.1f)\n", s.MinSize, s.DesiredCapacity, s.MaxSize, s.CPUTarget)
+		} else {
+			fmt.Fprintln(w, "App scaling: disabled")
+		}
+	}
+	if r.OS != "" {
+		fmt.Fprintf(w, "OS: %s\n", r.OS)
+	}
+	if r.IMDSv2 != "" {
+		fmt.Fprintf(w, "IMDSv2: %s\n", r.IMDSv2)
+	}
+	if o := r.Observability; o != nil {
+		if o.Enabled {
+			fmt.Fprintf(w, "Observability: enabled (cpu_high_threshold=%d)\n", o.CPUHighThreshold)
+		} else {
+			fmt.Fprintln(w, "Observability: disabled")
+		}
+	}
+	if sg := r.SGRules; sg != nil {
+		fmt.Fprintf(w, "Security group rules: app_extra_ingress_rules=%d db_extra_ingress_rules=%d alb_extra_ingress_rules=%d\n", sg.App, sg.DB, sg.ALB)
+	}
+	if rec := r.Recovery; rec != nil {
+		if rec.Enabled {
+			fmt.Fprintf(w, "Recovery: enabled (time_utc=%s retention_days=%d backup_app=%t backup_db=%t runbooks=%t)\n",
+				rec.SnapshotTimeUTC, rec.RetentionDays, rec.BackupApp, rec.BackupDB, rec.Runbooks)
+		} else {
+			fmt.Fprintln(w, "Recovery: disabled")
+		}
+	}
+
+	if !r.State.Present {
+		fmt.Fprintln(w, "State: missing/unreachable")
+		if e := r.stageError("state"); e != nil {
+			fmt.Fprintf(w, "  error: %s\n", e.Message)
+		}
+		return nil
+	}
+	fmt.Fprintln(w, "State: present")
+	fmt.Fprintln(w)
+
+	if e := r.stageError("outputs"); e != nil {
+		fmt.Fprintln(w, "Outputs: unavailable (terragrunt output failed)")
+		fmt.Fprintf(w, "  error: %s\n", e.Message)
+		return nil
+	}
+
+	bp, err := blueprints.Resolve(r.Workload.Type, r.Workload.Version)
+	if err != nil {
+		return err
+	}
+	bp.RenderStatus(w, r.Outputs)
+	if r.Live != nil {
+		r.Live.Render(w, time.Now())
+	}
+	return nil
+}
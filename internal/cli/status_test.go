@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PydaVi/brainctl/internal/health"
+)
+
+type fakeStatusSource struct {
+	present  bool
+	stateErr error
+	outputs  string
+	outErr   error
+}
+
+func (f fakeStatusSource) Init() error { return nil }
+
+func (f fakeStatusSource) StatePull() (bool, error) { return f.present, f.stateErr }
+
+func (f fakeStatusSource) OutputJSON() ([]byte, error) { return []byte(f.outputs), f.outErr }
+
+func loadStatusTestConfig(t *testing.T) *RuntimeConfig {
+	t.Helper()
+	stackDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(stackDir, "app.yaml"), []byte(testAppYAML), 0o644); err != nil {
+		t.Fatalf("write app.yaml: %v", err)
+	}
+	rc, err := LoadRuntimeConfig(RuntimeOptions{File: "app.yaml", StackDir: stackDir})
+	if err != nil {
+		t.Fatalf("LoadRuntimeConfig: %v", err)
+	}
+	return rc
+}
+
+func TestBuildStatusReportWithOutputs(t *testing.T) {
+	rc := loadStatusTestConfig(t)
+	report := buildStatusReport(rc, "/ws", fakeStatusSource{
+		present: true,
+		outputs: `{"instance_id":{"value":"i-123"}}`,
+	})
+
+	if !report.State.Present || len(report.Errors) != 0 {
+		t.Fatalf("unexpected state/errors: %+v %+v", report.State, report.Errors)
+	}
+	if err := report.exitError(); err != nil {
+		t.Fatalf("expected no exit error, got %v", err)
+	}
+	if report.Outputs["instance_id"] != "i-123" {
+		t.Fatalf("unexpected outputs: %+v", report.Outputs)
+	}
+	if report.Backend.Key != "test/test/dev/terraform.tfstate" {
+		t.Fatalf("unexpected backend key: %s", report.Backend.Key)
+	}
+
+	b, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	for _, want := range []string{`"present":true`, `"workload":{"type":"ec2-app","version":"v1"}`, `"observability":{"enabled":true`} {
+		if !strings.Contains(string(b), want) {
+			t.Fatalf("expected %s in %s", want, b)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := renderStatusText(&buf, report); err != nil {
+		t.Fatalf("renderStatusText: %v", err)
+	}
+	if !strings.Contains(buf.String(), "instance_id: i-123") {
+		t.Fatalf("expected rendered instance id, got:\n%s", buf.String())
+	}
+}
+
+func TestBuildStatusReportRecordsErrors(t *testing.T) {
+	rc := loadStatusTestConfig(t)
+	report := buildStatusReport(rc, "/ws", fakeStatusSource{stateErr: errors.New("AccessDenied")})
+
+	if report.State.Present {
+		t.Fatal("expected state to be missing")
+	}
+	if e := report.stageError("state"); e == nil || !strings.Contains(e.Message, "AccessDenied") {
+		t.Fatalf("expected state error detail, got %+v", report.Errors)
+	}
+
+	if err := report.exitError(); err == nil || !strings.Contains(err.Error(), "state") {
+		t.Fatalf("expected json/yaml exit error for state failure, got %v", err)
+	}
+
+	report = buildStatusReport(rc, "/ws", fakeStatusSource{present: true, outErr: errors.New("boom")})
+	if e := report.stageError("outputs"); e == nil || e.Message != "boom" {
+		t.Fatalf("expected outputs error, got %+v", report.Errors)
+	}
+}
+
+func TestAttachLiveHealth(t *testing.T) {
+	rc := loadStatusTestConfig(t)
+
+	report := buildStatusReport(rc, "/ws", fakeStatusSource{})
+	attachLiveHealth(report, &health.FakeClient{})
+	if report.Live != nil || report.stageError("live") == nil {
+		t.Fatalf("expected live error without outputs, got %+v", report.Errors)
+	}
+
+	report = buildStatusReport(rc, "/ws", fakeStatusSource{present: true, outputs: `{"instance_id":{"value":"i-1"}}`})
+	attachLiveHealth(report, &health.FakeClient{Instances: map[string]string{"i-1": "stopped"}})
+	if report.Live == nil || report.Live.Healthy {
+		t.Fatalf("expected degraded live report, got %+v", report.Live)
+	}
+}
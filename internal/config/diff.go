@@ -0,0 +1,208 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Field é um valor efetivo do contrato, identificado pelo caminho com pontos
+// (ex: lb.listener_port). Listas e mapas são folhas: mudam por inteiro, já que
+// chaves de mapa podem conter pontos (ex: tag app.example.com/owner).
+type Field struct {
+	Path  string
+	Value any
+}
+
+// FieldChange descreve a diferença de um campo entre dois contratos.
+// Old/New são nil quando o campo não existe de um dos lados (ex: chave de mapa).
+type FieldChange struct {
+	Path string `json:"path" yaml:"path"`
+	Old  any    `json:"old" yaml:"old"`
+	New  any    `json:"new" yaml:"new"`
+}
+
+// LoadEffectiveConfig lê e valida o contrato sem resolver user_data nem security-groups/,
+// para comparar contratos pelo que está escrito + defaults aplicados pelo Validate.
+// policy é a política de tags do repositório (RepoTagPolicyFile), vazia quando não há.
+func LoadEffectiveConfig(path string, policy TagPolicyConfig) (*AppConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEffectiveConfig(path, data, policy)
+}
+
+// ParseEffectiveConfig é LoadEffectiveConfig para conteúdo já lido (ex: git show).
+func ParseEffectiveConfig(name string, data []byte, policy TagPolicyConfig) (*AppConfig, error) {
+	var cfg AppConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	cfg.RepoTagPolicy = policy
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return &cfg, nil
+}
+
+// Flatten lista os campos efetivos na ordem em que aparecem no AppConfig.
+func Flatten(cfg *AppConfig) ([]Field, error) {
+	var doc yaml.Node
+	if err := doc.Encode(cfg); err != nil {
+		return nil, fmt.Errorf("encode contract: %w", err)
+	}
+	var fields []Field
+	if err := flattenNode(&doc, reflect.TypeOf(cfg), "", &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func flattenNode(n *yaml.Node, t reflect.Type, prefix string, out *[]Field) error {
+	if n.Kind == yaml.DocumentNode && len(n.Content) == 1 {
+		return flattenNode(n.Content[0], t, prefix, out)
+	}
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	// Só structs viram caminhos; mapas (tags, budget_by_environment) são folhas.
+	if n.Kind == yaml.MappingNode && len(n.Content) > 0 && t != nil && t.Kind() == reflect.Struct {
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			name := n.Content[i].Value
+			key := name
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			if err := flattenNode(n.Content[i+1], yamlFieldType(t, name), key, out); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	var v any
+	if err := n.Decode(&v); err != nil {
+		return fmt.Errorf("decode %s: %w", prefix, err)
+	}
+	*out = append(*out, Field{Path: prefix, Value: v})
+	return nil
+}
+
+// yamlFieldType acha o tipo do campo do struct pela tag yaml.
+func yamlFieldType(t reflect.Type, name string) reflect.Type {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if tag == "" {
+			tag = strings.ToLower(f.Name)
+		}
+		if tag == name {
+			return f.Type
+		}
+	}
+	return nil
+}
+
+// DiffConfigs compara os valores efetivos de dois contratos, na ordem do contrato.
+func DiffConfigs(oldCfg, newCfg *AppConfig) ([]FieldChange, error) {
+	oldFields, err := Flatten(oldCfg)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := Flatten(newCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	newValues := make(map[string]any, len(newFields))
+	for _, f := range newFields {
+		newValues[f.Path] = f.Value
+	}
+
+	var changes []FieldChange
+	seen := map[string]bool{}
+	for _, f := range oldFields {
+		seen[f.Path] = true
+		nv := newValues[f.Path]
+		if !reflect.DeepEqual(f.Value, nv) {
+			changes = append(changes, FieldChange{Path: f.Path, Old: f.Value, New: nv})
+		}
+	}
+	for _, f := range newFields {
+		if !seen[f.Path] && f.Value != nil {
+			changes = append(changes, FieldChange{Path: f.Path, New: f.Value})
+		}
+	}
+	return changes, nil
+}
+
+// SecretFields são campos cujo valor nunca é impresso por diff ou promote.
+var SecretFields = []string{"db.rds.password"}
+
+// IsSecretField indica se o campo está coberto por SecretFields.
+func IsSecretField(field string) bool {
+	for _, p := range SecretFields {
+		if MatchFieldPath(p, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchFieldPath indica se o campo está coberto pelo padrão: o próprio campo, um bloco
+// pai (lb cobre lb.subnet_ids) ou um glob por segmento (*.subnet_ids).
+func MatchFieldPath(pattern, field string) bool {
+	if pattern == field || strings.HasPrefix(field, pattern+".") {
+		return true
+	}
+	ps := strings.Split(pattern, ".")
+	fs := strings.Split(field, ".")
+	if len(ps) > len(fs) {
+		return false
+	}
+	for i, p := range ps {
+		if ok, _ := path.Match(p, fs[i]); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// FormatValue imprime um valor efetivo em uma linha (listas e mapas em flow YAML).
+func FormatValue(v any) string {
+	if v == nil {
+		return "(unset)"
+	}
+	switch v.(type) {
+	case []any, map[string]any:
+		n := &yaml.Node{}
+		if err := n.Encode(v); err != nil {
+			return fmt.Sprint(v)
+		}
+		setFlowStyle(n)
+		b, err := yaml.Marshal(n)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return strings.TrimSpace(string(b))
+	case string:
+		if v == "" {
+			return `""`
+		}
+		if strings.Contains(v.(string), "\n") {
+			return fmt.Sprintf("(%d lines)", strings.Count(v.(string), "\n")+1)
+		}
+	}
+	return fmt.Sprint(v)
+}
+
+func setFlowStyle(n *yaml.Node) {
+	n.Style |= yaml.FlowStyle
+	for _, c := range n.Content {
+		setFlowStyle(c)
+	}
+}
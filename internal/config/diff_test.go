@@ -0,0 +1,140 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestDiffConfigsUsesEffectiveValues(t *testing.T) {
+	t.Parallel()
+
+	oldCfg := minimalValidConfig()
+	newCfg := minimalValidConfig()
+	newCfg.EC2.InstanceType = "t3.large"
+	newCfg.Recovery.SnapshotTimeUTC = "03:00" // igual ao default do lado antigo
+	if err := oldCfg.Validate(); err != nil {
+		t.Fatalf("validate old: %v", err)
+	}
+	if err := newCfg.Validate(); err != nil {
+		t.Fatalf("validate new: %v", err)
+	}
+
+	changes, err := DiffConfigs(oldCfg, newCfg)
+	if err != nil {
+		t.Fatalf("DiffConfigs: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Path != "ec2.instance_type" || changes[0].Old != "t3.micro" || changes[0].New != "t3.large" {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+}
+
+func TestDiffConfigsTreatsMapsAsLeaves(t *testing.T) {
+	t.Parallel()
+
+	oldCfg := minimalValidConfig()
+	newCfg := minimalValidConfig()
+	newCfg.Tags = map[string]string{"app.example.com/owner": "payments"}
+	if err := oldCfg.Validate(); err != nil {
+		t.Fatalf("validate old: %v", err)
+	}
+	if err := newCfg.Validate(); err != nil {
+		t.Fatalf("validate new: %v", err)
+	}
+
+	changes, err := DiffConfigs(oldCfg, newCfg)
+	if err != nil {
+		t.Fatalf("DiffConfigs: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Path != "tags" {
+		t.Fatalf("expected a single tags change, got %+v", changes)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte("app:\n  name: billing\n"), &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if err := SetYAMLPath(&doc, changes[0].Path, changes[0].New); err != nil {
+		t.Fatalf("SetYAMLPath: %v", err)
+	}
+	var got AppConfig
+	if err := doc.Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.Tags["app.example.com/owner"] != "payments" {
+		t.Fatalf("expected dotted tag key to round-trip, got %+v", got.Tags)
+	}
+}
+
+func TestMatchFieldPath(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		pattern, field string
+		want           bool
+	}{
+		{"infrastructure", "infrastructure.subnet_ids", true},
+		{"app.name", "app.name", true},
+		{"app.name", "app.namespace", false},
+		{"*.subnet_ids", "lb.subnet_ids", true},
+		{"*.subnet_ids", "infrastructure.subnet_id", false},
+		{"lb.*", "lb.listener_port", true},
+	}
+	for _, c := range cases {
+		if got := MatchFieldPath(c.pattern, c.field); got != c.want {
+			t.Fatalf("MatchFieldPath(%q, %q)=%v, want %v", c.pattern, c.field, got, c.want)
+		}
+	}
+}
+
+func TestSetYAMLPathPreservesLayout(t *testing.T) {
+	t.Parallel()
+
+	src := []byte(`app:
+  name: billing
+
+# tamanho da instância
+ec2:
+  instance_type: t3.micro # barato
+`)
+	var doc yaml.Node
+	if err := yaml.Unmarshal(src, &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if err := SetYAMLPath(&doc, "ec2.instance_type", "t3.large"); err != nil {
+		t.Fatalf("set existing: %v", err)
+	}
+	if err := SetYAMLPath(&doc, "lb.subnet_ids", []any{"subnet-a"}); err != nil {
+		t.Fatalf("set new block: %v", err)
+	}
+
+	out, err := EncodeYAMLDocument(&doc, src)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	got := string(out)
+	for _, want := range []string{
+		"  name: billing\n\n# tamanho da instância\nec2:\n",
+		"instance_type: t3.large # barato",
+		"lb:\n  subnet_ids:\n    - subnet-a\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("output missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestFieldImpactPrefersSpecificRules(t *testing.T) {
+	t.Parallel()
+
+	if got := FieldImpact("db.rds.instance_class").Level; got != ImpactRestart {
+		t.Fatalf("expected restart for rds instance class, got %s", got)
+	}
+	if got := FieldImpact("db.port").Level; got != ImpactChange {
+		t.Fatalf("expected db block fallback, got %s", got)
+	}
+	if got := FieldImpact("cost.budget_monthly_usd").Level; got != ImpactNone {
+		t.Fatalf("expected no infra impact for cost fields, got %s", got)
+	}
+}
@@ -0,0 +1,125 @@
+package config
+
+// Níveis de impacto de uma mudança de contrato, do mais para o menos disruptivo.
+const (
+	ImpactReplace = "replace"
+	ImpactRestart = "restart"
+	ImpactChange  = "change"
+	ImpactNone    = "none"
+)
+
+// Impact descreve o efeito esperado na infraestrutura de uma mudança de campo.
+type Impact struct {
+	Level  string `json:"level" yaml:"level"`
+	Detail string `json:"detail" yaml:"detail"`
+}
+
+type impactRule struct {
+	pattern string
+	impact  Impact
+}
+
+// impactRules é avaliada em ordem; o primeiro padrão que casar (MatchFieldPath) vence,
+// então regras específicas ficam antes das de bloco.
+var impactRules = []impactRule{
+	{"app.name", Impact{ImpactReplace, "todos os recursos são renomeados (destroy/create) e a chave do state muda"}},
+	{"app.environment", Impact{ImpactReplace, "todos os recursos são renomeados (destroy/create) e a chave do state muda"}},
+	{"app.region", Impact{ImpactReplace, "stack recriada em outra região; state e backend precisam ser migrados"}},
+	{"terraform.backend", Impact{ImpactChange, "state passa a ser lido de outro lugar; migre o state antes do apply"}},
+	{"infrastructure.vpc_id", Impact{ImpactReplace, "security groups e instâncias recriados na nova VPC"}},
+	{"infrastructure.subnet_id", Impact{ImpactReplace, "instâncias recriadas na nova subnet"}},
+	{"infrastructure.subnet_ids", Impact{ImpactChange, "VPC endpoints recriados por AZ; node pools do k8s-workers redistribuem workers entre as subnets"}},
+	{"infrastructure", Impact{ImpactChange, "regras de security group atualizadas"}},
+
+	{"ec2.instance_type", Impact{ImpactRestart, "instância app parada e reiniciada (modify); o apply pede confirmação no guardrail de instância"}},
+	{"ec2.ami", Impact{ImpactReplace, "instância app substituída (nova AMI); o apply pede confirmação no guardrail de instância"}},
+	{"ec2.os", Impact{ImpactReplace, "instância app substituída (nova AMI); o apply pede confirmação no guardrail de instância"}},
+	{"ec2.user_data", Impact{ImpactRestart, "user data atualizado com stop/start da instância app"}},
+	{"ec2.user_data_mode", Impact{ImpactRestart, "user data atualizado com stop/start da instância app"}},
+	{"ec2.imds_v2_required", Impact{ImpactNone, "obsoleto: o módulo sempre exige IMDSv2; remova o campo com brainctl migrate"}},
+	{"ec2.allowed_rdp_cidr", Impact{ImpactChange, "regra de ingress do SG app atualizada"}},
+	{"ec2.allowed_ssh_cidr", Impact{ImpactChange, "regra de ingress do SG app atualizada"}},
+
+	{"db.enabled", Impact{ImpactReplace, "banco criado ou destruído (sem restore automático)"}},
+	{"db.mode", Impact{ImpactReplace, "troca EC2 <-> RDS: banco antigo destruído e novo criado (migre os dados)"}},
+	{"db.instance_type", Impact{ImpactRestart, "instância db parada e reiniciada (modify); o apply pede confirmação"}},
+	{"db.ami", Impact{ImpactReplace, "instância db substituída; o apply pede confirmação"}},
+	{"db.os", Impact{ImpactReplace, "instância db substituída; o apply pede confirmação"}},
+	{"db.user_data", Impact{ImpactRestart, "user data atualizado com stop/start da instância db"}},
+	{"db.rds.instance_class", Impact{ImpactRestart, "RDS modify com reinício (downtime em single-AZ)"}},
+	{"db.rds.engine", Impact{ImpactReplace, "instância RDS recriada"}},
+	{"db.rds.engine_version", Impact{ImpactRestart, "upgrade de engine do RDS com reinício"}},
+	{"db.rds.db_name", Impact{ImpactReplace, "instância RDS recriada"}},
+	{"db.rds.username", Impact{ImpactReplace, "instância RDS recriada"}},
+	{"db.rds.multi_az", Impact{ImpactChange, "RDS convertido para/de Multi-AZ (custo dobra com multi_az=true)"}},
+	{"db.rds.allocated_storage", Impact{ImpactChange, "storage do RDS ampliado in-place (não pode diminuir)"}},
+	{"db", Impact{ImpactChange, "configuração do banco atualizada"}},
+
+	{"lb.enabled", Impact{ImpactReplace, "ALB, listener e target group criados ou destruídos; o endpoint da aplicação muda"}},
+	{"lb.scheme", Impact{ImpactReplace, "ALB recriado (novo DNS)"}},
+	{"lb.subnet_ids", Impact{ImpactChange, "subnets do ALB atualizadas in-place"}},
+	{"lb.instance_count", Impact{ImpactReplace, "instâncias app adicionadas ou removidas"}},
+	{"lb.target_port", Impact{ImpactReplace, "target group recriado"}},
+	{"lb.target_protocol", Impact{ImpactReplace, "target group recriado (HTTP/HTTPS até as instâncias)"}},
+	{"lb.tls.domain", Impact{ImpactReplace, "novo certificado ACM solicitado e validado via DNS antes de trocar o listener"}},
+	{"lb.tls.subject_alternative_names", Impact{ImpactReplace, "novo certificado ACM solicitado e validado via DNS antes de trocar o listener"}},
+	{"lb.tls", Impact{ImpactChange, "listener HTTPS, certificado ou redirect 80->443 atualizados"}},
+	{"lb.health_check", Impact{ImpactChange, "health check dos target groups atualizado in-place"}},
+	{"lb.stickiness", Impact{ImpactChange, "afinidade de sessão dos target groups atualizada in-place"}},
+	{"lb.waf.web_acl_arn", Impact{ImpactChange, "associação do web ACL trocada; o ACL gerenciado pelo módulo é criado ou destruído"}},
+	{"lb.waf", Impact{ImpactChange, "web ACL WAF e alarme de bloqueios atualizados in-place"}},
+	{"lb.routes", Impact{ImpactChange, "target groups e listener rules por path criados, alterados ou removidos"}},
+	{"lb", Impact{ImpactChange, "listener/SG do ALB atualizados"}},
+
+	{"dns.enabled", Impact{ImpactChange, "registros Route53 criados ou removidos"}},
+	{"dns.record_name", Impact{ImpactReplace, "registro Route53 recriado com o novo nome; clientes do nome antigo param de resolver"}},
+	{"dns.zone_id", Impact{ImpactReplace, "registros Route53 recriados na nova hosted zone"}},
+	{"dns.zone_name", Impact{ImpactReplace, "registros Route53 recriados na nova hosted zone"}},
+	{"dns.private", Impact{ImpactReplace, "registros Route53 recriados na nova hosted zone"}},
+	{"dns", Impact{ImpactChange, "registros Route53 atualizados in-place"}},
+
+	{"app_scaling.enabled", Impact{ImpactReplace, "troca instância fixa por Auto Scaling Group (ou o contrário): instâncias app recriadas"}},
+	{"app_scaling.rollout", Impact{ImpactChange, "preferências do instance refresh atualizadas; a frota só é substituída quando o launch template muda"}},
+	{"app_scaling.schedules", Impact{ImpactChange, "ações agendadas do ASG criadas/atualizadas; a capacidade muda no próximo horário agendado"}},
+	{"app_scaling.step_policies", Impact{ImpactChange, "step scaling policies e alarmes CloudWatch associados atualizados"}},
+	{"app_scaling.warm_pool", Impact{ImpactChange, "warm pool do ASG ajustado; instâncias pré-inicializadas criadas ou removidas"}},
+	{"app_scaling.instances", Impact{ImpactChange, "mixed instances policy do ASG atualizada; só instâncias novas seguem a nova distribuição on-demand/Spot (use rollout para substituir a frota)"}},
+	{"app_scaling.desired_capacity", Impact{ImpactNone, "só vale na criação do ASG (ignore_changes); depois a capacidade é definida por min_size/max_size, policies e schedules"}},
+	{"app_scaling.subnet_ids", Impact{ImpactChange, "ASG redistribui instâncias entre subnets"}},
+	{"app_scaling", Impact{ImpactChange, "capacidade/política do ASG ajustada sem replace"}},
+
+	{"observability.enabled", Impact{ImpactChange, "dashboards, alarmes, SNS e log group criados ou removidos"}},
+	{"observability.enable_ssm_endpoints", Impact{ImpactChange, "VPC endpoints de SSM/logs criados ou removidos (custo por AZ)"}},
+	{"observability.log_kms_key_id", Impact{ImpactChange, "log group passa a usar outra chave KMS"}},
+	{"observability", Impact{ImpactChange, "alarmes/notificações atualizados"}},
+
+	{"recovery.enabled", Impact{ImpactChange, "políticas DLM, runbooks e drill criados ou removidos"}},
+	{"recovery", Impact{ImpactChange, "agenda/retenção de snapshots atualizada"}},
+
+	{"k8s.kubernetes_version", Impact{ImpactRestart, "user data dos control-planes atualizado com stop/start; a versão só vale para nós novos (cloud-init não roda de novo)"}},
+	{"k8s.pod_cidr", Impact{ImpactReplace, "control-plane recriado (pod CIDR definido no kubeadm init)"}},
+	{"k8s.control_plane_count", Impact{ImpactReplace, "topologia do control-plane muda (endpoint do API server passa a ser o NLB interno ou deixa de ser): cluster recriado"}},
+	{"k8s.join_token_ttl_minutes", Impact{ImpactChange, "parâmetro SSM do TTL atualizado in-place; o próximo token publicado usa o novo TTL"}},
+	{"k8s.control_plane_instance_type", Impact{ImpactRestart, "control-plane parado e reiniciado (modify)"}},
+	{"k8s.worker_instance_type", Impact{ImpactChange, "nova versão do launch template; só workers criados depois pelo ASG usam o novo tipo"}},
+	{"k8s.worker_count", Impact{ImpactChange, "desired/min/max do ASG do pool default ajustados: workers adicionados ou removidos"}},
+	{"k8s.node_pools", Impact{ImpactChange, "launch templates/ASGs por pool criados, removidos ou ajustados; labels, taints e tipos valem apenas para workers novos (pool removido encerra seus nós sem drain)"}},
+	{"k8s.control_plane_ami", Impact{ImpactReplace, "control-plane substituído"}},
+	{"k8s.worker_ami", Impact{ImpactChange, "nova versão do launch template; workers existentes só são substituídos quando o ASG recriá-los"}},
+	{"k8s", Impact{ImpactChange, "rede/acesso do cluster atualizados"}},
+
+	{"tags", Impact{ImpactChange, "tags atualizadas in-place em todos os recursos (default_tags do provider)"}},
+	{"tag_policy", Impact{ImpactNone, "apenas validação do contrato; sem mudança de infraestrutura"}},
+	{"cost", Impact{ImpactNone, "apenas guardrail/estimativa de custo; sem mudança de infraestrutura"}},
+	{"workload", Impact{ImpactReplace, "outro blueprint: stack inteira recriada"}},
+}
+
+// FieldImpact retorna o impacto esperado ao mudar o campo do contrato.
+func FieldImpact(path string) Impact {
+	for _, r := range impactRules {
+		if MatchFieldPath(r.pattern, path) {
+			return r.impact
+		}
+	}
+	return Impact{Level: ImpactChange}
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// K8sNodePoolConfig descreve um grupo de workers (launch template + ASG). Labels e
+// taints são aplicados pelo kubelet no kubeadm join.
+type K8sNodePoolConfig struct {
+	Name            string            `yaml:"name"`
+	InstanceTypes   []string          `yaml:"instance_types"`
+	MinSize         int               `yaml:"min_size"`
+	MaxSize         int               `yaml:"max_size"`
+	DesiredCapacity int               `yaml:"desired_capacity"`
+	Labels          map[string]string `yaml:"labels"`
+	Taints          []K8sTaint        `yaml:"taints"`
+	Spot            bool              `yaml:"spot"`
+}
+
+// K8sTaint segue o formato do kubelet (--register-with-taints key=value:Effect).
+type K8sTaint struct {
+	Key    string `yaml:"key"`
+	Value  string `yaml:"value"`
+	Effect string `yaml:"effect"`
+}
+
+// DefaultNodePoolName é o pool gerado a partir de worker_count/worker_instance_type.
+const DefaultNodePoolName = "default"
+
+// NodePoolLabel identifica o pool de cada nó; o brainctl sempre o adiciona.
+const NodePoolLabel = "brainctl.io/node-pool"
+
+// EffectiveNodePools retorna node_pools ou, sem eles, o pool único equivalente a
+// worker_count x worker_instance_type (shorthand mantido por compatibilidade).
+func (k K8sWorkersConfig) EffectiveNodePools() []K8sNodePoolConfig {
+	if len(k.NodePools) > 0 {
+		return k.NodePools
+	}
+	return []K8sNodePoolConfig{{
+		Name:            DefaultNodePoolName,
+		InstanceTypes:   []string{k.WorkerInstanceType},
+		MinSize:         k.WorkerCount,
+		MaxSize:         k.WorkerCount,
+		DesiredCapacity: k.WorkerCount,
+	}}
+}
+
+var (
+	nodePoolNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,20}$`)
+	k8sLabelNamePattern = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]{0,61}[A-Za-z0-9])?$`)
+	k8sLabelPrefix      = regexp.MustCompile(`^[a-z0-9]([-a-z0-9.]{0,251}[a-z0-9])?$`)
+	k8sLabelValue       = regexp.MustCompile(`^([A-Za-z0-9]([-A-Za-z0-9_.]{0,61}[A-Za-z0-9])?)?$`)
+)
+
+var k8sTaintEffects = map[string]bool{
+	"NoSchedule":       true,
+	"PreferNoSchedule": true,
+	"NoExecute":        true,
+}
+
+// validateNodePools aplica defaults dos pools e valida tamanhos, labels e taints.
+// worker_count é shorthand de um pool único e não pode ser combinado com node_pools.
+func (c *AppConfig) validateNodePools() error {
+	k := &c.K8s
+	if len(k.NodePools) == 0 {
+		if k.WorkerCount == 0 {
+			k.WorkerCount = 2
+		}
+		if k.WorkerCount < 1 {
+			return fmt.Errorf("k8s.worker_count must be >= 1")
+		}
+		return nil
+	}
+	if k.WorkerCount != 0 {
+		return fmt.Errorf("k8s.worker_count is a shorthand for a single node pool; use either worker_count or node_pools")
+	}
+
+	names := map[string]bool{}
+	total := 0
+	for i := range k.NodePools {
+		p := &k.NodePools[i]
+		field := fmt.Sprintf("k8s.node_pools[%d]", i)
+		if !nodePoolNamePattern.MatchString(p.Name) {
+			return fmt.Errorf("%s.name must be 1-21 lowercase letters, digits or hyphens", field)
+		}
+		if names[p.Name] {
+			return fmt.Errorf("%s.name %q is duplicated", field, p.Name)
+		}
+		names[p.Name] = true
+
+		if len(p.InstanceTypes) == 0 {
+			p.InstanceTypes = []string{k.WorkerInstanceType}
+		}
+		seen := map[string]bool{}
+		for j, t := range p.InstanceTypes {
+			if !instanceTypePattern.MatchString(t) {
+				return fmt.Errorf("%s.instance_types[%d] %q is not a valid instance type", field, j, t)
+			}
+			if seen[t] {
+				return fmt.Errorf("%s.instance_types[%d] %q is duplicated", field, j, t)
+			}
+			seen[t] = true
+		}
+
+		if p.DesiredCapacity == 0 {
+			p.DesiredCapacity = p.MinSize
+		}
+		if p.MaxSize == 0 {
+			p.MaxSize = max(p.MinSize, p.DesiredCapacity)
+		}
+		if p.MinSize < 0 || p.MaxSize < 1 {
+			return fmt.Errorf("%s: min_size must be >= 0 and max_size >= 1", field)
+		}
+		if p.MinSize > p.MaxSize || p.DesiredCapacity < p.MinSize || p.DesiredCapacity > p.MaxSize {
+			return fmt.Errorf("%s: expected min_size (%d) <= desired_capacity (%d) <= max_size (%d)", field, p.MinSize, p.DesiredCapacity, p.MaxSize)
+		}
+		total += p.DesiredCapacity
+
+		for key, value := range p.Labels {
+			if err := validateK8sLabelKey(key); err != nil {
+				return fmt.Errorf("%s.labels: %w", field, err)
+			}
+			if key == NodePoolLabel {
+				return fmt.Errorf("%s.labels: %s is set by brainctl", field, NodePoolLabel)
+			}
+			if !k8sLabelValue.MatchString(value) {
+				return fmt.Errorf("%s.labels: invalid value %q for %s", field, value, key)
+			}
+		}
+		for j, t := range p.Taints {
+			if err := validateK8sLabelKey(t.Key); err != nil {
+				return fmt.Errorf("%s.taints[%d]: %w", field, j, err)
+			}
+			if !k8sLabelValue.MatchString(t.Value) {
+				return fmt.Errorf("%s.taints[%d]: invalid value %q", field, j, t.Value)
+			}
+			if t.Effect == "" {
+				p.Taints[j].Effect = "NoSchedule"
+			} else if !k8sTaintEffects[t.Effect] {
+				return fmt.Errorf("%s.taints[%d].effect must be NoSchedule, PreferNoSchedule or NoExecute", field, j)
+			}
+		}
+	}
+	if total < 1 {
+		return fmt.Errorf("k8s.node_pools must have at least one worker (sum of desired_capacity)")
+	}
+	return nil
+}
+
+// validateK8sLabelKey valida [prefixo/]nome e rejeita prefixos reservados que a
+// NodeRestriction impede o kubelet de aplicar (exceto node. e kubelet.kubernetes.io).
+func validateK8sLabelKey(key string) error {
+	prefix, name, hasPrefix := strings.Cut(key, "/")
+	if !hasPrefix {
+		name, prefix = prefix, ""
+	}
+	if !k8sLabelNamePattern.MatchString(name) || (hasPrefix && !k8sLabelPrefix.MatchString(prefix)) {
+		return fmt.Errorf("invalid key %q", key)
+	}
+	reserved := prefix == "kubernetes.io" || strings.HasSuffix(prefix, ".kubernetes.io") || prefix == "k8s.io" || strings.HasSuffix(prefix, ".k8s.io")
+	if reserved && prefix != "node.kubernetes.io" && prefix != "kubelet.kubernetes.io" {
+		return fmt.Errorf("key %q uses a reserved prefix the kubelet cannot self-apply", key)
+	}
+	return nil
+}
@@ -1,9 +1,11 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -40,16 +42,27 @@ type AppConfig struct {
 		UserDataMode   string `yaml:"user_data_mode"`
 		IMDSv2Required bool   `yaml:"imds_v2_required"`
 		AllowedRDPCIDR string `yaml:"allowed_rdp_cidr"`
+		AllowedSSHCIDR string `yaml:"allowed_ssh_cidr"`
 	} `yaml:"ec2"`
 
 	DB            DBConfig            `yaml:"db"`
 	LB            LBConfig            `yaml:"lb"`
+	DNS           DNSConfig           `yaml:"dns"`
 	AppScaling    AppScalingConfig    `yaml:"app_scaling"`
 	Observability ObservabilityConfig `yaml:"observability"`
 	Recovery      RecoveryConfig      `yaml:"recovery"`
 
 	K8s K8sWorkersConfig `yaml:"k8s"`
 
+	Cost CostConfig `yaml:"cost"`
+
+	Tags      map[string]string `yaml:"tags"`
+	TagPolicy TagPolicyConfig   `yaml:"tag_policy"`
+
+	// RepoTagPolicy vem de RepoTagPolicyFile, fora da stack: o contrato pode exigir
+	// tags a mais em tag_policy, mas não remover as do repositório.
+	RepoTagPolicy TagPolicyConfig `yaml:"-"`
+
 	// RuntimeOverrides são alterações aplicadas por arquivos em security-groups/ (não fazem parte do contrato base).
 	RuntimeOverrides RuntimeOverrides `yaml:"-"`
 }
@@ -76,6 +89,8 @@ type K8sWorkersConfig struct {
 	ControlPlaneInstanceType string `yaml:"control_plane_instance_type"`
 	WorkerInstanceType       string `yaml:"worker_instance_type"`
 	ControlPlaneAMI          string `yaml:"control_plane_ami"`
+	ControlPlaneCount        int    `yaml:"control_plane_count"`
+	JoinTokenTTLMinutes      int    `yaml:"join_token_ttl_minutes"`
 	WorkerAMI                string `yaml:"worker_ami"`
 	WorkerCount              int    `yaml:"worker_count"`
 	KubernetesVersion        string `yaml:"kubernetes_version"`
@@ -90,6 +105,65 @@ type K8sWorkersConfig struct {
 	EnableSSM                *bool  `yaml:"enable_ssm"`
 	EnableSSMVPCEndpoints    *bool  `yaml:"enable_ssm_vpc_endpoints"`
 	EnableDetailedMonitoring *bool  `yaml:"enable_detailed_monitoring"`
+
+	NodePools []K8sNodePoolConfig `yaml:"node_pools"`
+}
+
+// CostConfig define o orçamento mensal avaliado no plan/apply.
+type CostConfig struct {
+	BudgetMonthlyUSD    float64            `yaml:"budget_monthly_usd"`
+	BudgetByEnvironment map[string]float64 `yaml:"budget_by_environment"`
+	MaxDeltaMonthlyUSD  float64            `yaml:"max_delta_monthly_usd"`
+	BudgetAction        string             `yaml:"budget_action"`
+	Usage               CostUsageConfig    `yaml:"usage"`
+}
+
+// CostUsageConfig descreve o consumo esperado usado no usage file do Infracost
+// e na estimativa offline (a metade do custo que depende de uso, não de recurso).
+type CostUsageConfig struct {
+	SnapshotStorageGB       float64 `yaml:"snapshot_storage_gb"`
+	LogIngestionGBMonth     float64 `yaml:"log_ingestion_gb_month"`
+	NATDataProcessedGBMonth float64 `yaml:"nat_data_processed_gb_month"`
+}
+
+// TagPolicyConfig define as tags que todo contrato precisa declarar (ex.: owner, cost_center).
+type TagPolicyConfig struct {
+	Required []string `yaml:"required"`
+}
+
+// RepoTagPolicyFile é a política de tags do repositório, relativa à raiz do repo.
+const RepoTagPolicyFile = "policies/tags.yaml"
+
+// LoadTagPolicy lê a política de tags do repositório; sem o arquivo, nada é exigido.
+func LoadTagPolicy(path string) (TagPolicyConfig, error) {
+	var policy TagPolicyConfig
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return policy, nil
+	}
+	if err != nil {
+		return policy, err
+	}
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return policy, fmt.Errorf("invalid yaml: %w", err)
+	}
+	return policy, nil
+}
+
+// ManagedTagPrefix marca as tags gerenciadas pelo brainctl; o contrato não pode defini-las.
+const ManagedTagPrefix = "brainctl:"
+
+// EffectiveBudget retorna o orçamento do ambiente, caindo para budget_monthly_usd.
+func (c CostConfig) EffectiveBudget(environment string) float64 {
+	if v, ok := c.BudgetByEnvironment[environment]; ok {
+		return v
+	}
+	return c.BudgetMonthlyUSD
+}
+
+// BudgetEnabled indica se existe algum limite de custo para o ambiente.
+func (c CostConfig) BudgetEnabled(environment string) bool {
+	return c.EffectiveBudget(environment) > 0 || c.MaxDeltaMonthlyUSD > 0
 }
 
 // DBConfig define o bloco opcional de banco.
@@ -119,22 +193,112 @@ type DBConfig struct {
 
 // LBConfig define parâmetros de load balancer.
 type LBConfig struct {
-	Enabled       bool     `yaml:"enabled"`
-	Scheme        string   `yaml:"scheme"`
-	SubnetIDs     []string `yaml:"subnet_ids"`
-	ListenerPort  int      `yaml:"listener_port"`
-	TargetPort    int      `yaml:"target_port"`
-	AllowedCIDR   string   `yaml:"allowed_cidr"`
-	InstanceCount int      `yaml:"instance_count"`
+	Enabled        bool        `yaml:"enabled"`
+	Scheme         string      `yaml:"scheme"`
+	SubnetIDs      []string    `yaml:"subnet_ids"`
+	ListenerPort   int         `yaml:"listener_port"`
+	TargetPort     int         `yaml:"target_port"`
+	TargetProtocol string      `yaml:"target_protocol"`
+	AllowedCIDR    string      `yaml:"allowed_cidr"`
+	InstanceCount  int         `yaml:"instance_count"`
+	TLS            LBTLSConfig `yaml:"tls"`
+
+	HealthCheck         LBHealthCheckConfig `yaml:"health_check"`
+	DeregistrationDelay *int                `yaml:"deregistration_delay"`
+	Stickiness          LBStickinessConfig  `yaml:"stickiness"`
+	Routes              []LBRouteConfig     `yaml:"routes"`
+	WAF                 LBWAFConfig         `yaml:"waf"`
+}
+
+// LBWAFConfig associa um web ACL WAFv2 ao ALB: um ACL existente (WebACLARN) ou
+// um criado pelo módulo a partir de rule groups gerenciados da AWS e rate limit.
+type LBWAFConfig struct {
+	Enabled              bool     `yaml:"enabled"`
+	WebACLARN            string   `yaml:"web_acl_arn"`
+	ManagedRules         []string `yaml:"managed_rules"`
+	RateLimit            int      `yaml:"rate_limit"`
+	BlockedRequestsAlarm int      `yaml:"blocked_requests_alarm"`
+}
+
+// WAFManagedRules são as chaves aceitas em lb.waf.managed_rules, na ordem de
+// prioridade usada no web ACL.
+var WAFManagedRules = []string{"core", "known_bad_inputs", "ip_reputation"}
+
+// DNSConfig publica registros Route53 para o ALB (alias) ou, sem LB, para
+// cada instância app. A zona é informada por ID ou pelo nome.
+type DNSConfig struct {
+	Enabled         bool   `yaml:"enabled"`
+	ZoneID          string `yaml:"zone_id"`
+	ZoneName        string `yaml:"zone_name"`
+	Private         bool   `yaml:"private"`
+	RecordName      string `yaml:"record_name"`
+	TTL             int    `yaml:"ttl"`
+	InstanceRecords bool   `yaml:"instance_records"`
+}
+
+// LBHealthCheckConfig ajusta o health check dos target groups. Port=0 usa a
+// porta de tráfego de cada target group.
+type LBHealthCheckConfig struct {
+	Path               string `yaml:"path"`
+	Port               int    `yaml:"port"`
+	Matcher            string `yaml:"matcher"`
+	Interval           int    `yaml:"interval"`
+	Timeout            int    `yaml:"timeout"`
+	HealthyThreshold   int    `yaml:"healthy_threshold"`
+	UnhealthyThreshold int    `yaml:"unhealthy_threshold"`
+}
+
+// LBStickinessConfig liga afinidade de sessão por cookie do ALB (lb_cookie)
+// ou da aplicação (app_cookie).
+type LBStickinessConfig struct {
+	Enabled         bool   `yaml:"enabled"`
+	Type            string `yaml:"type"`
+	CookieName      string `yaml:"cookie_name"`
+	DurationSeconds int    `yaml:"duration_seconds"`
+}
+
+// LBRouteConfig encaminha path patterns do listener para outra porta das mesmas
+// instâncias app, com target group próprio.
+type LBRouteConfig struct {
+	Name            string   `yaml:"name"`
+	PathPatterns    []string `yaml:"path_patterns"`
+	TargetPort      int      `yaml:"target_port"`
+	Priority        int      `yaml:"priority"`
+	HealthCheckPath string   `yaml:"health_check_path"`
+}
+
+// DefaultSSLPolicy é a política TLS aplicada ao listener HTTPS quando lb.tls.ssl_policy é omitido.
+const DefaultSSLPolicy = "ELBSecurityPolicy-TLS13-1-2-2021-06"
+
+// LBTLSConfig habilita o listener HTTPS do ALB. O certificado vem de um ARN ACM
+// existente ou é solicitado para Domain com validação DNS na hosted zone informada.
+type LBTLSConfig struct {
+	CertificateARN          string   `yaml:"certificate_arn"`
+	Domain                  string   `yaml:"domain"`
+	SubjectAlternativeNames []string `yaml:"subject_alternative_names"`
+	HostedZoneID            string   `yaml:"hosted_zone_id"`
+	SSLPolicy               string   `yaml:"ssl_policy"`
+	RedirectHTTP            *bool    `yaml:"redirect_http"`
+}
+
+// Enabled indica se o contrato pediu TLS (certificado existente ou domínio ACM).
+func (t LBTLSConfig) Enabled() bool {
+	return strings.TrimSpace(t.CertificateARN) != "" || strings.TrimSpace(t.Domain) != ""
 }
 
 type AppScalingConfig struct {
-	Enabled         bool     `yaml:"enabled"`
-	SubnetIDs       []string `yaml:"subnet_ids"`
-	MinSize         int      `yaml:"min_size"`
-	MaxSize         int      `yaml:"max_size"`
-	DesiredCapacity int      `yaml:"desired_capacity"`
-	CPUTarget       float64  `yaml:"cpu_target"`
+	Enabled           bool                `yaml:"enabled"`
+	SubnetIDs         []string            `yaml:"subnet_ids"`
+	MinSize           int                 `yaml:"min_size"`
+	MaxSize           int                 `yaml:"max_size"`
+	DesiredCapacity   int                 `yaml:"desired_capacity"`
+	CPUTarget         float64             `yaml:"cpu_target"`
+	RequestsPerTarget int                 `yaml:"requests_per_target"`
+	Schedules         []ScalingSchedule   `yaml:"schedules"`
+	StepPolicies      []StepScalingPolicy `yaml:"step_policies"`
+	WarmPool          WarmPoolConfig      `yaml:"warm_pool"`
+	Instances         ASGInstancesConfig  `yaml:"instances"`
+	Rollout           RolloutConfig       `yaml:"rollout"`
 }
 
 // ObservabilityConfig controla dashboards, alarmes e SNS.
@@ -380,6 +544,13 @@ func (c *AppConfig) Validate() error {
 		}
 	}
 
+	if err := c.validateCost(); err != nil {
+		return err
+	}
+	if err := c.validateTags(); err != nil {
+		return err
+	}
+
 	if c.Workload.Type == "k8s-workers" {
 		if c.K8s.ControlPlaneInstanceType == "" {
 			c.K8s.ControlPlaneInstanceType = "t3.medium"
@@ -387,11 +558,24 @@ func (c *AppConfig) Validate() error {
 		if c.K8s.WorkerInstanceType == "" {
 			c.K8s.WorkerInstanceType = "t3.medium"
 		}
-		if c.K8s.WorkerCount == 0 {
-			c.K8s.WorkerCount = 2
+		if c.K8s.ControlPlaneCount == 0 {
+			c.K8s.ControlPlaneCount = 1
+		}
+		// etcd empilhado precisa de maioria: contagem par não aumenta a tolerância a falhas.
+		if c.K8s.ControlPlaneCount < 1 || c.K8s.ControlPlaneCount > 7 || c.K8s.ControlPlaneCount%2 == 0 {
+			return fmt.Errorf("k8s.control_plane_count must be odd (1, 3, 5 or 7) to keep etcd quorum")
+		}
+		if c.K8s.ControlPlaneCount > 1 && len(c.Infrastructure.SubnetIDs) < 2 {
+			return fmt.Errorf("k8s.control_plane_count>1 requires infrastructure.subnet_ids with at least 2 subnets (control planes and the NLB are spread across them)")
 		}
-		if c.K8s.WorkerCount < 1 {
-			return fmt.Errorf("k8s.worker_count must be >= 1")
+		if c.K8s.JoinTokenTTLMinutes == 0 {
+			c.K8s.JoinTokenTTLMinutes = 60
+		}
+		if c.K8s.JoinTokenTTLMinutes < 10 || c.K8s.JoinTokenTTLMinutes > 1440 {
+			return fmt.Errorf("k8s.join_token_ttl_minutes must be between 10 and 1440")
+		}
+		if err := c.validateNodePools(); err != nil {
+			return err
 		}
 		if c.K8s.KubernetesVersion == "" {
 			c.K8s.KubernetesVersion = "1.30"
@@ -423,6 +607,13 @@ func (c *AppConfig) Validate() error {
 	if c.EC2.InstanceType == "" {
 		return fmt.Errorf("ec2.instance_type is required")
 	}
+	if c.EC2.OS == "" {
+		c.EC2.OS = OSWindows2022
+	}
+	c.EC2.OS = normalizeOS(c.EC2.OS)
+	if !validOS(c.EC2.OS) {
+		return fmt.Errorf("ec2.os must be one of: %s", strings.Join(SupportedOS, ", "))
+	}
 	if c.EC2.UserDataMode == "" {
 		c.EC2.UserDataMode = "default"
 	}
@@ -432,11 +623,30 @@ func (c *AppConfig) Validate() error {
 	if c.EC2.UserDataMode == "custom" && strings.TrimSpace(c.EC2.UserData) == "" {
 		return fmt.Errorf("ec2.user_data is required when ec2.user_data_mode=custom")
 	}
-	if c.EC2.AllowedRDPCIDR == "" {
-		c.EC2.AllowedRDPCIDR = c.Infrastructure.VpcCIDR
+	if _, err := NormalizeUserData(c.EC2.OS, c.EC2.UserData); err != nil {
+		return fmt.Errorf("ec2.user_data: %w", err)
 	}
-	if c.EC2.AllowedRDPCIDR == "0.0.0.0/0" {
-		return fmt.Errorf("ec2.allowed_rdp_cidr cannot be 0.0.0.0/0")
+	// O acesso administrativo segue o SO: RDP no Windows, SSH no Linux.
+	if IsWindowsOS(c.EC2.OS) {
+		if c.EC2.AllowedSSHCIDR != "" {
+			return fmt.Errorf("ec2.allowed_ssh_cidr requires a Linux ec2.os; use ec2.allowed_rdp_cidr on %s", OSWindows2022)
+		}
+		if c.EC2.AllowedRDPCIDR == "" {
+			c.EC2.AllowedRDPCIDR = c.Infrastructure.VpcCIDR
+		}
+		if c.EC2.AllowedRDPCIDR == "0.0.0.0/0" {
+			return fmt.Errorf("ec2.allowed_rdp_cidr cannot be 0.0.0.0/0")
+		}
+	} else {
+		if c.EC2.AllowedRDPCIDR != "" {
+			return fmt.Errorf("ec2.allowed_rdp_cidr is only supported on %s; use ec2.allowed_ssh_cidr", OSWindows2022)
+		}
+		if c.EC2.AllowedSSHCIDR == "" {
+			c.EC2.AllowedSSHCIDR = c.Infrastructure.VpcCIDR
+		}
+		if c.EC2.AllowedSSHCIDR == "0.0.0.0/0" {
+			return fmt.Errorf("ec2.allowed_ssh_cidr cannot be 0.0.0.0/0")
+		}
 	}
 
 	if c.DB.UserDataMode == "" {
@@ -460,8 +670,22 @@ func (c *AppConfig) Validate() error {
 			if c.DB.InstanceType == "" {
 				c.DB.InstanceType = c.EC2.InstanceType
 			}
+			if c.DB.OS == "" {
+				c.DB.OS = c.EC2.OS
+			}
+			c.DB.OS = normalizeOS(c.DB.OS)
+			if !validOS(c.DB.OS) {
+				return fmt.Errorf("db.os must be one of: %s", strings.Join(SupportedOS, ", "))
+			}
+			if _, err := NormalizeUserData(c.DB.OS, c.DB.UserData); err != nil {
+				return fmt.Errorf("db.user_data: %w", err)
+			}
 			if c.DB.Port == 0 {
+				// SQL Server no Windows, PostgreSQL no Linux.
 				c.DB.Port = 1433
+				if !IsWindowsOS(c.DB.OS) {
+					c.DB.Port = 5432
+				}
 			}
 		} else {
 			if len(c.Infrastructure.SubnetIDs) < 2 && len(c.LB.SubnetIDs) < 2 {
@@ -526,6 +750,9 @@ func (c *AppConfig) Validate() error {
 		}
 		if c.LB.ListenerPort == 0 {
 			c.LB.ListenerPort = 80
+			if c.LB.TLS.Enabled() {
+				c.LB.ListenerPort = 443
+			}
 		}
 		if c.LB.ListenerPort != 80 && c.LB.ListenerPort != 443 {
 			return fmt.Errorf("lb.listener_port must be 80 or 443")
@@ -533,6 +760,22 @@ func (c *AppConfig) Validate() error {
 		if c.LB.TargetPort == 0 {
 			c.LB.TargetPort = 80
 		}
+		if c.LB.TargetProtocol == "" {
+			c.LB.TargetProtocol = "HTTP"
+		}
+		c.LB.TargetProtocol = strings.ToUpper(c.LB.TargetProtocol)
+		if c.LB.TargetProtocol != "HTTP" && c.LB.TargetProtocol != "HTTPS" {
+			return fmt.Errorf("lb.target_protocol must be HTTP or HTTPS")
+		}
+		if err := c.validateLBTLS(); err != nil {
+			return err
+		}
+		if err := c.validateLBTargets(); err != nil {
+			return err
+		}
+		if err := c.validateLBWAF(); err != nil {
+			return err
+		}
 		if c.LB.AllowedCIDR == "" {
 			c.LB.AllowedCIDR = c.Infrastructure.VpcCIDR
 		}
@@ -542,6 +785,8 @@ func (c *AppConfig) Validate() error {
 		if len(c.LB.SubnetIDs) < 2 {
 			return fmt.Errorf("lb.subnet_ids must have at least 2 subnets")
 		}
+	} else if c.LB.WAF.Enabled {
+		return fmt.Errorf("lb.waf requires lb.enabled=true")
 	}
 
 	if c.AppScaling.Enabled {
@@ -579,12 +824,24 @@ func (c *AppConfig) Validate() error {
 		if c.LB.InstanceCount != 1 {
 			return fmt.Errorf("lb.instance_count cannot be used when app_scaling.enabled=true")
 		}
+		if err := c.validateScalingPolicies(); err != nil {
+			return err
+		}
+		if err := c.validateRollout(); err != nil {
+			return err
+		}
+	} else if c.AppScaling.Rollout.Enabled {
+		return fmt.Errorf("app_scaling.rollout requires app_scaling.enabled=true")
 	}
 
 	if !c.AppScaling.Enabled && c.LB.InstanceCount > 1 && !c.LB.Enabled {
 		return fmt.Errorf("lb.instance_count>1 requires lb.enabled=true")
 	}
 
+	if err := c.validateDNS(); err != nil {
+		return err
+	}
+
 	if c.Observability.Enabled == nil {
 		enabled := true
 		c.Observability.Enabled = &enabled
@@ -690,6 +947,73 @@ func (c *AppConfig) Validate() error {
 	return nil
 }
 
+func (c *AppConfig) validateCost() error {
+	if c.Cost.BudgetMonthlyUSD < 0 {
+		return fmt.Errorf("cost.budget_monthly_usd must be >= 0")
+	}
+	if c.Cost.MaxDeltaMonthlyUSD < 0 {
+		return fmt.Errorf("cost.max_delta_monthly_usd must be >= 0")
+	}
+	for env, v := range c.Cost.BudgetByEnvironment {
+		if v < 0 {
+			return fmt.Errorf("cost.budget_by_environment.%s must be >= 0", env)
+		}
+	}
+	if c.Cost.Usage.SnapshotStorageGB < 0 {
+		return fmt.Errorf("cost.usage.snapshot_storage_gb must be >= 0")
+	}
+	if c.Cost.Usage.LogIngestionGBMonth < 0 {
+		return fmt.Errorf("cost.usage.log_ingestion_gb_month must be >= 0")
+	}
+	if c.Cost.Usage.NATDataProcessedGBMonth < 0 {
+		return fmt.Errorf("cost.usage.nat_data_processed_gb_month must be >= 0")
+	}
+	if c.Cost.BudgetAction == "" {
+		c.Cost.BudgetAction = "confirm"
+	}
+	if c.Cost.BudgetAction != "confirm" && c.Cost.BudgetAction != "block" {
+		return fmt.Errorf("cost.budget_action must be one of: confirm, block")
+	}
+	return nil
+}
+
+// validateTags aplica os limites de tag da AWS e as políticas de tags obrigatórias
+// (a do repositório e a do contrato).
+func (c *AppConfig) validateTags() error {
+	for k, v := range c.Tags {
+		key := strings.TrimSpace(k)
+		switch {
+		case key == "":
+			return fmt.Errorf("tags: key must not be empty")
+		case strings.HasPrefix(strings.ToLower(key), "aws:"):
+			return fmt.Errorf("tags.%s: prefix 'aws:' is reserved by AWS", k)
+		case strings.HasPrefix(strings.ToLower(key), ManagedTagPrefix):
+			return fmt.Errorf("tags.%s: prefix '%s' is reserved for brainctl-managed tags", k, ManagedTagPrefix)
+		case len(key) > 128:
+			return fmt.Errorf("tags.%s: key must be at most 128 characters", k)
+		case len(v) > 256:
+			return fmt.Errorf("tags.%s: value must be at most 256 characters", k)
+		}
+	}
+	for _, required := range c.RepoTagPolicy.Required {
+		if strings.TrimSpace(required) == "" {
+			return fmt.Errorf("%s: required must not contain empty keys", RepoTagPolicyFile)
+		}
+		if strings.TrimSpace(c.Tags[required]) == "" {
+			return fmt.Errorf("tags.%s is required by the repository tag policy (%s)", required, RepoTagPolicyFile)
+		}
+	}
+	for _, required := range c.TagPolicy.Required {
+		if strings.TrimSpace(required) == "" {
+			return fmt.Errorf("tag_policy.required must not contain empty keys")
+		}
+		if strings.TrimSpace(c.Tags[required]) == "" {
+			return fmt.Errorf("tags.%s is required by tag_policy.required", required)
+		}
+	}
+	return nil
+}
+
 func (c *AppConfig) TerraformBackendKey() string {
 	prefix := strings.Trim(c.Terraform.Backend.KeyPrefix, "/")
 	base := fmt.Sprintf("%s/%s/terraform.tfstate", c.App.Name, c.App.Environment)
@@ -698,3 +1022,268 @@ func (c *AppConfig) TerraformBackendKey() string {
 	}
 	return fmt.Sprintf("%s/%s", prefix, base)
 }
+
+// validateLBTLS aplica defaults de lb.tls e garante que a porta 443 nunca
+// sirva HTTP puro. ALB público em produção precisa de TLS.
+func (c *AppConfig) validateLBTLS() error {
+	tls := &c.LB.TLS
+	if !tls.Enabled() {
+		if tls.SSLPolicy != "" || tls.RedirectHTTP != nil || tls.HostedZoneID != "" || len(tls.SubjectAlternativeNames) > 0 {
+			return fmt.Errorf("lb.tls requires certificate_arn or domain")
+		}
+		if c.LB.ListenerPort == 443 {
+			return fmt.Errorf("lb.listener_port=443 requires lb.tls (certificate_arn or domain)")
+		}
+		if c.LB.Scheme == "public" && isProductionEnvironment(c.App.Environment) {
+			return fmt.Errorf("lb.tls is required for public load balancers in environment %q", c.App.Environment)
+		}
+		return nil
+	}
+
+	if c.LB.ListenerPort != 443 {
+		return fmt.Errorf("lb.tls requires lb.listener_port=443")
+	}
+	if tls.CertificateARN != "" && tls.Domain != "" {
+		return fmt.Errorf("lb.tls.certificate_arn and lb.tls.domain are mutually exclusive")
+	}
+	if tls.CertificateARN != "" {
+		if !strings.HasPrefix(tls.CertificateARN, "arn:aws:acm:") {
+			return fmt.Errorf("lb.tls.certificate_arn must be an ACM certificate ARN (arn:aws:acm:...)")
+		}
+		if tls.HostedZoneID != "" || len(tls.SubjectAlternativeNames) > 0 {
+			return fmt.Errorf("lb.tls.hosted_zone_id and lb.tls.subject_alternative_names are only used with lb.tls.domain")
+		}
+	}
+	if tls.Domain != "" && strings.TrimSpace(tls.HostedZoneID) == "" {
+		return fmt.Errorf("lb.tls.hosted_zone_id is required when lb.tls.domain is set (ACM DNS validation)")
+	}
+	if tls.SSLPolicy == "" {
+		tls.SSLPolicy = DefaultSSLPolicy
+	}
+	if !strings.HasPrefix(tls.SSLPolicy, "ELBSecurityPolicy-") {
+		return fmt.Errorf("lb.tls.ssl_policy must be an ELBSecurityPolicy-* policy")
+	}
+	if tls.RedirectHTTP == nil {
+		redirect := true
+		tls.RedirectHTTP = &redirect
+	}
+	return nil
+}
+
+func isProductionEnvironment(env string) bool {
+	switch strings.ToLower(strings.TrimSpace(env)) {
+	case "prod", "production":
+		return true
+	}
+	return false
+}
+
+var (
+	healthCheckMatcherPattern = regexp.MustCompile(`^\d{3}(-\d{3})?(,\d{3}(-\d{3})?)*$`)
+	lbRouteNamePattern        = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,15}$`)
+)
+
+// validateLBTargets aplica defaults de health check, stickiness e rotas por path.
+// Os limites seguem os do target group/listener rule da AWS para falhar no
+// validate e não no apply.
+func (c *AppConfig) validateLBTargets() error {
+	hc := &c.LB.HealthCheck
+	if hc.Path == "" {
+		hc.Path = "/"
+	}
+	if !strings.HasPrefix(hc.Path, "/") {
+		return fmt.Errorf("lb.health_check.path must start with /")
+	}
+	if hc.Port < 0 || hc.Port > 65535 {
+		return fmt.Errorf("lb.health_check.port must be between 1 and 65535 (omit to use the traffic port)")
+	}
+	if hc.Matcher == "" {
+		hc.Matcher = "200-399"
+	}
+	if !healthCheckMatcherPattern.MatchString(hc.Matcher) {
+		return fmt.Errorf("lb.health_check.matcher must be HTTP codes like 200, 200,301 or 200-399")
+	}
+	if hc.Interval == 0 {
+		hc.Interval = 30
+	}
+	if hc.Interval < 5 || hc.Interval > 300 {
+		return fmt.Errorf("lb.health_check.interval must be between 5 and 300")
+	}
+	if hc.Timeout == 0 {
+		hc.Timeout = 5
+	}
+	if hc.Timeout < 2 || hc.Timeout > 120 || hc.Timeout >= hc.Interval {
+		return fmt.Errorf("lb.health_check.timeout must be between 2 and 120 and lower than interval")
+	}
+	if hc.HealthyThreshold == 0 {
+		hc.HealthyThreshold = 2
+	}
+	if hc.UnhealthyThreshold == 0 {
+		hc.UnhealthyThreshold = 2
+	}
+	if hc.HealthyThreshold < 2 || hc.HealthyThreshold > 10 || hc.UnhealthyThreshold < 2 || hc.UnhealthyThreshold > 10 {
+		return fmt.Errorf("lb.health_check.healthy_threshold and unhealthy_threshold must be between 2 and 10")
+	}
+
+	if c.LB.DeregistrationDelay == nil {
+		delay := 300
+		c.LB.DeregistrationDelay = &delay
+	}
+	if *c.LB.DeregistrationDelay < 0 || *c.LB.DeregistrationDelay > 3600 {
+		return fmt.Errorf("lb.deregistration_delay must be between 0 and 3600 seconds")
+	}
+
+	// Type e duração recebem default mesmo desligados: o bloco stickiness do
+	// target group é sempre renderizado e a AWS exige um tipo válido.
+	st := &c.LB.Stickiness
+	if st.Type == "" {
+		st.Type = "lb_cookie"
+	}
+	if st.DurationSeconds == 0 {
+		st.DurationSeconds = 86400
+	}
+	if st.Enabled {
+		switch st.Type {
+		case "lb_cookie":
+			if st.CookieName != "" {
+				return fmt.Errorf("lb.stickiness.cookie_name is only supported with type app_cookie")
+			}
+		case "app_cookie":
+			if strings.TrimSpace(st.CookieName) == "" {
+				return fmt.Errorf("lb.stickiness.cookie_name is required when type=app_cookie")
+			}
+		default:
+			return fmt.Errorf("lb.stickiness.type must be lb_cookie or app_cookie")
+		}
+		if st.DurationSeconds < 1 || st.DurationSeconds > 604800 {
+			return fmt.Errorf("lb.stickiness.duration_seconds must be between 1 and 604800")
+		}
+	}
+
+	names := map[string]bool{}
+	priorities := map[int]string{}
+	for i := range c.LB.Routes {
+		r := &c.LB.Routes[i]
+		if !lbRouteNamePattern.MatchString(r.Name) {
+			return fmt.Errorf("lb.routes[%d].name must be 1-16 lowercase letters, digits or hyphens", i)
+		}
+		if names[r.Name] {
+			return fmt.Errorf("lb.routes[%d].name %q is duplicated", i, r.Name)
+		}
+		names[r.Name] = true
+		if len(r.PathPatterns) == 0 || len(r.PathPatterns) > 5 {
+			return fmt.Errorf("lb.routes[%d].path_patterns must have between 1 and 5 patterns", i)
+		}
+		for _, pattern := range r.PathPatterns {
+			if !strings.HasPrefix(pattern, "/") {
+				return fmt.Errorf("lb.routes[%d].path_patterns entries must start with /", i)
+			}
+		}
+		if r.TargetPort < 1 || r.TargetPort > 65535 {
+			return fmt.Errorf("lb.routes[%d].target_port must be between 1 and 65535", i)
+		}
+		if r.Priority == 0 {
+			r.Priority = (i + 1) * 10
+		}
+		if r.Priority < 1 || r.Priority > 50000 {
+			return fmt.Errorf("lb.routes[%d].priority must be between 1 and 50000", i)
+		}
+		if other, ok := priorities[r.Priority]; ok {
+			return fmt.Errorf("lb.routes[%d].priority %d is already used by route %q", i, r.Priority, other)
+		}
+		priorities[r.Priority] = r.Name
+		if r.HealthCheckPath != "" && !strings.HasPrefix(r.HealthCheckPath, "/") {
+			return fmt.Errorf("lb.routes[%d].health_check_path must start with /", i)
+		}
+	}
+	return nil
+}
+
+var dnsRecordNamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*$`)
+
+// validateDNS garante que os registros tenham um alvo: alias do ALB quando
+// lb.enabled, ou um registro A por instância app fixa quando não há LB.
+func (c *AppConfig) validateDNS() error {
+	d := &c.DNS
+	if !d.Enabled {
+		if d.ZoneID != "" || d.ZoneName != "" || d.RecordName != "" || d.InstanceRecords {
+			return fmt.Errorf("dns fields require dns.enabled=true")
+		}
+		return nil
+	}
+	if (d.ZoneID == "") == (d.ZoneName == "") {
+		return fmt.Errorf("dns requires exactly one of dns.zone_id or dns.zone_name")
+	}
+	d.ZoneName = strings.TrimSuffix(strings.ToLower(d.ZoneName), ".")
+	if d.RecordName == "" {
+		d.RecordName = c.App.Name + "-" + c.App.Environment
+	}
+	d.RecordName = strings.TrimSuffix(strings.ToLower(d.RecordName), ".")
+	if !dnsRecordNamePattern.MatchString(d.RecordName) {
+		return fmt.Errorf("dns.record_name must be a DNS name (labels with letters, digits and hyphens)")
+	}
+	if d.TTL == 0 {
+		d.TTL = 60
+	}
+	if d.TTL < 0 || d.TTL > 86400 {
+		return fmt.Errorf("dns.ttl must be between 1 and 86400")
+	}
+	if c.LB.Enabled {
+		if d.InstanceRecords {
+			return fmt.Errorf("dns.instance_records is only supported when lb.enabled=false (the record points to the ALB)")
+		}
+		return nil
+	}
+	if !d.InstanceRecords {
+		return fmt.Errorf("dns requires lb.enabled=true or dns.instance_records=true")
+	}
+	return nil
+}
+
+// validateLBWAF aplica defaults do web ACL. Com ARN existente, regras e rate
+// limit ficam a cargo do dono do ACL e não podem ser declarados aqui.
+func (c *AppConfig) validateLBWAF() error {
+	waf := &c.LB.WAF
+	if !waf.Enabled {
+		if waf.WebACLARN != "" || len(waf.ManagedRules) > 0 || waf.RateLimit != 0 {
+			return fmt.Errorf("lb.waf fields require lb.waf.enabled=true")
+		}
+		return nil
+	}
+	if waf.WebACLARN != "" {
+		if !strings.HasPrefix(waf.WebACLARN, "arn:aws:wafv2:") || !strings.Contains(waf.WebACLARN, ":regional/webacl/") {
+			return fmt.Errorf("lb.waf.web_acl_arn must be a regional WAFv2 web ACL ARN (arn:aws:wafv2:<region>:<account>:regional/webacl/...)")
+		}
+		if len(waf.ManagedRules) > 0 || waf.RateLimit != 0 {
+			return fmt.Errorf("lb.waf.managed_rules and lb.waf.rate_limit are only supported when the module creates the web ACL (omit lb.waf.web_acl_arn)")
+		}
+	} else {
+		if len(waf.ManagedRules) == 0 {
+			waf.ManagedRules = append([]string{}, WAFManagedRules...)
+		}
+		supported := map[string]bool{}
+		for _, rule := range WAFManagedRules {
+			supported[rule] = true
+		}
+		seen := map[string]bool{}
+		for _, rule := range waf.ManagedRules {
+			if !supported[rule] {
+				return fmt.Errorf("lb.waf.managed_rules has unsupported rule group %q (supported: %s)", rule, strings.Join(WAFManagedRules, ", "))
+			}
+			if seen[rule] {
+				return fmt.Errorf("lb.waf.managed_rules has duplicated rule group %q", rule)
+			}
+			seen[rule] = true
+		}
+		if waf.RateLimit != 0 && (waf.RateLimit < 100 || waf.RateLimit > 2000000000) {
+			return fmt.Errorf("lb.waf.rate_limit must be between 100 and 2000000000 requests per 5 minutes per IP (0 disables)")
+		}
+	}
+	if waf.BlockedRequestsAlarm == 0 {
+		waf.BlockedRequestsAlarm = 100
+	}
+	if waf.BlockedRequestsAlarm < 1 {
+		return fmt.Errorf("lb.waf.blocked_requests_alarm must be >= 1")
+	}
+	return nil
+}
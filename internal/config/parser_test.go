@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -277,6 +278,116 @@ func TestValidate_K8sWorkersMinimal(t *testing.T) {
 	}
 }
 
+func TestValidate_K8sNodePools(t *testing.T) {
+	t.Parallel()
+
+	newCfg := func() *AppConfig {
+		cfg := &AppConfig{}
+		cfg.Workload.Type = "k8s-workers"
+		cfg.Workload.Version = "v1"
+		cfg.App.Name = "brainctl-k8s"
+		cfg.App.Environment = "dev"
+		cfg.App.Region = "us-east-1"
+		cfg.Terraform.Backend.Bucket = "brainctl-test-state"
+		cfg.Infrastructure.VpcID = "vpc-123"
+		cfg.Infrastructure.VpcCIDR = "10.0.0.0/16"
+		cfg.Infrastructure.SubnetID = "subnet-123"
+		return cfg
+	}
+
+	cfg := newCfg()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate config: %v", err)
+	}
+	pools := cfg.K8s.EffectiveNodePools()
+	if len(pools) != 1 || pools[0].Name != DefaultNodePoolName || pools[0].DesiredCapacity != 2 || pools[0].InstanceTypes[0] != "t3.medium" {
+		t.Fatalf("expected worker_count shorthand as default pool, got %+v", pools)
+	}
+
+	cfg = newCfg()
+	cfg.K8s.NodePools = []K8sNodePoolConfig{
+		{Name: "general", MinSize: 2, MaxSize: 5},
+		{Name: "batch", InstanceTypes: []string{"m5.large", "m6i.large"}, MaxSize: 10, Spot: true,
+			Labels: map[string]string{"workload": "batch"}, Taints: []K8sTaint{{Key: "dedicated", Value: "batch"}}},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate node pools: %v", err)
+	}
+	general, batch := cfg.K8s.NodePools[0], cfg.K8s.NodePools[1]
+	if general.DesiredCapacity != 2 || general.InstanceTypes[0] != "t3.medium" || batch.Taints[0].Effect != "NoSchedule" || cfg.K8s.WorkerCount != 0 {
+		t.Fatalf("unexpected pool defaults: %+v %+v", general, batch)
+	}
+
+	cases := []struct {
+		mutate func(*AppConfig)
+		want   string
+	}{
+		{func(c *AppConfig) {
+			c.K8s.WorkerCount = 3
+			c.K8s.NodePools = []K8sNodePoolConfig{{Name: "general", MinSize: 1}}
+		}, "k8s.worker_count is a shorthand for a single node pool; use either worker_count or node_pools"},
+		{func(c *AppConfig) {
+			c.K8s.NodePools = []K8sNodePoolConfig{{Name: "general", MinSize: 3, MaxSize: 2}}
+		}, "k8s.node_pools[0]: expected min_size (3) <= desired_capacity (3) <= max_size (2)"},
+		{func(c *AppConfig) {
+			c.K8s.NodePools = []K8sNodePoolConfig{{Name: "general", MinSize: 1, Labels: map[string]string{"node-role.kubernetes.io/worker": ""}}}
+		}, `k8s.node_pools[0].labels: key "node-role.kubernetes.io/worker" uses a reserved prefix the kubelet cannot self-apply`},
+		{func(c *AppConfig) {
+			c.K8s.NodePools = []K8sNodePoolConfig{{Name: "general", MinSize: 1, Taints: []K8sTaint{{Key: "gpu", Effect: "Evict"}}}}
+		}, "k8s.node_pools[0].taints[0].effect must be NoSchedule, PreferNoSchedule or NoExecute"},
+	}
+	for i, tc := range cases {
+		cfg := newCfg()
+		tc.mutate(cfg)
+		err := cfg.Validate()
+		if err == nil || err.Error() != tc.want {
+			t.Fatalf("case %d: expected %q, got %v", i, tc.want, err)
+		}
+	}
+}
+
+func TestValidate_K8sControlPlaneCount(t *testing.T) {
+	t.Parallel()
+
+	newCfg := func(count int) *AppConfig {
+		cfg := &AppConfig{}
+		cfg.Workload.Type = "k8s-workers"
+		cfg.Workload.Version = "v1"
+		cfg.App.Name = "brainctl-k8s"
+		cfg.App.Environment = "dev"
+		cfg.App.Region = "us-east-1"
+		cfg.Terraform.Backend.Bucket = "brainctl-test-state"
+		cfg.Infrastructure.VpcID = "vpc-123"
+		cfg.Infrastructure.VpcCIDR = "10.0.0.0/16"
+		cfg.Infrastructure.SubnetID = "subnet-123"
+		cfg.K8s.ControlPlaneCount = count
+		return cfg
+	}
+
+	cfg := newCfg(0)
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate config: %v", err)
+	}
+	if cfg.K8s.ControlPlaneCount != 1 || cfg.K8s.JoinTokenTTLMinutes != 60 {
+		t.Fatalf("unexpected defaults: control_plane_count=%d join_token_ttl_minutes=%d", cfg.K8s.ControlPlaneCount, cfg.K8s.JoinTokenTTLMinutes)
+	}
+	err := newCfg(3).Validate()
+	if err == nil || err.Error() != "k8s.control_plane_count>1 requires infrastructure.subnet_ids with at least 2 subnets (control planes and the NLB are spread across them)" {
+		t.Fatalf("expected HA without subnet_ids to fail, got: %v", err)
+	}
+	cfg = newCfg(3)
+	cfg.Infrastructure.SubnetIDs = []string{"subnet-123", "subnet-456"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate HA config: %v", err)
+	}
+	for _, count := range []int{2, 4, 9} {
+		err := newCfg(count).Validate()
+		if err == nil || err.Error() != "k8s.control_plane_count must be odd (1, 3, 5 or 7) to keep etcd quorum" {
+			t.Fatalf("count %d: unexpected validate error: %v", count, err)
+		}
+	}
+}
+
 func TestValidate_InvalidWorkloadType(t *testing.T) {
 	t.Parallel()
 
@@ -289,6 +400,110 @@ func TestValidate_InvalidWorkloadType(t *testing.T) {
 	}
 }
 
+func TestValidate_CostBudget(t *testing.T) {
+	t.Parallel()
+
+	cfg := minimalValidConfig()
+	cfg.Cost.BudgetMonthlyUSD = 500
+	cfg.Cost.BudgetByEnvironment = map[string]float64{"dev": 120}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate failed: %v", err)
+	}
+	if cfg.Cost.BudgetAction != "confirm" {
+		t.Fatalf("expected default cost.budget_action=confirm, got %q", cfg.Cost.BudgetAction)
+	}
+	if got := cfg.Cost.EffectiveBudget("dev"); got != 120 {
+		t.Fatalf("expected per-environment budget 120, got %.2f", got)
+	}
+	if got := cfg.Cost.EffectiveBudget("prod"); got != 500 {
+		t.Fatalf("expected fallback budget 500, got %.2f", got)
+	}
+
+	cfg = minimalValidConfig()
+	cfg.Cost.BudgetAction = "warn"
+	err := cfg.Validate()
+	if err == nil || err.Error() != "cost.budget_action must be one of: confirm, block" {
+		t.Fatalf("unexpected validate error: %v", err)
+	}
+
+	cfg = minimalValidConfig()
+	cfg.Cost.Usage.SnapshotStorageGB = -1
+	err = cfg.Validate()
+	if err == nil || err.Error() != "cost.usage.snapshot_storage_gb must be >= 0" {
+		t.Fatalf("unexpected validate error: %v", err)
+	}
+}
+
+func TestValidate_LinuxOS(t *testing.T) {
+	t.Parallel()
+
+	cfg := minimalValidConfig()
+	cfg.EC2.OS = OSAmazonLinux2023
+	cfg.DB.Enabled = true
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate failed: %v", err)
+	}
+	if cfg.EC2.AllowedSSHCIDR != "10.0.0.0/16" || cfg.EC2.AllowedRDPCIDR != "" {
+		t.Fatalf("expected SSH CIDR defaulted from vpc_cidr and no RDP CIDR, got ssh=%q rdp=%q", cfg.EC2.AllowedSSHCIDR, cfg.EC2.AllowedRDPCIDR)
+	}
+	if cfg.DB.OS != OSAmazonLinux2023 || cfg.DB.Port != 5432 {
+		t.Fatalf("expected db to follow ec2.os with port 5432, got os=%q port=%d", cfg.DB.OS, cfg.DB.Port)
+	}
+
+	cfg = minimalValidConfig()
+	cfg.EC2.OS = OSUbuntu2204
+	cfg.EC2.AllowedRDPCIDR = "10.0.0.0/16"
+	err := cfg.Validate()
+	if err == nil || err.Error() != "ec2.allowed_rdp_cidr is only supported on windows-2022; use ec2.allowed_ssh_cidr" {
+		t.Fatalf("unexpected validate error: %v", err)
+	}
+
+	cfg = minimalValidConfig()
+	cfg.EC2.OS = "windows"
+	if err := cfg.Validate(); err != nil || cfg.EC2.OS != OSWindows2022 {
+		t.Fatalf("expected legacy os alias normalized, got %q (%v)", cfg.EC2.OS, err)
+	}
+
+	cfg = minimalValidConfig()
+	cfg.EC2.OS = "rhel-9"
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected error for unsupported ec2.os")
+	}
+}
+
+func TestValidate_TagPolicy(t *testing.T) {
+	t.Parallel()
+
+	cfg := minimalValidConfig()
+	cfg.Tags = map[string]string{"owner": "time-billing", "cost_center": "cc-42"}
+	cfg.TagPolicy.Required = []string{"owner", "cost_center"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate failed: %v", err)
+	}
+
+	cfg = minimalValidConfig()
+	cfg.Tags = map[string]string{"owner": "time-billing"}
+	cfg.TagPolicy.Required = []string{"owner", "cost_center"}
+	err := cfg.Validate()
+	if err == nil || err.Error() != "tags.cost_center is required by tag_policy.required" {
+		t.Fatalf("unexpected validate error: %v", err)
+	}
+
+	cfg = minimalValidConfig()
+	cfg.Tags = map[string]string{"cost_center": "cc-42"}
+	cfg.RepoTagPolicy.Required = []string{"owner"}
+	err = cfg.Validate()
+	if err == nil || err.Error() != "tags.owner is required by the repository tag policy (policies/tags.yaml)" {
+		t.Fatalf("expected repository policy to apply without tag_policy in the contract, got: %v", err)
+	}
+
+	cfg = minimalValidConfig()
+	cfg.Tags = map[string]string{"brainctl:blueprint": "x"}
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected error for reserved brainctl: tag prefix")
+	}
+}
+
 func TestApplySecurityGroupRulesDir(t *testing.T) {
 	t.Parallel()
 
@@ -331,3 +546,366 @@ func TestApplySecurityGroupRulesDir_InvalidGroup(t *testing.T) {
 		t.Fatalf("expected error for invalid group")
 	}
 }
+
+func TestValidate_LBTLS(t *testing.T) {
+	t.Parallel()
+
+	withLB := func() *AppConfig {
+		cfg := minimalValidConfig()
+		cfg.LB.Enabled = true
+		cfg.LB.SubnetIDs = []string{"subnet-a", "subnet-b"}
+		return cfg
+	}
+
+	cfg := withLB()
+	cfg.LB.TLS.CertificateARN = "arn:aws:acm:us-east-1:123456789012:certificate/abc"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate failed: %v", err)
+	}
+	if cfg.LB.ListenerPort != 443 || cfg.LB.TLS.SSLPolicy != DefaultSSLPolicy || cfg.LB.TLS.RedirectHTTP == nil || !*cfg.LB.TLS.RedirectHTTP {
+		t.Fatalf("expected TLS defaults, got port=%d policy=%q redirect=%v", cfg.LB.ListenerPort, cfg.LB.TLS.SSLPolicy, cfg.LB.TLS.RedirectHTTP)
+	}
+	if cfg.LB.TargetProtocol != "HTTP" {
+		t.Fatalf("expected target_protocol default HTTP, got %q", cfg.LB.TargetProtocol)
+	}
+
+	cases := []struct {
+		name   string
+		mutate func(*AppConfig)
+		want   string
+	}{
+		{"443 without tls", func(c *AppConfig) { c.LB.ListenerPort = 443 }, "lb.listener_port=443 requires lb.tls (certificate_arn or domain)"},
+		{"tls on 80", func(c *AppConfig) {
+			c.LB.ListenerPort = 80
+			c.LB.TLS.CertificateARN = "arn:aws:acm:us-east-1:1:certificate/x"
+		}, "lb.tls requires lb.listener_port=443"},
+		{"domain without zone", func(c *AppConfig) { c.LB.TLS.Domain = "app.example.com" }, "lb.tls.hosted_zone_id is required when lb.tls.domain is set (ACM DNS validation)"},
+		{"arn and domain", func(c *AppConfig) {
+			c.LB.TLS.CertificateARN = "arn:aws:acm:us-east-1:1:certificate/x"
+			c.LB.TLS.Domain = "app.example.com"
+		}, "lb.tls.certificate_arn and lb.tls.domain are mutually exclusive"},
+		{"bad policy", func(c *AppConfig) {
+			c.LB.TLS.CertificateARN = "arn:aws:acm:us-east-1:1:certificate/x"
+			c.LB.TLS.SSLPolicy = "TLSv1"
+		}, "lb.tls.ssl_policy must be an ELBSecurityPolicy-* policy"},
+		{"public prod without tls", func(c *AppConfig) {
+			c.App.Environment = "prod"
+			c.LB.Scheme = "public"
+			c.LB.AllowedCIDR = "203.0.113.0/24"
+		}, `lb.tls is required for public load balancers in environment "prod"`},
+		{"bad target protocol", func(c *AppConfig) { c.LB.TargetProtocol = "tcp" }, "lb.target_protocol must be HTTP or HTTPS"},
+	}
+	for _, tc := range cases {
+		cfg := withLB()
+		tc.mutate(cfg)
+		err := cfg.Validate()
+		if err == nil || err.Error() != tc.want {
+			t.Fatalf("%s: expected %q, got %v", tc.name, tc.want, err)
+		}
+	}
+
+	cfg = withLB()
+	cfg.App.Environment = "prod"
+	cfg.LB.Scheme = "public"
+	cfg.LB.AllowedCIDR = "203.0.113.0/24"
+	cfg.LB.TLS.Domain = "app.example.com"
+	cfg.LB.TLS.HostedZoneID = "Z123"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected public prod ALB with ACM domain to validate, got %v", err)
+	}
+}
+
+func TestValidate_LBTargets(t *testing.T) {
+	t.Parallel()
+
+	withLB := func() *AppConfig {
+		cfg := minimalValidConfig()
+		cfg.LB.Enabled = true
+		cfg.LB.SubnetIDs = []string{"subnet-a", "subnet-b"}
+		return cfg
+	}
+
+	cfg := withLB()
+	cfg.LB.HealthCheck.Path = "/healthz"
+	cfg.LB.Routes = []LBRouteConfig{
+		{Name: "api", PathPatterns: []string{"/api/*"}, TargetPort: 8081},
+		{Name: "admin", PathPatterns: []string{"/admin", "/admin/*"}, TargetPort: 8082},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate failed: %v", err)
+	}
+	hc := cfg.LB.HealthCheck
+	if hc.Matcher != "200-399" || hc.Interval != 30 || hc.Timeout != 5 || hc.HealthyThreshold != 2 || hc.UnhealthyThreshold != 2 {
+		t.Fatalf("unexpected health check defaults: %+v", hc)
+	}
+	if cfg.LB.DeregistrationDelay == nil || *cfg.LB.DeregistrationDelay != 300 || cfg.LB.Stickiness.Type != "lb_cookie" {
+		t.Fatalf("unexpected deregistration/stickiness defaults: %v %+v", cfg.LB.DeregistrationDelay, cfg.LB.Stickiness)
+	}
+	if cfg.LB.Routes[0].Priority != 10 || cfg.LB.Routes[1].Priority != 20 {
+		t.Fatalf("expected default route priorities 10/20, got %d/%d", cfg.LB.Routes[0].Priority, cfg.LB.Routes[1].Priority)
+	}
+
+	cases := []struct {
+		name   string
+		mutate func(*AppConfig)
+		want   string
+	}{
+		{"timeout >= interval", func(c *AppConfig) { c.LB.HealthCheck.Interval = 5; c.LB.HealthCheck.Timeout = 5 }, "lb.health_check.timeout must be between 2 and 120 and lower than interval"},
+		{"bad matcher", func(c *AppConfig) { c.LB.HealthCheck.Matcher = "2xx" }, "lb.health_check.matcher must be HTTP codes like 200, 200,301 or 200-399"},
+		{"app cookie without name", func(c *AppConfig) {
+			c.LB.Stickiness.Enabled = true
+			c.LB.Stickiness.Type = "app_cookie"
+		}, "lb.stickiness.cookie_name is required when type=app_cookie"},
+		{"duplicated priority", func(c *AppConfig) {
+			c.LB.Routes = []LBRouteConfig{
+				{Name: "api", PathPatterns: []string{"/api/*"}, TargetPort: 8081, Priority: 20},
+				{Name: "admin", PathPatterns: []string{"/admin/*"}, TargetPort: 8082},
+			}
+		}, `lb.routes[1].priority 20 is already used by route "api"`},
+		{"relative pattern", func(c *AppConfig) {
+			c.LB.Routes = []LBRouteConfig{{Name: "api", PathPatterns: []string{"api/*"}, TargetPort: 8081}}
+		}, "lb.routes[0].path_patterns entries must start with /"},
+	}
+	for _, tc := range cases {
+		cfg := withLB()
+		tc.mutate(cfg)
+		err := cfg.Validate()
+		if err == nil || err.Error() != tc.want {
+			t.Fatalf("%s: expected %q, got %v", tc.name, tc.want, err)
+		}
+	}
+}
+
+func TestValidate_DNS(t *testing.T) {
+	t.Parallel()
+
+	cfg := minimalValidConfig()
+	cfg.LB.Enabled = true
+	cfg.LB.SubnetIDs = []string{"subnet-a", "subnet-b"}
+	cfg.DNS.Enabled = true
+	cfg.DNS.ZoneName = "Example.internal."
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate failed: %v", err)
+	}
+	if cfg.DNS.ZoneName != "example.internal" || cfg.DNS.RecordName != cfg.App.Name+"-dev" || cfg.DNS.TTL != 60 {
+		t.Fatalf("unexpected dns defaults: %+v", cfg.DNS)
+	}
+
+	cfg = minimalValidConfig()
+	cfg.DNS.Enabled = true
+	cfg.DNS.ZoneID = "Z123"
+	err := cfg.Validate()
+	if err == nil || err.Error() != "dns requires lb.enabled=true or dns.instance_records=true" {
+		t.Fatalf("unexpected validate error: %v", err)
+	}
+	cfg.DNS.InstanceRecords = true
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected instance records without LB to validate, got %v", err)
+	}
+
+	cfg = minimalValidConfig()
+	cfg.DNS.Enabled = true
+	cfg.DNS.ZoneID = "Z123"
+	cfg.DNS.ZoneName = "example.internal"
+	err = cfg.Validate()
+	if err == nil || err.Error() != "dns requires exactly one of dns.zone_id or dns.zone_name" {
+		t.Fatalf("unexpected validate error: %v", err)
+	}
+}
+
+func TestValidate_LBWAF(t *testing.T) {
+	t.Parallel()
+
+	withWAF := func() *AppConfig {
+		cfg := minimalValidConfig()
+		cfg.LB.Enabled = true
+		cfg.LB.SubnetIDs = []string{"subnet-a", "subnet-b"}
+		cfg.LB.WAF.Enabled = true
+		return cfg
+	}
+
+	cfg := withWAF()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate failed: %v", err)
+	}
+	if strings.Join(cfg.LB.WAF.ManagedRules, ",") != "core,known_bad_inputs,ip_reputation" || cfg.LB.WAF.BlockedRequestsAlarm != 100 {
+		t.Fatalf("unexpected waf defaults: %+v", cfg.LB.WAF)
+	}
+
+	cfg = withWAF()
+	cfg.LB.WAF.WebACLARN = "arn:aws:wafv2:us-east-1:123456789012:regional/webacl/shared/abc"
+	cfg.LB.WAF.RateLimit = 1000
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "only supported when the module creates the web ACL") {
+		t.Fatalf("unexpected validate error: %v", err)
+	}
+
+	cfg = withWAF()
+	cfg.LB.WAF.ManagedRules = []string{"sqli"}
+	err = cfg.Validate()
+	if err == nil || err.Error() != `lb.waf.managed_rules has unsupported rule group "sqli" (supported: core, known_bad_inputs, ip_reputation)` {
+		t.Fatalf("unexpected validate error: %v", err)
+	}
+
+	cfg = withWAF()
+	cfg.LB.Enabled = false
+	err = cfg.Validate()
+	if err == nil || err.Error() != "lb.waf requires lb.enabled=true" {
+		t.Fatalf("unexpected validate error: %v", err)
+	}
+}
+
+func TestValidate_AppScalingRollout(t *testing.T) {
+	t.Parallel()
+
+	withASG := func() *AppConfig {
+		cfg := minimalValidConfig()
+		cfg.LB.Enabled = true
+		cfg.LB.SubnetIDs = []string{"subnet-a", "subnet-b"}
+		cfg.AppScaling.Enabled = true
+		cfg.AppScaling.Rollout.Enabled = true
+		return cfg
+	}
+
+	cfg := withASG()
+	cfg.AppScaling.Rollout.CheckpointPercentages = []int{25, 100}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate failed: %v", err)
+	}
+	r := cfg.AppScaling.Rollout
+	if *r.MinHealthyPercentage != 90 || *r.InstanceWarmup != 300 || *r.CheckpointDelay != 3600 || !*r.AutoRollback {
+		t.Fatalf("unexpected rollout defaults: %+v", r)
+	}
+
+	cfg = withASG()
+	zero := 0
+	cfg.AppScaling.Rollout.InstanceWarmup = &zero
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate failed: %v", err)
+	}
+	if *cfg.AppScaling.Rollout.InstanceWarmup != 0 {
+		t.Fatalf("expected explicit instance_warmup 0 to be kept, got %d", *cfg.AppScaling.Rollout.InstanceWarmup)
+	}
+
+	cfg = withASG()
+	cfg.AppScaling.Rollout.CheckpointPercentages = []int{50}
+	err := cfg.Validate()
+	if err == nil || err.Error() != "app_scaling.rollout.checkpoint_percentages must end with 100 to replace the whole fleet" {
+		t.Fatalf("unexpected validate error: %v", err)
+	}
+
+	cfg = minimalValidConfig()
+	cfg.AppScaling.Rollout.Enabled = true
+	err = cfg.Validate()
+	if err == nil || err.Error() != "app_scaling.rollout requires app_scaling.enabled=true" {
+		t.Fatalf("unexpected validate error: %v", err)
+	}
+}
+
+func TestValidate_AppScalingPolicies(t *testing.T) {
+	t.Parallel()
+
+	intPtr := func(v int) *int { return &v }
+	floatPtr := func(v float64) *float64 { return &v }
+	withASG := func() *AppConfig {
+		cfg := minimalValidConfig()
+		cfg.LB.Enabled = true
+		cfg.LB.SubnetIDs = []string{"subnet-a", "subnet-b"}
+		cfg.AppScaling.Enabled = true
+		cfg.AppScaling.MinSize = 1
+		cfg.AppScaling.MaxSize = 4
+		return cfg
+	}
+
+	cfg := withASG()
+	cfg.AppScaling.RequestsPerTarget = 1000
+	cfg.AppScaling.Schedules = []ScalingSchedule{
+		{Name: "night-off", Recurrence: "0 20 * * 1-5", MinSize: intPtr(0), DesiredCapacity: intPtr(0)},
+		{Name: "peak", Recurrence: "0 11 * * *", TimeZone: "America/Sao_Paulo", MinSize: intPtr(3), MaxSize: intPtr(8)},
+	}
+	cfg.AppScaling.StepPolicies = []StepScalingPolicy{{
+		Name: "queue-backlog", Namespace: "AWS/SQS", MetricName: "ApproximateNumberOfMessagesVisible", Threshold: 100,
+		Steps: []ScalingStep{{LowerBound: floatPtr(0), UpperBound: floatPtr(400), Adjustment: 1}, {LowerBound: floatPtr(400), Adjustment: 3}},
+	}}
+	cfg.AppScaling.WarmPool.Enabled = true
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate failed: %v", err)
+	}
+	p := cfg.AppScaling.StepPolicies[0]
+	if cfg.AppScaling.Schedules[0].TimeZone != "UTC" || p.Statistic != "Average" || p.AdjustmentType != "ChangeInCapacity" || p.Period != 60 || cfg.AppScaling.WarmPool.PoolState != "Stopped" {
+		t.Fatalf("unexpected scaling defaults: %+v %+v", cfg.AppScaling.Schedules[0], p)
+	}
+
+	cases := []struct {
+		mutate func(*AppConfig)
+		want   string
+	}{
+		{func(c *AppConfig) {
+			c.AppScaling.Schedules = []ScalingSchedule{{Name: "big", Recurrence: "0 8 * * *", DesiredCapacity: intPtr(6)}}
+		}, "app_scaling.schedules[0]: desired_capacity (6) must be between min_size (1) and max_size (4)"},
+		{func(c *AppConfig) {
+			c.AppScaling.Schedules = []ScalingSchedule{{Name: "bad", Recurrence: "daily", MinSize: intPtr(0)}}
+		}, `app_scaling.schedules[0].recurrence must be a 5-field cron expression (ex: "0 20 * * 1-5")`},
+		{func(c *AppConfig) {
+			c.AppScaling.StepPolicies = []StepScalingPolicy{{Name: "gap", Namespace: "X", MetricName: "Y",
+				Steps: []ScalingStep{{LowerBound: floatPtr(0), UpperBound: floatPtr(10), Adjustment: 1}, {LowerBound: floatPtr(20), Adjustment: 2}}}}
+		}, "app_scaling.step_policies[0].steps[1].lower_bound must equal the previous upper_bound (no gaps or overlaps)"},
+		{func(c *AppConfig) {
+			c.AppScaling.WarmPool = WarmPoolConfig{Enabled: true, MaxPreparedCapacity: intPtr(2)}
+		}, "app_scaling.warm_pool.max_prepared_capacity must be >= app_scaling.max_size"},
+	}
+	for i, tc := range cases {
+		cfg := withASG()
+		tc.mutate(cfg)
+		err := cfg.Validate()
+		if err == nil || err.Error() != tc.want {
+			t.Fatalf("case %d: expected %q, got %v", i, tc.want, err)
+		}
+	}
+}
+
+func TestValidate_AppScalingInstances(t *testing.T) {
+	t.Parallel()
+
+	withASG := func() *AppConfig {
+		cfg := minimalValidConfig()
+		cfg.LB.Enabled = true
+		cfg.LB.SubnetIDs = []string{"subnet-a", "subnet-b"}
+		cfg.AppScaling.Enabled = true
+		cfg.AppScaling.MinSize = 2
+		cfg.AppScaling.MaxSize = 6
+		cfg.AppScaling.Instances.Types = []string{"t3.medium", "t3a.medium"}
+		return cfg
+	}
+
+	cfg := withASG()
+	pct := 25
+	cfg.AppScaling.Instances.OnDemandBaseCapacity = 1
+	cfg.AppScaling.Instances.OnDemandPercentage = &pct
+	cfg.AppScaling.Instances.CapacityRebalance = true
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate failed: %v", err)
+	}
+	in := cfg.AppScaling.Instances
+	if in.SpotAllocationStrategy != DefaultSpotAllocationStrategy {
+		t.Fatalf("unexpected spot strategy default: %q", in.SpotAllocationStrategy)
+	}
+	// base 1 + 25% de 4 acima da base = 2 on-demand, 3 Spot
+	if od, spot := in.Split(5); od != 2 || spot == 0 || od+spot != 5 {
+		t.Fatalf("unexpected split: on-demand=%d spot=%d", od, spot)
+	}
+
+	cfg = withASG()
+	cfg.AppScaling.Instances.CapacityRebalance = true
+	err := cfg.Validate()
+	if err == nil || err.Error() != "app_scaling.instances.capacity_rebalance requires Spot capacity (on_demand_percentage < 100)" {
+		t.Fatalf("unexpected validate error: %v", err)
+	}
+
+	cfg = withASG()
+	cfg.AppScaling.WarmPool.Enabled = true
+	err = cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "warm_pool is not supported together with app_scaling.instances") {
+		t.Fatalf("unexpected validate error: %v", err)
+	}
+}
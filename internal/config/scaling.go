@@ -0,0 +1,368 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ScalingSchedule é uma ação agendada do ASG (cron em time_zone). Campos nil
+// mantêm o valor atual do grupo; min_size/desired_capacity 0 desligam a frota
+// (ex.: dev fora do horário comercial).
+type ScalingSchedule struct {
+	Name            string `yaml:"name"`
+	Recurrence      string `yaml:"recurrence"`
+	TimeZone        string `yaml:"time_zone"`
+	MinSize         *int   `yaml:"min_size"`
+	MaxSize         *int   `yaml:"max_size"`
+	DesiredCapacity *int   `yaml:"desired_capacity"`
+}
+
+// StepScalingPolicy cria um alarme CloudWatch sobre uma métrica qualquer e
+// ajusta a capacidade em degraus relativos ao threshold.
+type StepScalingPolicy struct {
+	Name               string            `yaml:"name"`
+	MetricName         string            `yaml:"metric_name"`
+	Namespace          string            `yaml:"namespace"`
+	Statistic          string            `yaml:"statistic"`
+	Dimensions         map[string]string `yaml:"dimensions"`
+	ComparisonOperator string            `yaml:"comparison_operator"`
+	Threshold          float64           `yaml:"threshold"`
+	Period             int               `yaml:"period"`
+	EvaluationPeriods  int               `yaml:"evaluation_periods"`
+	AdjustmentType     string            `yaml:"adjustment_type"`
+	Steps              []ScalingStep     `yaml:"steps"`
+}
+
+// ScalingStep é um degrau da política: bounds relativos ao threshold (nil = sem limite).
+type ScalingStep struct {
+	LowerBound *float64 `yaml:"lower_bound"`
+	UpperBound *float64 `yaml:"upper_bound"`
+	Adjustment int      `yaml:"adjustment"`
+}
+
+// WarmPoolConfig mantém instâncias pré-inicializadas (paradas ou em execução)
+// para reduzir o tempo de scale-out de AMIs com bootstrap lento.
+type WarmPoolConfig struct {
+	Enabled             bool   `yaml:"enabled"`
+	MinSize             int    `yaml:"min_size"`
+	MaxPreparedCapacity *int   `yaml:"max_prepared_capacity"`
+	PoolState           string `yaml:"pool_state"`
+	ReuseOnScaleIn      bool   `yaml:"reuse_on_scale_in"`
+}
+
+// ASGInstancesConfig habilita a mixed instances policy do ASG: vários tipos de
+// instância e uma parcela Spot acima da base on-demand. Sem types o ASG segue
+// usando apenas ec2.instance_type do launch template.
+type ASGInstancesConfig struct {
+	Types                  []string `yaml:"types"`
+	OnDemandBaseCapacity   int      `yaml:"on_demand_base_capacity"`
+	OnDemandPercentage     *int     `yaml:"on_demand_percentage"`
+	SpotAllocationStrategy string   `yaml:"spot_allocation_strategy"`
+	CapacityRebalance      bool     `yaml:"capacity_rebalance"`
+}
+
+// Mixed indica se o ASG usa mixed instances policy.
+func (i ASGInstancesConfig) Mixed() bool {
+	return len(i.Types) > 0
+}
+
+// Split divide a capacidade entre on-demand e Spot como o Auto Scaling faz:
+// a base é on-demand e o restante segue on_demand_percentage (arredondado para cima).
+func (i ASGInstancesConfig) Split(capacity int) (onDemand, spot int) {
+	if !i.Mixed() {
+		return capacity, 0
+	}
+	base := min(i.OnDemandBaseCapacity, capacity)
+	pct := 100
+	if i.OnDemandPercentage != nil {
+		pct = *i.OnDemandPercentage
+	}
+	onDemand = base + ((capacity-base)*pct+99)/100
+	return onDemand, capacity - onDemand
+}
+
+// RolloutConfig liga o instance refresh do ASG: mudanças no launch template
+// (AMI, user data, tipo) substituem a frota gradualmente em vez de só afetar
+// instâncias novas.
+type RolloutConfig struct {
+	Enabled               bool     `yaml:"enabled"`
+	MinHealthyPercentage  *int     `yaml:"min_healthy_percentage"`
+	InstanceWarmup        *int     `yaml:"instance_warmup"`
+	CheckpointPercentages []int    `yaml:"checkpoint_percentages"`
+	CheckpointDelay       *int     `yaml:"checkpoint_delay"`
+	AutoRollback          *bool    `yaml:"auto_rollback"`
+	RollbackAlarms        []string `yaml:"rollback_alarms"`
+}
+
+var (
+	scalingNamePattern  = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+	instanceTypePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*\.[a-z0-9]+$`)
+	// cron de 5 campos aceito pelo aws_autoscaling_schedule (minuto hora dia mês dia-da-semana).
+	cronPattern = regexp.MustCompile(`^\S+ \S+ \S+ \S+ \S+$`)
+)
+
+var stepComparisonOperators = map[string]bool{
+	"GreaterThanOrEqualToThreshold": true,
+	"GreaterThanThreshold":          true,
+	"LessThanThreshold":             true,
+	"LessThanOrEqualToThreshold":    true,
+}
+
+// DefaultSpotAllocationStrategy equilibra preço e risco de interrupção.
+const DefaultSpotAllocationStrategy = "price-capacity-optimized"
+
+var spotAllocationStrategies = map[string]bool{
+	"price-capacity-optimized":       true,
+	"capacity-optimized":             true,
+	"capacity-optimized-prioritized": true,
+	"lowest-price":                   true,
+}
+
+var stepAdjustmentTypes = map[string]bool{
+	"ChangeInCapacity":        true,
+	"PercentChangeInCapacity": true,
+	"ExactCapacity":           true,
+}
+
+// validateScalingPolicies valida request-count tracking, schedules, step
+// policies e warm pool contra min_size/max_size do ASG já com defaults.
+func (c *AppConfig) validateScalingPolicies() error {
+	s := &c.AppScaling
+	if s.RequestsPerTarget < 0 {
+		return fmt.Errorf("app_scaling.requests_per_target must be >= 0 (0 disables request-count tracking)")
+	}
+
+	names := map[string]bool{}
+	for i := range s.Schedules {
+		sc := &s.Schedules[i]
+		if !scalingNamePattern.MatchString(sc.Name) {
+			return fmt.Errorf("app_scaling.schedules[%d].name must be 1-32 lowercase letters, digits or hyphens", i)
+		}
+		if names[sc.Name] {
+			return fmt.Errorf("app_scaling.schedules[%d].name %q is duplicated", i, sc.Name)
+		}
+		names[sc.Name] = true
+		sc.Recurrence = strings.TrimSpace(sc.Recurrence)
+		if !cronPattern.MatchString(sc.Recurrence) {
+			return fmt.Errorf("app_scaling.schedules[%d].recurrence must be a 5-field cron expression (ex: \"0 20 * * 1-5\")", i)
+		}
+		if sc.TimeZone == "" {
+			sc.TimeZone = "UTC"
+		}
+		if sc.MinSize == nil && sc.MaxSize == nil && sc.DesiredCapacity == nil {
+			return fmt.Errorf("app_scaling.schedules[%d] must set min_size, max_size or desired_capacity", i)
+		}
+		minSize, maxSize := s.MinSize, s.MaxSize
+		if sc.MinSize != nil {
+			minSize = *sc.MinSize
+		}
+		if sc.MaxSize != nil {
+			maxSize = *sc.MaxSize
+		}
+		if minSize < 0 || maxSize < 0 {
+			return fmt.Errorf("app_scaling.schedules[%d] sizes must be >= 0", i)
+		}
+		if minSize > maxSize {
+			return fmt.Errorf("app_scaling.schedules[%d]: min_size (%d) must be <= max_size (%d)", i, minSize, maxSize)
+		}
+		if sc.DesiredCapacity != nil && (*sc.DesiredCapacity < minSize || *sc.DesiredCapacity > maxSize) {
+			return fmt.Errorf("app_scaling.schedules[%d]: desired_capacity (%d) must be between min_size (%d) and max_size (%d)", i, *sc.DesiredCapacity, minSize, maxSize)
+		}
+	}
+
+	names = map[string]bool{}
+	for i := range s.StepPolicies {
+		p := &s.StepPolicies[i]
+		field := fmt.Sprintf("app_scaling.step_policies[%d]", i)
+		if !scalingNamePattern.MatchString(p.Name) {
+			return fmt.Errorf("%s.name must be 1-32 lowercase letters, digits or hyphens", field)
+		}
+		if names[p.Name] {
+			return fmt.Errorf("%s.name %q is duplicated", field, p.Name)
+		}
+		names[p.Name] = true
+		if p.MetricName == "" || p.Namespace == "" {
+			return fmt.Errorf("%s requires metric_name and namespace", field)
+		}
+		if p.Statistic == "" {
+			p.Statistic = "Average"
+		}
+		if p.ComparisonOperator == "" {
+			p.ComparisonOperator = "GreaterThanOrEqualToThreshold"
+		}
+		if !stepComparisonOperators[p.ComparisonOperator] {
+			return fmt.Errorf("%s.comparison_operator %q is not supported", field, p.ComparisonOperator)
+		}
+		if p.Period == 0 {
+			p.Period = 60
+		}
+		if p.EvaluationPeriods == 0 {
+			p.EvaluationPeriods = 2
+		}
+		if p.Period < 10 || p.EvaluationPeriods < 1 {
+			return fmt.Errorf("%s.period must be >= 10 and evaluation_periods >= 1", field)
+		}
+		if p.AdjustmentType == "" {
+			p.AdjustmentType = "ChangeInCapacity"
+		}
+		if !stepAdjustmentTypes[p.AdjustmentType] {
+			return fmt.Errorf("%s.adjustment_type must be ChangeInCapacity, PercentChangeInCapacity or ExactCapacity", field)
+		}
+		if err := validateScalingSteps(field, p.Steps, p.AdjustmentType, s.MinSize, s.MaxSize); err != nil {
+			return err
+		}
+	}
+
+	if err := c.validateASGInstances(); err != nil {
+		return err
+	}
+
+	wp := &s.WarmPool
+	if wp.Enabled {
+		if s.Instances.Mixed() {
+			return fmt.Errorf("app_scaling.warm_pool is not supported together with app_scaling.instances (mixed instances policy)")
+		}
+		if wp.PoolState == "" {
+			wp.PoolState = "Stopped"
+		}
+		if wp.PoolState != "Stopped" && wp.PoolState != "Running" {
+			return fmt.Errorf("app_scaling.warm_pool.pool_state must be Stopped or Running")
+		}
+		if wp.MinSize < 0 || wp.MinSize > s.MaxSize {
+			return fmt.Errorf("app_scaling.warm_pool.min_size must be between 0 and app_scaling.max_size")
+		}
+		if wp.MaxPreparedCapacity != nil && *wp.MaxPreparedCapacity < s.MaxSize {
+			return fmt.Errorf("app_scaling.warm_pool.max_prepared_capacity must be >= app_scaling.max_size")
+		}
+	}
+	return nil
+}
+
+// validateASGInstances aplica defaults da mixed instances policy; os campos de
+// distribuição só fazem sentido com types.
+func (c *AppConfig) validateASGInstances() error {
+	in := &c.AppScaling.Instances
+	if !in.Mixed() {
+		if in.OnDemandBaseCapacity != 0 || in.OnDemandPercentage != nil || in.SpotAllocationStrategy != "" || in.CapacityRebalance {
+			return fmt.Errorf("app_scaling.instances requires types to configure on-demand/spot distribution")
+		}
+		return nil
+	}
+	seen := map[string]bool{}
+	for i, t := range in.Types {
+		if !instanceTypePattern.MatchString(t) {
+			return fmt.Errorf("app_scaling.instances.types[%d] %q is not a valid instance type", i, t)
+		}
+		if seen[t] {
+			return fmt.Errorf("app_scaling.instances.types[%d] %q is duplicated", i, t)
+		}
+		seen[t] = true
+	}
+	if in.OnDemandBaseCapacity < 0 || in.OnDemandBaseCapacity > c.AppScaling.MaxSize {
+		return fmt.Errorf("app_scaling.instances.on_demand_base_capacity must be between 0 and app_scaling.max_size")
+	}
+	if in.OnDemandPercentage == nil {
+		pct := 100
+		in.OnDemandPercentage = &pct
+	}
+	if *in.OnDemandPercentage < 0 || *in.OnDemandPercentage > 100 {
+		return fmt.Errorf("app_scaling.instances.on_demand_percentage must be between 0 and 100")
+	}
+	if in.SpotAllocationStrategy == "" {
+		in.SpotAllocationStrategy = DefaultSpotAllocationStrategy
+	}
+	if !spotAllocationStrategies[in.SpotAllocationStrategy] {
+		return fmt.Errorf("app_scaling.instances.spot_allocation_strategy %q is not supported (price-capacity-optimized, capacity-optimized, capacity-optimized-prioritized, lowest-price)", in.SpotAllocationStrategy)
+	}
+	if in.CapacityRebalance && *in.OnDemandPercentage == 100 {
+		return fmt.Errorf("app_scaling.instances.capacity_rebalance requires Spot capacity (on_demand_percentage < 100)")
+	}
+	return nil
+}
+
+// validateScalingSteps exige degraus contíguos e sem sobreposição, como a API
+// do Auto Scaling: só o primeiro pode ter lower_bound nulo e só o último upper_bound.
+func validateScalingSteps(field string, steps []ScalingStep, adjustmentType string, minSize, maxSize int) error {
+	if len(steps) == 0 {
+		return fmt.Errorf("%s.steps must have at least one step", field)
+	}
+	for i, st := range steps {
+		if st.LowerBound == nil && i != 0 {
+			return fmt.Errorf("%s.steps[%d].lower_bound can only be omitted on the first step", field, i)
+		}
+		if st.UpperBound == nil && i != len(steps)-1 {
+			return fmt.Errorf("%s.steps[%d].upper_bound can only be omitted on the last step", field, i)
+		}
+		if st.LowerBound == nil && st.UpperBound == nil {
+			return fmt.Errorf("%s.steps[%d] needs lower_bound or upper_bound", field, i)
+		}
+		if st.LowerBound != nil && st.UpperBound != nil && *st.LowerBound >= *st.UpperBound {
+			return fmt.Errorf("%s.steps[%d].lower_bound must be < upper_bound", field, i)
+		}
+		if i > 0 && (steps[i-1].UpperBound == nil || *steps[i-1].UpperBound != *st.LowerBound) {
+			return fmt.Errorf("%s.steps[%d].lower_bound must equal the previous upper_bound (no gaps or overlaps)", field, i)
+		}
+		if adjustmentType == "ExactCapacity" {
+			if st.Adjustment < minSize || st.Adjustment > maxSize {
+				return fmt.Errorf("%s.steps[%d].adjustment must be between app_scaling.min_size and max_size for ExactCapacity", field, i)
+			}
+		} else if st.Adjustment == 0 {
+			return fmt.Errorf("%s.steps[%d].adjustment cannot be 0", field, i)
+		}
+	}
+	return nil
+}
+
+// validateRollout aplica defaults do instance refresh. Checkpoints pausam o
+// refresh por CheckpointDelay segundos; a lista precisa terminar em 100 para
+// que toda a frota seja substituída.
+func (c *AppConfig) validateRollout() error {
+	r := &c.AppScaling.Rollout
+	if !r.Enabled {
+		return nil
+	}
+	if r.MinHealthyPercentage == nil {
+		healthy := 90
+		r.MinHealthyPercentage = &healthy
+	}
+	if *r.MinHealthyPercentage < 0 || *r.MinHealthyPercentage > 100 {
+		return fmt.Errorf("app_scaling.rollout.min_healthy_percentage must be between 0 and 100")
+	}
+	if r.InstanceWarmup == nil {
+		warmup := 300
+		r.InstanceWarmup = &warmup
+	}
+	if *r.InstanceWarmup < 0 {
+		return fmt.Errorf("app_scaling.rollout.instance_warmup must be >= 0")
+	}
+	for i, p := range r.CheckpointPercentages {
+		if p < 1 || p > 100 || (i > 0 && p <= r.CheckpointPercentages[i-1]) {
+			return fmt.Errorf("app_scaling.rollout.checkpoint_percentages must be ascending values between 1 and 100")
+		}
+	}
+	if n := len(r.CheckpointPercentages); n > 0 {
+		if r.CheckpointPercentages[n-1] != 100 {
+			return fmt.Errorf("app_scaling.rollout.checkpoint_percentages must end with 100 to replace the whole fleet")
+		}
+	} else if r.CheckpointDelay != nil {
+		return fmt.Errorf("app_scaling.rollout.checkpoint_delay requires checkpoint_percentages")
+	}
+	if r.CheckpointDelay == nil {
+		delay := 3600
+		r.CheckpointDelay = &delay
+	}
+	if *r.CheckpointDelay < 0 || *r.CheckpointDelay > 172800 {
+		return fmt.Errorf("app_scaling.rollout.checkpoint_delay must be between 0 and 172800 seconds")
+	}
+	if r.AutoRollback == nil {
+		rollback := true
+		r.AutoRollback = &rollback
+	}
+	for _, name := range r.RollbackAlarms {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("app_scaling.rollout.rollback_alarms cannot contain empty names")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Sistemas operacionais suportados pelo blueprint ec2-app (ec2.os / db.os).
+const (
+	OSWindows2022     = "windows-2022"
+	OSAmazonLinux2023 = "amazon-linux-2023"
+	OSUbuntu2204      = "ubuntu-22.04"
+)
+
+// SupportedOS lista os valores aceitos em ec2.os/db.os, na ordem exibida em erros.
+var SupportedOS = []string{OSWindows2022, OSAmazonLinux2023, OSUbuntu2204}
+
+// IsWindowsOS indica se o SO usa PowerShell/EC2Launch; os demais seguem o caminho Linux
+// (bash/cloud-init). Vazio conta como Windows, o default histórico do blueprint.
+func IsWindowsOS(osName string) bool {
+	return osName == "" || osName == OSWindows2022
+}
+
+// osAliases aceita valores de contratos anteriores ao suporte a Linux, quando ec2.os
+// não era renderizado e a documentação sugeria "windows".
+var osAliases = map[string]string{"windows": OSWindows2022}
+
+// DefaultSSHUser devolve o usuário criado pela AMI oficial do SO; vazio no Windows,
+// que não usa SSH.
+func DefaultSSHUser(osName string) string {
+	switch normalizeOS(osName) {
+	case OSAmazonLinux2023:
+		return "ec2-user"
+	case OSUbuntu2204:
+		return "ubuntu"
+	}
+	return ""
+}
+
+func normalizeOS(osName string) string {
+	if v, ok := osAliases[osName]; ok {
+		return v
+	}
+	return osName
+}
+
+func validOS(osName string) bool {
+	for _, v := range SupportedOS {
+		if osName == v {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeUserData devolve o corpo do user data sem wrapper, pronto para o módulo
+// compor com o bootstrap padrão: no Windows remove <powershell>; no Linux remove o
+// shebang bash (o módulo adiciona o seu) e mantém #cloud-config intacto.
+func NormalizeUserData(osName, raw string) (string, error) {
+	if IsWindowsOS(osName) {
+		return sanitizePowerShellUserData(raw)
+	}
+	return sanitizeShellUserData(raw)
+}
+
+var (
+	powershellOpenTag  = regexp.MustCompile(`(?i)<\s*powershell\s*>`)
+	powershellCloseTag = regexp.MustCompile(`(?i)</\s*powershell\s*>`)
+)
+
+// sanitizePowerShellUserData garante payload sem wrapper duplicado para o modo merge.
+// Se vier encapsulado com <powershell>...</powershell>, remove o wrapper e retorna apenas o corpo.
+func sanitizePowerShellUserData(raw string) (string, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return "", nil
+	}
+
+	openIndexes := powershellOpenTag.FindAllStringIndex(trimmed, -1)
+	closeIndexes := powershellCloseTag.FindAllStringIndex(trimmed, -1)
+
+	if len(openIndexes) == 0 && len(closeIndexes) == 0 {
+		return trimmed, nil
+	}
+	if len(openIndexes) != 1 || len(closeIndexes) != 1 {
+		return "", fmt.Errorf("must contain at most one <powershell> wrapper")
+	}
+
+	open := openIndexes[0]
+	close := closeIndexes[0]
+	if open[0] != 0 || close[1] != len(trimmed) || open[1] > close[0] {
+		return "", fmt.Errorf("<powershell> wrapper must enclose the whole script")
+	}
+
+	body := strings.TrimSpace(trimmed[open[1]:close[0]])
+	if body == "" {
+		return "", fmt.Errorf("script inside <powershell> wrapper is empty")
+	}
+	return body, nil
+}
+
+// bashShebangs são os interpretadores aceitos no Linux: o script roda concatenado ao
+// bootstrap padrão em bash, então outro interpretador quebraria o modo merge.
+var bashShebangs = map[string]bool{
+	"#!/bin/bash":         true,
+	"#!/usr/bin/env bash": true,
+	"#!/bin/sh":           true,
+}
+
+// sanitizeShellUserData valida o user data Linux: script bash (com ou sem shebang)
+// ou documento #cloud-config.
+func sanitizeShellUserData(raw string) (string, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return "", nil
+	}
+	if powershellOpenTag.MatchString(trimmed) {
+		return "", fmt.Errorf("<powershell> wrapper is only supported on %s", OSWindows2022)
+	}
+	if strings.HasPrefix(trimmed, "#cloud-config") {
+		return trimmed, nil
+	}
+	if !strings.HasPrefix(trimmed, "#!") {
+		return trimmed, nil
+	}
+
+	first, rest, _ := strings.Cut(trimmed, "\n")
+	if !bashShebangs[strings.TrimSpace(first)] {
+		return "", fmt.Errorf("unsupported interpreter %q: use a bash script or #cloud-config", strings.TrimSpace(first))
+	}
+	body := strings.TrimSpace(rest)
+	if body == "" {
+		return "", fmt.Errorf("script after shebang is empty")
+	}
+	return body, nil
+}
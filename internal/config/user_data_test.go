@@ -0,0 +1,99 @@
+package config
+
+import "testing"
+
+func TestSanitizePowerShellUserData(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{name: "plain script", in: "Write-Host 'ok'", want: "Write-Host 'ok'"},
+		{name: "wrapped script", in: "<powershell>\nWrite-Host 'ok'\n</powershell>", want: "Write-Host 'ok'"},
+		{name: "wrapped mixed case", in: "<PowerShell>Write-Host 'ok'</PowerShell>", want: "Write-Host 'ok'"},
+		{name: "empty", in: "", want: ""},
+		{name: "multiple wrappers", in: "<powershell>a</powershell>\n<powershell>b</powershell>", wantErr: true},
+		{name: "open tag only", in: "<powershell>Write-Host 'ok'", wantErr: true},
+		{name: "close tag only", in: "Write-Host 'ok'</powershell>", wantErr: true},
+		{name: "wrapper not enclosing whole script", in: "#comment\n<powershell>Write-Host 'ok'</powershell>", wantErr: true},
+		{name: "empty wrapped", in: "<powershell>   </powershell>", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := sanitizePowerShellUserData(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got nil with value %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNormalizeUserDataLinux(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{name: "plain bash", in: "echo ok", want: "echo ok"},
+		{name: "bash shebang stripped", in: "#!/bin/bash\nset -e\necho ok\n", want: "set -e\necho ok"},
+		{name: "env bash shebang stripped", in: "#!/usr/bin/env bash\necho ok", want: "echo ok"},
+		{name: "cloud-config kept", in: "#cloud-config\npackages: [nginx]\n", want: "#cloud-config\npackages: [nginx]"},
+		{name: "python rejected", in: "#!/usr/bin/env python3\nprint('ok')", wantErr: true},
+		{name: "powershell rejected", in: "<powershell>Write-Host 'ok'</powershell>", wantErr: true},
+		{name: "shebang only", in: "#!/bin/bash\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NormalizeUserData(OSAmazonLinux2023, tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got nil with value %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDefaultSSHUser(t *testing.T) {
+	t.Parallel()
+
+	for os, want := range map[string]string{
+		OSAmazonLinux2023: "ec2-user",
+		OSUbuntu2204:      "ubuntu",
+		OSWindows2022:     "",
+		"windows":         "",
+		"":                "",
+	} {
+		if got := DefaultSSHUser(os); got != want {
+			t.Fatalf("DefaultSSHUser(%q) = %q, want %q", os, got, want)
+		}
+	}
+}
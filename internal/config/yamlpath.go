@@ -0,0 +1,181 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SetYAMLPath grava value no caminho com pontos dentro do documento, criando os blocos
+// que faltarem no fim do mapa. Comentários e ordem das chaves existentes são preservados.
+func SetYAMLPath(doc *yaml.Node, path string, value any) error {
+	root := doc
+	if root.Kind == yaml.DocumentNode {
+		if len(root.Content) == 0 {
+			root.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+		}
+		root = root.Content[0]
+	}
+
+	var encoded yaml.Node
+	if err := encoded.Encode(value); err != nil {
+		return fmt.Errorf("encode %s: %w", path, err)
+	}
+
+	node := root
+	keys := strings.Split(path, ".")
+	for i, key := range keys {
+		if node.Kind != yaml.MappingNode {
+			return fmt.Errorf("set %s: %s is not a mapping", path, strings.Join(keys[:i], "."))
+		}
+		last := i == len(keys)-1
+		var child *yaml.Node
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			if node.Content[j].Value == key {
+				child = node.Content[j+1]
+				if last {
+					// preserva o comentário de linha do valor antigo
+					encoded.LineComment = child.LineComment
+					node.Content[j+1] = &encoded
+				}
+				break
+			}
+		}
+		if last {
+			if child == nil {
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, &encoded)
+			}
+			return nil
+		}
+		if child == nil || (child.Kind == yaml.ScalarNode && child.Tag == "!!null") {
+			next := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			if child == nil {
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, next)
+			} else {
+				*child = *next
+				next = child
+			}
+			child = next
+		}
+		node = child
+	}
+	return nil
+}
+
+// EncodeYAMLDocument serializa o documento editado com indentação 2. O yaml.v3 descarta
+// linhas em branco; as que separavam blocos de topo no original são restauradas para
+// que o diff do arquivo mostre só as mudanças de valor.
+func EncodeYAMLDocument(doc *yaml.Node, original []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	spaced := topLevelKeysAfterBlank(original)
+	var out []string
+	for _, line := range strings.SplitAfter(buf.String(), "\n") {
+		if key, ok := topLevelKey(line); ok && spaced[key] {
+			// a linha em branco vai antes dos comentários que antecedem a chave
+			at := len(out)
+			for at > 0 && strings.HasPrefix(out[at-1], "#") {
+				at--
+			}
+			if at > 0 {
+				out = append(out[:at], append([]string{"\n"}, out[at:]...)...)
+			}
+		}
+		out = append(out, line)
+	}
+	return []byte(strings.Join(out, "")), nil
+}
+
+// topLevelKeysAfterBlank retorna as chaves de topo precedidas (antes dos comentários) por linha
+// em branco. A primeira chave também conta: se um bloco for inserido antes dela, ganha o espaço.
+func topLevelKeysAfterBlank(src []byte) map[string]bool {
+	out := map[string]bool{}
+	blank := true
+	for _, line := range strings.Split(string(src), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			blank = true
+		case strings.HasPrefix(line, "#"):
+			// comentário de topo mantém o estado da linha em branco anterior
+		default:
+			if key, ok := topLevelKey(line); ok && blank {
+				out[key] = true
+			}
+			blank = false
+		}
+	}
+	return out
+}
+
+func topLevelKey(line string) (string, bool) {
+	if line == "" || line[0] == ' ' || line[0] == '#' || line[0] == '-' {
+		return "", false
+	}
+	i := strings.Index(line, ":")
+	if i <= 0 {
+		return "", false
+	}
+	return line[:i], true
+}
+
+// LookupYAMLPath retorna o nó de valor no caminho com pontos, ou nil se não existir.
+func LookupYAMLPath(doc *yaml.Node, path string) *yaml.Node {
+	parent, idx := lookupParent(doc, path)
+	if parent == nil {
+		return nil
+	}
+	return parent.Content[idx+1]
+}
+
+// DeleteYAMLPath remove a chave do mapa pai; retorna false se ela não existir.
+func DeleteYAMLPath(doc *yaml.Node, path string) bool {
+	parent, idx := lookupParent(doc, path)
+	if parent == nil {
+		return false
+	}
+	parent.Content = append(parent.Content[:idx], parent.Content[idx+2:]...)
+	return true
+}
+
+// lookupParent devolve o mapa que contém a última chave do caminho e o índice da chave.
+func lookupParent(doc *yaml.Node, path string) (*yaml.Node, int) {
+	node := doc
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil, 0
+		}
+		node = node.Content[0]
+	}
+	keys := strings.Split(path, ".")
+	for i, key := range keys {
+		if node.Kind != yaml.MappingNode {
+			return nil, 0
+		}
+		found := -1
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			if node.Content[j].Value == key {
+				found = j
+				break
+			}
+		}
+		if found < 0 {
+			return nil, 0
+		}
+		if i == len(keys)-1 {
+			return node, found
+		}
+		node = node.Content[found+1]
+	}
+	return nil, 0
+}
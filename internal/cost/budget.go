@@ -0,0 +1,35 @@
+package cost
+
+import "fmt"
+
+// BudgetResult é a avaliação do plano contra o orçamento do contrato.
+type BudgetResult struct {
+	BudgetMonthly    float64  `json:"budget_monthly"`
+	ProjectedMonthly float64  `json:"projected_monthly"`
+	MaxDeltaMonthly  float64  `json:"max_delta_monthly"`
+	DeltaMonthly     float64  `json:"delta_monthly"`
+	Violations       []string `json:"violations,omitempty"`
+}
+
+// Exceeded indica se algum limite foi ultrapassado.
+func (b *BudgetResult) Exceeded() bool {
+	return len(b.Violations) > 0
+}
+
+// EvaluateBudget compara o total projetado e o delta do plano com os limites.
+// Limites zerados são ignorados.
+func EvaluateBudget(diff *DiffReport, budgetMonthly, maxDeltaMonthly float64) *BudgetResult {
+	r := &BudgetResult{
+		BudgetMonthly:    budgetMonthly,
+		ProjectedMonthly: diff.Planned.TotalMonthly,
+		MaxDeltaMonthly:  maxDeltaMonthly,
+		DeltaMonthly:     diff.Delta.TotalMonthly,
+	}
+	if budgetMonthly > 0 && r.ProjectedMonthly > budgetMonthly {
+		r.Violations = append(r.Violations, fmt.Sprintf("projected monthly cost $%.2f exceeds budget $%.2f", r.ProjectedMonthly, budgetMonthly))
+	}
+	if maxDeltaMonthly > 0 && r.DeltaMonthly > maxDeltaMonthly {
+		r.Violations = append(r.Violations, fmt.Sprintf("monthly cost delta +$%.2f exceeds max_delta $%.2f", r.DeltaMonthly, maxDeltaMonthly))
+	}
+	return r
+}
@@ -0,0 +1,20 @@
+package cost
+
+import "testing"
+
+func TestEvaluateBudget(t *testing.T) {
+	diff := &DiffReport{
+		Planned: Report{TotalMonthly: 620},
+		Delta:   Report{TotalMonthly: 140},
+	}
+
+	if r := EvaluateBudget(diff, 700, 200); r.Exceeded() {
+		t.Fatalf("expected budget within limits, got %v", r.Violations)
+	}
+	if r := EvaluateBudget(diff, 500, 0); !r.Exceeded() || len(r.Violations) != 1 {
+		t.Fatalf("expected total budget violation, got %v", r.Violations)
+	}
+	if r := EvaluateBudget(diff, 0, 100); !r.Exceeded() || len(r.Violations) != 1 {
+		t.Fatalf("expected delta violation, got %v", r.Violations)
+	}
+}
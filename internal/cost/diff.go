@@ -0,0 +1,87 @@
+package cost
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DiffReport compara o custo do state atual (baseline) com o custo do plano proposto.
+// Delta usa o mesmo formato de Report, com valores positivos para aumento de custo.
+type DiffReport struct {
+	Baseline Report `json:"baseline"`
+	Planned  Report `json:"planned"`
+	Delta    Report `json:"delta"`
+}
+
+// EstimatePlanDiff executa Infracost sobre o JSON de um plano (terraform show -json).
+// Com plan JSON, o Infracost devolve o breakdown proposto e o pastBreakdown do state.
+func EstimatePlanDiff(planJSONPath, usageFile string) (*DiffReport, error) {
+	stdout, stderr, err := runInfracostBreakdown(planJSONPath, usageFile)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := ParseInfracostDiffJSON(stdout)
+	if err != nil {
+		return nil, fmt.Errorf("%w\nraw_stdout=%q\nraw_stderr=%q", err, strings.TrimSpace(string(stdout)), strings.TrimSpace(string(stderr)))
+	}
+	return diff, nil
+}
+
+// ParseInfracostDiffJSON agrega breakdown (planejado) e pastBreakdown (baseline) e calcula o delta.
+// Sem pastBreakdown (stack ainda não aplicada), o baseline é zero.
+func ParseInfracostDiffJSON(raw []byte) (*DiffReport, error) {
+	var payload infracostOutput
+	if err := json.Unmarshal(extractJSONObject(raw), &payload); err != nil {
+		return nil, fmt.Errorf("parse infracost json: %w", err)
+	}
+
+	var planned, baseline []infracostResource
+	for _, p := range payload.Projects {
+		planned = append(planned, p.Breakdown.Resources...)
+		if p.PastBreakdown != nil {
+			baseline = append(baseline, p.PastBreakdown.Resources...)
+		}
+	}
+
+	diff := &DiffReport{
+		Baseline: *aggregateResources(baseline),
+		Planned:  *aggregateResources(planned),
+	}
+	diff.Delta = *DiffReports(&diff.Baseline, &diff.Planned)
+	return diff, nil
+}
+
+// DiffReports calcula planned - baseline por serviço, omitindo serviços sem variação.
+func DiffReports(baseline, planned *Report) *Report {
+	delta := map[string]*ServiceCost{}
+	add := func(services []ServiceCost, sign float64) {
+		for _, s := range services {
+			entry, ok := delta[s.Service]
+			if !ok {
+				entry = &ServiceCost{Service: s.Service}
+				delta[s.Service] = entry
+			}
+			entry.Hourly += sign * s.Hourly
+			entry.Monthly += sign * s.Monthly
+		}
+	}
+	add(baseline.Services, -1)
+	add(planned.Services, 1)
+
+	out := &Report{Services: []ServiceCost{}}
+	for _, v := range delta {
+		v.Hourly = round4(v.Hourly)
+		v.Monthly = round2(v.Monthly)
+		if v.Hourly == 0 && v.Monthly == 0 {
+			continue
+		}
+		out.Services = append(out.Services, *v)
+	}
+	sort.Slice(out.Services, func(i, j int) bool { return out.Services[i].Service < out.Services[j].Service })
+	out.TotalHourly = round4(planned.TotalHourly - baseline.TotalHourly)
+	out.TotalMonthly = round2(planned.TotalMonthly - baseline.TotalMonthly)
+	return out
+}
@@ -0,0 +1,58 @@
+package cost
+
+import "testing"
+
+func TestParseInfracostDiffJSON(t *testing.T) {
+	raw := []byte(`{
+  "projects": [
+    {
+      "pastBreakdown": {
+        "resources": [
+          {"resourceType":"aws_instance","hourlyCost":"0.0416","monthlyCost":"30.37"},
+          {"resourceType":"aws_eip","hourlyCost":"0.005","monthlyCost":"3.65"}
+        ]
+      },
+      "breakdown": {
+        "resources": [
+          {"resourceType":"aws_instance","hourlyCost":"0.0416","monthlyCost":"30.37"},
+          {"resourceType":"aws_instance","hourlyCost":"0.0416","monthlyCost":"30.37"},
+          {"resourceType":"aws_lb","hourlyCost":"0.0225","monthlyCost":"16.43"}
+        ]
+      }
+    }
+  ]
+}`)
+
+	diff, err := ParseInfracostDiffJSON(raw)
+	if err != nil {
+		t.Fatalf("ParseInfracostDiffJSON failed: %v", err)
+	}
+	if diff.Baseline.TotalMonthly != 34.02 || diff.Planned.TotalMonthly != 77.17 {
+		t.Fatalf("unexpected totals: baseline=%.2f planned=%.2f", diff.Baseline.TotalMonthly, diff.Planned.TotalMonthly)
+	}
+	if diff.Delta.TotalMonthly != 43.15 {
+		t.Fatalf("expected +43.15/month, got %.2f", diff.Delta.TotalMonthly)
+	}
+
+	want := map[string]float64{"ALB": 16.43, "EC2": 30.37, "EIP": -3.65}
+	if len(diff.Delta.Services) != len(want) {
+		t.Fatalf("unexpected delta services: %+v", diff.Delta.Services)
+	}
+	for _, s := range diff.Delta.Services {
+		if want[s.Service] != s.Monthly {
+			t.Fatalf("unexpected delta for %s: %.2f", s.Service, s.Monthly)
+		}
+	}
+}
+
+func TestParseInfracostDiffJSONWithoutBaseline(t *testing.T) {
+	raw := []byte(`{"projects":[{"breakdown":{"resources":[{"resourceType":"aws_lb","hourlyCost":"0.0225","monthlyCost":"16.43"}]}}]}`)
+
+	diff, err := ParseInfracostDiffJSON(raw)
+	if err != nil {
+		t.Fatalf("ParseInfracostDiffJSON failed: %v", err)
+	}
+	if diff.Baseline.TotalMonthly != 0 || diff.Delta.TotalMonthly != 16.43 {
+		t.Fatalf("expected full planned cost as delta, got %+v", diff.Delta)
+	}
+}
@@ -25,16 +25,23 @@ type Report struct {
 	Services     []ServiceCost `json:"services"`
 	TotalHourly  float64       `json:"total_hourly"`
 	TotalMonthly float64       `json:"total_monthly"`
+	// PriceTable identifica a versão da tabela embarcada quando o report é offline.
+	PriceTable string `json:"price_table,omitempty"`
+	// SpotSavingsMonthly é a economia estimada da parcela Spot frente ao on-demand.
+	SpotSavingsMonthly float64 `json:"spot_savings_monthly,omitempty"`
 }
 
 type infracostOutput struct {
 	Projects []struct {
-		Breakdown struct {
-			Resources []infracostResource `json:"resources"`
-		} `json:"breakdown"`
+		Breakdown     infracostBreakdown  `json:"breakdown"`
+		PastBreakdown *infracostBreakdown `json:"pastBreakdown"`
 	} `json:"projects"`
 }
 
+type infracostBreakdown struct {
+	Resources []infracostResource `json:"resources"`
+}
+
 type infracostResource struct {
 	ResourceType   string                   `json:"resourceType"`
 	MonthlyCost    string                   `json:"monthlyCost"`
@@ -43,30 +50,45 @@ type infracostResource struct {
 }
 
 type infracostCostComponent struct {
+	Name        string `json:"name"`
 	MonthlyCost string `json:"monthlyCost"`
 	HourlyCost  string `json:"hourlyCost"`
 }
 
 // EstimateInfraBase executa Infracost e gera um report resumido para custos base.
-func EstimateInfraBase(workspaceDir string) (*Report, error) {
-	cmd := exec.Command("infracost", "breakdown", "--path", workspaceDir, "--format", "json", "--no-color")
-
-	var stdout bytes.Buffer
-	var stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		return nil, fmt.Errorf("infracost breakdown failed: %w\n%s", err, strings.TrimSpace(stderr.String()))
+// usageFile é opcional (ver WriteUsageFile).
+func EstimateInfraBase(workspaceDir, usageFile string) (*Report, error) {
+	stdout, stderr, err := runInfracostBreakdown(workspaceDir, usageFile)
+	if err != nil {
+		return nil, err
 	}
 
-	report, err := ParseInfracostJSON(stdout.Bytes())
+	report, err := ParseInfracostJSON(stdout)
 	if err != nil {
-		return nil, fmt.Errorf("%w\nraw_stdout=%q\nraw_stderr=%q", err, strings.TrimSpace(stdout.String()), strings.TrimSpace(stderr.String()))
+		return nil, fmt.Errorf("%w\nraw_stdout=%q\nraw_stderr=%q", err, strings.TrimSpace(string(stdout)), strings.TrimSpace(string(stderr)))
 	}
 	return report, nil
 }
 
+// runInfracostBreakdown executa `infracost breakdown` em um diretório ou plan JSON.
+func runInfracostBreakdown(path, usageFile string) (stdout []byte, stderr []byte, err error) {
+	args := []string{"breakdown", "--path", path, "--format", "json", "--no-color"}
+	if usageFile != "" {
+		args = append(args, "--usage-file", usageFile)
+	}
+	cmd := exec.Command("infracost", args...)
+
+	var out bytes.Buffer
+	var errOut bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &errOut
+
+	if err := cmd.Run(); err != nil {
+		return nil, nil, fmt.Errorf("infracost breakdown failed: %w\n%s", err, strings.TrimSpace(errOut.String()))
+	}
+	return out.Bytes(), errOut.Bytes(), nil
+}
+
 func extractJSONObject(raw []byte) []byte {
 	raw = bytes.TrimSpace(raw)
 	start := bytes.IndexByte(raw, '{')
@@ -84,46 +106,57 @@ func ParseInfracostJSON(raw []byte) (*Report, error) {
 		return nil, fmt.Errorf("parse infracost json: %w", err)
 	}
 
-	agg := map[string]*ServiceCost{}
+	var resources []infracostResource
 	for _, p := range payload.Projects {
-		for _, r := range p.Breakdown.Resources {
-			hourly := parseMoney(r.HourlyCost)
-			monthly := parseMoney(r.MonthlyCost)
-
-			if hourly == 0 || monthly == 0 {
-				ch, cm := sumComponents(r.CostComponents)
-				if hourly == 0 {
-					hourly = ch
-				}
-				if monthly == 0 {
-					monthly = cm
-				}
-			}
-			if hourly == 0 && monthly > 0 {
-				hourly = monthly / monthlyHours
+		resources = append(resources, p.Breakdown.Resources...)
+	}
+	return aggregateResources(resources), nil
+}
+
+// aggregateResources soma o custo dos recursos por serviço.
+func aggregateResources(resources []infracostResource) *Report {
+	agg := map[string]*ServiceCost{}
+	for _, r := range resources {
+		hourly := parseMoney(r.HourlyCost)
+		monthly := parseMoney(r.MonthlyCost)
+
+		if hourly == 0 || monthly == 0 {
+			ch, cm := sumComponents(r.CostComponents)
+			if hourly == 0 {
+				hourly = ch
 			}
-			if monthly == 0 && hourly > 0 {
-				monthly = hourly * monthlyHours
+			if monthly == 0 {
+				monthly = cm
 			}
+		}
+		if hourly == 0 && monthly > 0 {
+			hourly = monthly / monthlyHours
+		}
+		if monthly == 0 && hourly > 0 {
+			monthly = hourly * monthlyHours
+		}
 
-			service, ok := classifyResourceType(r.ResourceType)
-			if !ok {
-				if hourly == 0 && monthly == 0 {
-					continue
-				}
-				service = fmt.Sprintf("Other (%s)", r.ResourceType)
+		service, ok := classifyResource(r)
+		if !ok {
+			if hourly == 0 && monthly == 0 {
+				continue
 			}
+			service = fmt.Sprintf("Other (%s)", r.ResourceType)
+		}
 
-			entry, exists := agg[service]
-			if !exists {
-				entry = &ServiceCost{Service: service}
-				agg[service] = entry
-			}
-			entry.Hourly += hourly
-			entry.Monthly += monthly
+		entry, exists := agg[service]
+		if !exists {
+			entry = &ServiceCost{Service: service}
+			agg[service] = entry
 		}
+		entry.Hourly += hourly
+		entry.Monthly += monthly
 	}
+	return finalizeReport(agg)
+}
 
+// finalizeReport arredonda os serviços, ordena por nome e calcula os totais.
+func finalizeReport(agg map[string]*ServiceCost) *Report {
 	services := make([]ServiceCost, 0, len(agg))
 	var totalH, totalM float64
 	for _, v := range agg {
@@ -139,32 +172,62 @@ func ParseInfracostJSON(raw []byte) (*Report, error) {
 		Services:     services,
 		TotalHourly:  round4(totalH),
 		TotalMonthly: round2(totalM),
-	}, nil
+	}
+}
+
+// resourceServices mapeia cada resource type criado pelos módulos (e os que o Infracost
+// devolve para uso, como aws_data_transfer) para a categoria do report.
+// Recursos sem custo próprio (IAM, security groups, rotas) ficam de fora e só aparecem
+// como "Other" se um dia o Infracost passar a cobrar por eles.
+var resourceServices = map[string]string{
+	"aws_instance":                   "EC2",
+	"aws_autoscaling_group":          "EC2",
+	"aws_launch_template":            "EC2",
+	"aws_ebs_volume":                 "EBS",
+	"aws_ebs_snapshot":               "EBS Snapshots",
+	"aws_dlm_lifecycle_policy":       "EBS Snapshots",
+	"aws_db_instance":                "RDS",
+	"aws_lb":                         "ALB",
+	"aws_alb":                        "ALB",
+	"aws_lb_listener":                "ALB",
+	"aws_lb_target_group":            "ALB",
+	"aws_lb_target_group_attachment": "ALB",
+	"aws_wafv2_web_acl":              "WAF",
+	"aws_route53_zone":               "Route 53",
+	"aws_route53_record":             "Route 53",
+	"aws_nat_gateway":                "NAT Gateway",
+	"aws_eip":                        "EIP",
+	"aws_vpc_endpoint":               "VPC Endpoint",
+	"aws_cloudwatch_log_group":       "CloudWatch Logs",
+	"aws_cloudwatch_metric_alarm":    "CloudWatch Alarms",
+	"aws_cloudwatch_dashboard":       "CloudWatch Dashboards",
+	"aws_sns_topic":                  "SNS",
+	"aws_sns_topic_subscription":     "SNS",
+	"aws_ssm_association":            "SSM",
+	"aws_ssm_document":               "SSM",
+	"aws_ssm_parameter":              "SSM",
+	"aws_scheduler_schedule":         "EventBridge Scheduler",
+	"aws_kms_key":                    "KMS",
+	"aws_data_transfer":              "Data Transfer",
 }
 
 func classifyResourceType(resourceType string) (string, bool) {
-	switch resourceType {
-	case "aws_instance":
-		return "EC2", true
-	case "aws_ebs_volume":
-		return "EBS", true
-	case "aws_db_instance":
-		return "RDS", true
-	case "aws_lb":
-		return "ALB", true
-	case "aws_nat_gateway":
-		return "NAT Gateway", true
-	case "aws_eip":
-		return "EIP", true
-	case "aws_vpc_endpoint":
-		return "VPC Endpoint", true
-	case "aws_cloudwatch_log_group":
-		return "CloudWatch Logs", true
-	case "aws_sns_topic":
-		return "SNS", true
-	default:
-		return "", false
+	service, ok := resourceServices[resourceType]
+	return service, ok
+}
+
+// classifyResource refina classifyResourceType para aws_lb, que cobre ALB e NLB (o
+// load balancer do control-plane HA): o Infracost nomeia o componente de custo pelo
+// load_balancer_type, e o report usa "NLB" como a estimativa offline.
+func classifyResource(r infracostResource) (string, bool) {
+	if r.ResourceType == "aws_lb" {
+		for _, c := range r.CostComponents {
+			if strings.HasPrefix(strings.ToLower(c.Name), "network load balancer") {
+				return "NLB", true
+			}
+		}
 	}
+	return classifyResourceType(r.ResourceType)
 }
 
 func sumComponents(components []infracostCostComponent) (hourly float64, monthly float64) {
@@ -69,3 +69,39 @@ func TestParseInfracostJSONWithPrefixedLogs(t *testing.T) {
 		t.Fatalf("expected 1 service, got %d", len(report.Services))
 	}
 }
+
+func TestClassifyResourceType(t *testing.T) {
+	t.Parallel()
+
+	for resourceType, want := range map[string]string{
+		"aws_instance":       "EC2",
+		"aws_wafv2_web_acl":  "WAF",
+		"aws_route53_record": "Route 53",
+	} {
+		got, ok := classifyResourceType(resourceType)
+		if !ok || got != want {
+			t.Fatalf("classifyResourceType(%q) = %q, %v; want %q", resourceType, got, ok, want)
+		}
+	}
+}
+
+func TestParseInfracostJSONSplitsLoadBalancerTypes(t *testing.T) {
+	t.Parallel()
+
+	raw := []byte(`{"projects":[{"breakdown":{"resources":[
+  {"resourceType":"aws_lb","hourlyCost":"0.0225","monthlyCost":"16.43","costComponents":[{"name":"Application load balancer","hourlyCost":"0.0225","monthlyCost":"16.43"}]},
+  {"resourceType":"aws_lb","hourlyCost":"0.0225","monthlyCost":"16.43","costComponents":[{"name":"Network load balancer","hourlyCost":"0.0225","monthlyCost":"16.43"}]}
+]}}]}`)
+
+	report, err := ParseInfracostJSON(raw)
+	if err != nil {
+		t.Fatalf("ParseInfracostJSON failed: %v", err)
+	}
+	got := map[string]float64{}
+	for _, s := range report.Services {
+		got[s.Service] = s.Monthly
+	}
+	if len(got) != 2 || got["ALB"] != 16.43 || got["NLB"] != 16.43 {
+		t.Fatalf("expected ALB and NLB split, got %v", got)
+	}
+}
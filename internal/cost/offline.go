@@ -0,0 +1,322 @@
+package cost
+
+import (
+	_ "embed"
+	"fmt"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/PydaVi/brainctl/internal/config"
+)
+
+//go:embed prices/aws.yaml
+var embeddedPrices []byte
+
+// PriceTable é a tabela de preços embarcada no binário para a estimativa offline.
+type PriceTable struct {
+	Version                    string                        `yaml:"version"`
+	BaseRegion                 string                        `yaml:"base_region"`
+	RegionMultipliers          map[string]float64            `yaml:"region_multipliers"`
+	EC2Hourly                  map[string]map[string]float64 `yaml:"ec2_hourly"`
+	SpotDiscount               float64                       `yaml:"spot_discount"`
+	RootVolumeGB               map[string]float64            `yaml:"root_volume_gb"`
+	EBSGBMonth                 map[string]float64            `yaml:"ebs_gb_month"`
+	EBSSnapshotGBMonth         float64                       `yaml:"ebs_snapshot_gb_month"`
+	RDSHourly                  map[string]float64            `yaml:"rds_hourly"`
+	RDSStorageGBMonth          map[string]float64            `yaml:"rds_storage_gb_month"`
+	ALBHourly                  float64                       `yaml:"alb_hourly"`
+	ALBLCUHourly               float64                       `yaml:"alb_lcu_hourly"`
+	NLBHourly                  float64                       `yaml:"nlb_hourly"`
+	NLBLCUHourly               float64                       `yaml:"nlb_lcu_hourly"`
+	WAFWebACLMonth             float64                       `yaml:"waf_web_acl_month"`
+	WAFRuleMonth               float64                       `yaml:"waf_rule_month"`
+	NATGatewayHourly           float64                       `yaml:"nat_gateway_hourly"`
+	NATGatewayDataGB           float64                       `yaml:"nat_gateway_data_gb"`
+	EIPHourly                  float64                       `yaml:"eip_hourly"`
+	VPCEndpointHourly          float64                       `yaml:"vpc_endpoint_hourly"`
+	CloudWatchLogsIngestionGB  float64                       `yaml:"cloudwatch_logs_ingestion_gb"`
+	DefaultLogIngestionGBMonth float64                       `yaml:"default_log_ingestion_gb_month"`
+}
+
+var (
+	priceTableOnce sync.Once
+	priceTable     *PriceTable
+	priceTableErr  error
+)
+
+// LoadPriceTable decodifica a tabela embarcada (uma única vez por processo).
+func LoadPriceTable() (*PriceTable, error) {
+	priceTableOnce.Do(func() {
+		var t PriceTable
+		if err := yaml.Unmarshal(embeddedPrices, &t); err != nil {
+			priceTableErr = fmt.Errorf("parse embedded price table: %w", err)
+			return
+		}
+		priceTable = &t
+	})
+	return priceTable, priceTableErr
+}
+
+// Chaves de SO da tabela de preços: ec2-app segue ec2.os/db.os; k8s-workers usa Ubuntu.
+const (
+	osWindows = "windows"
+	osLinux   = "linux"
+)
+
+// priceOS traduz ec2.os/db.os do contrato para a chave de preço (licença Windows ou não).
+func priceOS(osName string) string {
+	if config.IsWindowsOS(osName) {
+		return osWindows
+	}
+	return osLinux
+}
+
+// offlineEstimate acumula custo por serviço já ajustado pelo multiplicador da região.
+type offlineEstimate struct {
+	prices     *PriceTable
+	multiplier float64
+	services   map[string]*ServiceCost
+}
+
+func (e *offlineEstimate) addHourly(service string, hourly float64) {
+	e.add(service, hourly*e.multiplier, hourly*e.multiplier*monthlyHours)
+}
+
+func (e *offlineEstimate) addMonthly(service string, monthly float64) {
+	e.add(service, monthly*e.multiplier/monthlyHours, monthly*e.multiplier)
+}
+
+func (e *offlineEstimate) add(service string, hourly, monthly float64) {
+	entry, ok := e.services[service]
+	if !ok {
+		entry = &ServiceCost{Service: service}
+		e.services[service] = entry
+	}
+	entry.Hourly += hourly
+	entry.Monthly += monthly
+}
+
+func (e *offlineEstimate) instances(osName, instanceType string, count int) error {
+	if count <= 0 {
+		return nil
+	}
+	price, err := e.prices.ec2Price(osName, instanceType)
+	if err != nil {
+		return err
+	}
+	e.addHourly("EC2", price*float64(count))
+	e.addMonthly("EBS", e.prices.RootVolumeGB[osName]*e.prices.EBSGBMonth["gp2"]*float64(count))
+	return nil
+}
+
+// spotInstances precifica a parcela Spot com spot_discount sobre o on-demand.
+func (e *offlineEstimate) spotInstances(osName, instanceType string, count int) error {
+	if count <= 0 {
+		return nil
+	}
+	price, err := e.prices.ec2Price(osName, instanceType)
+	if err != nil {
+		return err
+	}
+	e.addHourly("EC2 Spot", price*(1-e.prices.SpotDiscount)*float64(count))
+	e.addMonthly("EBS", e.prices.RootVolumeGB[osName]*e.prices.EBSGBMonth["gp2"]*float64(count))
+	return nil
+}
+
+func (t *PriceTable) ec2Price(osName, instanceType string) (float64, error) {
+	price, ok := t.EC2Hourly[osName][instanceType]
+	if !ok {
+		return 0, fmt.Errorf("instance type %q (%s) not in offline price table %s", instanceType, osName, t.Version)
+	}
+	return price, nil
+}
+
+// SpotSavings estima a economia mensal da capacidade Spot (app_scaling.instances no
+// ec2-app, node pools com spot no k8s-workers) frente à mesma capacidade on-demand.
+// A capacidade vem de desired_capacity e é precificada pelo primeiro tipo da lista
+// (a alocação Spot real pode variar).
+func SpotSavings(cfg *config.AppConfig) (float64, error) {
+	type spotShare struct {
+		os, instanceType string
+		count            int
+	}
+	var shares []spotShare
+	switch cfg.Workload.Type {
+	case "k8s-workers":
+		for _, p := range cfg.K8s.EffectiveNodePools() {
+			if p.Spot && p.DesiredCapacity > 0 {
+				shares = append(shares, spotShare{osLinux, p.InstanceTypes[0], p.DesiredCapacity})
+			}
+		}
+	default:
+		if cfg.AppScaling.Enabled {
+			if _, spot := cfg.AppScaling.Instances.Split(cfg.AppScaling.DesiredCapacity); spot > 0 {
+				shares = append(shares, spotShare{priceOS(cfg.EC2.OS), cfg.AppScaling.Instances.Types[0], spot})
+			}
+		}
+	}
+	if len(shares) == 0 {
+		return 0, nil
+	}
+
+	prices, err := LoadPriceTable()
+	if err != nil {
+		return 0, err
+	}
+	multiplier, ok := prices.RegionMultipliers[cfg.App.Region]
+	if !ok {
+		return 0, fmt.Errorf("region %q not in offline price table %s", cfg.App.Region, prices.Version)
+	}
+	var hourly float64
+	for _, sh := range shares {
+		price, err := prices.ec2Price(sh.os, sh.instanceType)
+		if err != nil {
+			return 0, err
+		}
+		hourly += price * prices.SpotDiscount * float64(sh.count)
+	}
+	return round2(hourly * multiplier * monthlyHours), nil
+}
+
+// EstimateOffline estima o custo base a partir do contrato validado, sem Infracost.
+// Cobre apenas recursos com preço fixo por hora/GB; uso variável (tráfego, LCUs extras)
+// fica de fora ou usa os defaults da tabela.
+func EstimateOffline(cfg *config.AppConfig) (*Report, error) {
+	prices, err := LoadPriceTable()
+	if err != nil {
+		return nil, err
+	}
+	multiplier, ok := prices.RegionMultipliers[cfg.App.Region]
+	if !ok {
+		return nil, fmt.Errorf("region %q not in offline price table %s", cfg.App.Region, prices.Version)
+	}
+
+	e := &offlineEstimate{prices: prices, multiplier: multiplier, services: map[string]*ServiceCost{}}
+	switch cfg.Workload.Type {
+	case "k8s-workers":
+		err = estimateK8sWorkers(e, cfg)
+	default:
+		err = estimateEC2App(e, cfg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	report := finalizeReport(e.services)
+	report.PriceTable = prices.Version
+	if report.SpotSavingsMonthly, err = SpotSavings(cfg); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func estimateEC2App(e *offlineEstimate, cfg *config.AppConfig) error {
+	appOS := priceOS(cfg.EC2.OS)
+	if cfg.AppScaling.Enabled && cfg.AppScaling.Instances.Mixed() {
+		// Mixed instances: on-demand segue a prioridade da lista (primeiro tipo).
+		onDemand, spot := cfg.AppScaling.Instances.Split(cfg.AppScaling.DesiredCapacity)
+		primary := cfg.AppScaling.Instances.Types[0]
+		if err := e.instances(appOS, primary, onDemand); err != nil {
+			return err
+		}
+		if err := e.spotInstances(appOS, primary, spot); err != nil {
+			return err
+		}
+	} else {
+		appCount := cfg.LB.InstanceCount
+		if cfg.AppScaling.Enabled {
+			appCount = cfg.AppScaling.DesiredCapacity
+		}
+		if err := e.instances(appOS, cfg.EC2.InstanceType, appCount); err != nil {
+			return err
+		}
+	}
+
+	if cfg.DB.Enabled {
+		if cfg.DB.Mode == "rds" {
+			rds := cfg.DB.RDS
+			price, ok := e.prices.RDSHourly[rds.InstanceClass]
+			if !ok {
+				return fmt.Errorf("rds instance class %q not in offline price table %s", rds.InstanceClass, e.prices.Version)
+			}
+			storage := e.prices.RDSStorageGBMonth[rds.StorageType] * float64(rds.AllocatedStorage)
+			if rds.MultiAZ {
+				price *= 2
+				storage *= 2
+			}
+			e.addHourly("RDS", price)
+			e.addMonthly("RDS", storage)
+		} else if err := e.instances(priceOS(cfg.DB.OS), cfg.DB.InstanceType, 1); err != nil {
+			return err
+		}
+	}
+
+	if cfg.LB.Enabled {
+		e.addHourly("ALB", e.prices.ALBHourly+e.prices.ALBLCUHourly)
+		// ACL existente (web_acl_arn) é compartilhado e cobrado fora desta stack.
+		if waf := cfg.LB.WAF; waf.Enabled && waf.WebACLARN == "" {
+			rules := len(waf.ManagedRules)
+			if waf.RateLimit > 0 {
+				rules++
+			}
+			e.addMonthly("WAF", e.prices.WAFWebACLMonth+float64(rules)*e.prices.WAFRuleMonth)
+		}
+	}
+
+	if cfg.Recovery.Enabled && cfg.Cost.Usage.SnapshotStorageGB > 0 {
+		e.addMonthly("EBS Snapshots", cfg.Cost.Usage.SnapshotStorageGB*e.prices.EBSSnapshotGBMonth)
+	}
+
+	observability := cfg.Observability.Enabled != nil && *cfg.Observability.Enabled
+	if observability {
+		ingestion := e.prices.DefaultLogIngestionGBMonth
+		if cfg.Cost.Usage.LogIngestionGBMonth > 0 {
+			ingestion = cfg.Cost.Usage.LogIngestionGBMonth
+		}
+		e.addMonthly("CloudWatch Logs", ingestion*e.prices.CloudWatchLogsIngestionGB)
+		if cfg.Observability.EnableSSMEndpoints != nil && *cfg.Observability.EnableSSMEndpoints {
+			// ssm, ssmmessages, ec2messages, logs, monitoring e sts (10-observability-bootstrap.tf)
+			e.addHourly("VPC Endpoint", e.prices.VPCEndpointHourly*6*float64(endpointAZs(cfg)))
+		}
+	}
+	return nil
+}
+
+func estimateK8sWorkers(e *offlineEstimate, cfg *config.AppConfig) error {
+	controlPlanes := max(cfg.K8s.ControlPlaneCount, 1)
+	if err := e.instances(osLinux, cfg.K8s.ControlPlaneInstanceType, controlPlanes); err != nil {
+		return err
+	}
+	if controlPlanes > 1 {
+		// NLB interno na frente dos API servers (HA)
+		e.addHourly("NLB", e.prices.NLBHourly+e.prices.NLBLCUHourly)
+	}
+	// Pools Spot usam spot_discount; a capacidade vem de desired_capacity e do primeiro tipo.
+	for _, p := range cfg.K8s.EffectiveNodePools() {
+		add := e.instances
+		if p.Spot {
+			add = e.spotInstances
+		}
+		if err := add(osLinux, p.InstanceTypes[0], p.DesiredCapacity); err != nil {
+			return err
+		}
+	}
+	if cfg.K8s.EnableNatGateway != nil && *cfg.K8s.EnableNatGateway {
+		e.addHourly("NAT Gateway", e.prices.NATGatewayHourly)
+		e.addHourly("EIP", e.prices.EIPHourly)
+		e.addMonthly("NAT Gateway", cfg.Cost.Usage.NATDataProcessedGBMonth*e.prices.NATGatewayDataGB)
+	}
+	if cfg.K8s.EnableSSM != nil && *cfg.K8s.EnableSSM && cfg.K8s.EnableSSMVPCEndpoints != nil && *cfg.K8s.EnableSSMVPCEndpoints {
+		// ssm, ssmmessages e ec2messages
+		e.addHourly("VPC Endpoint", e.prices.VPCEndpointHourly*3*float64(endpointAZs(cfg)))
+	}
+	return nil
+}
+
+// endpointAZs espelha local.endpoint_subnet_ids dos módulos: subnet_ids ou a subnet principal.
+func endpointAZs(cfg *config.AppConfig) int {
+	if n := len(cfg.Infrastructure.SubnetIDs); n > 0 {
+		return n
+	}
+	return 1
+}
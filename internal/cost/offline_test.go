@@ -0,0 +1,235 @@
+package cost
+
+import (
+	"testing"
+
+	"github.com/PydaVi/brainctl/internal/config"
+)
+
+func offlineTestConfig(workload string) *config.AppConfig {
+	cfg := &config.AppConfig{}
+	cfg.Workload.Type = workload
+	cfg.Workload.Version = "v1"
+	cfg.App.Name = "billing"
+	cfg.App.Environment = "dev"
+	cfg.App.Region = "us-east-1"
+	cfg.Terraform.Backend.Bucket = "state"
+	cfg.Infrastructure.VpcID = "vpc-1"
+	cfg.Infrastructure.VpcCIDR = "10.0.0.0/16"
+	cfg.Infrastructure.SubnetID = "subnet-a"
+	cfg.Infrastructure.SubnetIDs = []string{"subnet-a", "subnet-b"}
+	cfg.EC2.InstanceType = "t3.medium"
+	cfg.Observability.LogKMSKeyID = "alias/logs"
+	return cfg
+}
+
+func TestEstimateOfflineEC2App(t *testing.T) {
+	cfg := offlineTestConfig("ec2-app")
+	cfg.LB.Enabled = true
+	cfg.LB.SubnetIDs = []string{"subnet-a", "subnet-b"}
+	cfg.AppScaling.Enabled = true
+	cfg.AppScaling.DesiredCapacity = 3
+	cfg.AppScaling.MinSize = 2
+	cfg.AppScaling.MaxSize = 4
+	cfg.DB.Enabled = true
+	cfg.DB.Mode = "rds"
+	cfg.DB.RDS.Password = "secret"
+	cfg.DB.RDS.MultiAZ = true
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	report, err := EstimateOffline(cfg)
+	if err != nil {
+		t.Fatalf("EstimateOffline: %v", err)
+	}
+	if report.PriceTable == "" {
+		t.Fatal("expected price table version in report")
+	}
+
+	got := map[string]ServiceCost{}
+	for _, s := range report.Services {
+		got[s.Service] = s
+	}
+	// 3 x t3.medium Windows (ASG desired) a 0.06/h
+	if ec2 := got["EC2"]; ec2.Hourly != 0.18 {
+		t.Fatalf("unexpected EC2 hourly: %+v", ec2)
+	}
+	// db.t3.micro multi-AZ + 20 GB gp3 x2
+	if rds := got["RDS"]; rds.Monthly != round2(0.034*monthlyHours+2*20*0.115) {
+		t.Fatalf("unexpected RDS monthly: %+v", rds)
+	}
+	for _, svc := range []string{"ALB", "EBS", "CloudWatch Logs"} {
+		if got[svc].Monthly <= 0 {
+			t.Fatalf("expected %s cost, got %+v", svc, report.Services)
+		}
+	}
+	if report.TotalMonthly <= got["EC2"].Monthly {
+		t.Fatalf("unexpected total: %.2f", report.TotalMonthly)
+	}
+}
+
+func TestEstimateOfflineEC2AppSpot(t *testing.T) {
+	cfg := offlineTestConfig("ec2-app")
+	cfg.LB.Enabled = true
+	cfg.LB.SubnetIDs = []string{"subnet-a", "subnet-b"}
+	cfg.AppScaling.Enabled = true
+	cfg.AppScaling.MinSize = 2
+	cfg.AppScaling.MaxSize = 4
+	cfg.AppScaling.DesiredCapacity = 4
+	pct := 0
+	cfg.AppScaling.Instances = config.ASGInstancesConfig{Types: []string{"t3.medium", "t3.large"}, OnDemandBaseCapacity: 1, OnDemandPercentage: &pct}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	report, err := EstimateOffline(cfg)
+	if err != nil {
+		t.Fatalf("EstimateOffline: %v", err)
+	}
+	got := map[string]ServiceCost{}
+	for _, s := range report.Services {
+		got[s.Service] = s
+	}
+	// base on-demand de 1 x t3.medium Windows; as outras 3 são Spot com 65% de desconto
+	if got["EC2"].Hourly != 0.06 || got["EC2 Spot"].Hourly != round4(3*0.06*0.35) {
+		t.Fatalf("unexpected EC2 split: %+v", report.Services)
+	}
+	if report.SpotSavingsMonthly != round2(3*0.06*0.65*monthlyHours) {
+		t.Fatalf("unexpected spot savings: %.2f", report.SpotSavingsMonthly)
+	}
+}
+
+func TestEstimateOfflineEC2AppFollowsOS(t *testing.T) {
+	cfg := offlineTestConfig("ec2-app")
+	cfg.EC2.OS = config.OSAmazonLinux2023
+	cfg.DB.Enabled = true
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	report, err := EstimateOffline(cfg)
+	if err != nil {
+		t.Fatalf("EstimateOffline: %v", err)
+	}
+	for _, s := range report.Services {
+		// app + db t3.medium Linux a 0.0416/h
+		if s.Service == "EC2" && s.Hourly != 2*0.0416 {
+			t.Fatalf("expected Linux price for app and db, got %+v", s)
+		}
+	}
+}
+
+func TestEstimateOfflineEC2AppWAF(t *testing.T) {
+	cfg := offlineTestConfig("ec2-app")
+	cfg.LB.Enabled = true
+	cfg.LB.SubnetIDs = []string{"subnet-a", "subnet-b"}
+	cfg.LB.WAF.Enabled = true
+	cfg.LB.WAF.RateLimit = 2000
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	report, err := EstimateOffline(cfg)
+	if err != nil {
+		t.Fatalf("EstimateOffline: %v", err)
+	}
+	found := false
+	for _, s := range report.Services {
+		// web ACL (5) + 3 rule groups gerenciados + rate limit (1 cada)
+		if s.Service == "WAF" {
+			found = true
+			if s.Monthly != 9 {
+				t.Fatalf("expected WAF at 9/month, got %+v", s)
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("expected WAF line in %+v", report.Services)
+	}
+}
+
+func TestEstimateOfflineK8sWorkersRegionMultiplier(t *testing.T) {
+	cfg := offlineTestConfig("k8s-workers")
+	nat := true
+	cfg.K8s.EnableNatGateway = &nat
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	base, err := EstimateOffline(cfg)
+	if err != nil {
+		t.Fatalf("EstimateOffline: %v", err)
+	}
+	if len(base.Services) != 5 {
+		t.Fatalf("expected EC2, EBS, NAT, EIP and VPC endpoints, got %+v", base.Services)
+	}
+
+	cfg.App.Region = "sa-east-1"
+	sa, err := EstimateOffline(cfg)
+	if err != nil {
+		t.Fatalf("EstimateOffline sa-east-1: %v", err)
+	}
+	if sa.TotalMonthly <= base.TotalMonthly {
+		t.Fatalf("expected sa-east-1 to be more expensive: %.2f <= %.2f", sa.TotalMonthly, base.TotalMonthly)
+	}
+}
+
+func TestEstimateOfflineUnknownInstanceType(t *testing.T) {
+	cfg := offlineTestConfig("ec2-app")
+	cfg.EC2.InstanceType = "x9.mega"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if _, err := EstimateOffline(cfg); err == nil {
+		t.Fatal("expected error for instance type missing from the price table")
+	}
+}
+
+func TestEstimateOfflineK8sWorkersHAControlPlane(t *testing.T) {
+	cfg := offlineTestConfig("k8s-workers")
+	cfg.K8s.ControlPlaneCount = 3
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	report, err := EstimateOffline(cfg)
+	if err != nil {
+		t.Fatalf("EstimateOffline: %v", err)
+	}
+	got := map[string]ServiceCost{}
+	for _, s := range report.Services {
+		got[s.Service] = s
+	}
+	// 3 control-planes + 2 workers t3.medium Linux, mais o NLB interno do API server
+	if got["EC2"].Hourly != round4(5*0.0416) || got["NLB"].Hourly != round4(0.0225+0.006) {
+		t.Fatalf("unexpected HA cost: %+v", report.Services)
+	}
+}
+
+func TestEstimateOfflineK8sSpotNodePool(t *testing.T) {
+	cfg := offlineTestConfig("k8s-workers")
+	cfg.K8s.NodePools = []config.K8sNodePoolConfig{
+		{Name: "general", InstanceTypes: []string{"t3.medium"}, MinSize: 2},
+		{Name: "batch", InstanceTypes: []string{"m5.large", "m6i.large"}, MinSize: 0, MaxSize: 6, DesiredCapacity: 2, Spot: true},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	report, err := EstimateOffline(cfg)
+	if err != nil {
+		t.Fatalf("EstimateOffline: %v", err)
+	}
+	got := map[string]ServiceCost{}
+	for _, s := range report.Services {
+		got[s.Service] = s
+	}
+	// control-plane + pool general on-demand; pool batch (2 x m5.large) Spot
+	if got["EC2"].Hourly != round4(3*0.0416) || got["EC2 Spot"].Hourly != round4(2*0.096*0.35) {
+		t.Fatalf("unexpected node pool cost: %+v", report.Services)
+	}
+	if report.SpotSavingsMonthly != round2(2*0.096*0.65*monthlyHours) {
+		t.Fatalf("unexpected spot savings: %.2f", report.SpotSavingsMonthly)
+	}
+}
@@ -0,0 +1,132 @@
+package cost
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RollupGroupBy lista as dimensões aceitas por `brainctl cost --all --group-by`.
+var RollupGroupBy = []string{"app", "environment", "workload"}
+
+// ValidateGroupBy rejeita dimensões desconhecidas antes de estimar as stacks.
+func ValidateGroupBy(groupBy string) error {
+	if !slices.Contains(RollupGroupBy, groupBy) {
+		return fmt.Errorf("invalid --group-by %q (allowed: %s)", groupBy, strings.Join(RollupGroupBy, ", "))
+	}
+	return nil
+}
+
+// StackCost é o custo de uma stack, com as dimensões usadas no agrupamento.
+// Error registra stacks que não puderam ser estimadas sem abortar o rollup.
+type StackCost struct {
+	StackDir    string  `json:"stack_dir"`
+	App         string  `json:"app"`
+	Environment string  `json:"environment"`
+	Workload    string  `json:"workload"`
+	Report      *Report `json:"report,omitempty"`
+	Error       string  `json:"error,omitempty"`
+}
+
+// RollupGroup soma os reports das stacks que compartilham a mesma chave.
+type RollupGroup struct {
+	Key    string   `json:"key"`
+	Stacks []string `json:"stacks"`
+	Report
+}
+
+// Rollup é a visão consolidada de várias stacks.
+type Rollup struct {
+	GroupBy      string        `json:"group_by"`
+	Groups       []RollupGroup `json:"groups"`
+	Stacks       []StackCost   `json:"stacks"`
+	TotalHourly  float64       `json:"total_hourly"`
+	TotalMonthly float64       `json:"total_monthly"`
+}
+
+func (s StackCost) groupKey(groupBy string) (string, error) {
+	switch groupBy {
+	case "app":
+		return s.App, nil
+	case "environment":
+		return s.Environment, nil
+	case "workload":
+		return s.Workload, nil
+	default:
+		return "", ValidateGroupBy(groupBy)
+	}
+}
+
+// BuildRollup agrega os reports por serviço dentro de cada grupo. Stacks com erro
+// aparecem em Stacks, mas não entram nos totais.
+func BuildRollup(stacks []StackCost, groupBy string) (*Rollup, error) {
+	type acc struct {
+		stacks   []string
+		services map[string]*ServiceCost
+	}
+	groups := map[string]*acc{}
+
+	for _, s := range stacks {
+		key, err := s.groupKey(groupBy)
+		if err != nil {
+			return nil, err
+		}
+		if s.Report == nil {
+			continue
+		}
+		g, ok := groups[key]
+		if !ok {
+			g = &acc{services: map[string]*ServiceCost{}}
+			groups[key] = g
+		}
+		g.stacks = append(g.stacks, s.StackDir)
+		for _, svc := range s.Report.Services {
+			entry, ok := g.services[svc.Service]
+			if !ok {
+				entry = &ServiceCost{Service: svc.Service}
+				g.services[svc.Service] = entry
+			}
+			entry.Hourly += svc.Hourly
+			entry.Monthly += svc.Monthly
+		}
+	}
+
+	out := &Rollup{GroupBy: groupBy, Groups: []RollupGroup{}, Stacks: stacks}
+	var totalH, totalM float64
+	for key, g := range groups {
+		report := finalizeReport(g.services)
+		out.Groups = append(out.Groups, RollupGroup{Key: key, Stacks: g.stacks, Report: *report})
+		totalH += report.TotalHourly
+		totalM += report.TotalMonthly
+	}
+	sort.Slice(out.Groups, func(i, j int) bool { return out.Groups[i].Key < out.Groups[j].Key })
+	out.TotalHourly = round4(totalH)
+	out.TotalMonthly = round2(totalM)
+	return out, nil
+}
+
+// WriteCSV exporta uma linha por grupo/serviço, mais uma linha TOTAL por grupo.
+func (r *Rollup) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	money := func(v float64, prec int) string { return strconv.FormatFloat(v, 'f', prec, 64) }
+
+	if err := cw.Write([]string{r.GroupBy, "service", "hourly_usd", "monthly_usd"}); err != nil {
+		return err
+	}
+	for _, g := range r.Groups {
+		for _, s := range g.Services {
+			if err := cw.Write([]string{g.Key, s.Service, money(s.Hourly, 4), money(s.Monthly, 2)}); err != nil {
+				return err
+			}
+		}
+		if err := cw.Write([]string{g.Key, "TOTAL", money(g.TotalHourly, 4), money(g.TotalMonthly, 2)}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
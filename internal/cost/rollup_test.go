@@ -0,0 +1,56 @@
+package cost
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestBuildRollupByEnvironment(t *testing.T) {
+	stacks := []StackCost{
+		{StackDir: "stacks/billing/dev", App: "billing", Environment: "dev", Workload: "ec2-app", Report: &Report{
+			Services: []ServiceCost{{Service: "EC2", Hourly: 0.06, Monthly: 43.8}},
+		}},
+		{StackDir: "stacks/lab/dev", App: "lab", Environment: "dev", Workload: "k8s-workers", Report: &Report{
+			Services: []ServiceCost{{Service: "EC2", Hourly: 0.04, Monthly: 29.2}, {Service: "NAT Gateway", Hourly: 0.045, Monthly: 32.85}},
+		}},
+		{StackDir: "stacks/billing/prod", App: "billing", Environment: "prod", Workload: "ec2-app", Report: &Report{
+			Services: []ServiceCost{{Service: "EC2", Hourly: 0.12, Monthly: 87.6}},
+		}},
+		{StackDir: "stacks/broken/prod", App: "broken", Environment: "prod", Workload: "ec2-app", Error: "infracost failed"},
+	}
+
+	r, err := BuildRollup(stacks, "environment")
+	if err != nil {
+		t.Fatalf("BuildRollup: %v", err)
+	}
+	if len(r.Groups) != 2 || r.Groups[0].Key != "dev" || r.Groups[1].Key != "prod" {
+		t.Fatalf("unexpected groups: %+v", r.Groups)
+	}
+	dev := r.Groups[0]
+	if len(dev.Stacks) != 2 || dev.TotalMonthly != 105.85 {
+		t.Fatalf("unexpected dev group: %+v", dev)
+	}
+	if dev.Services[0].Service != "EC2" || dev.Services[0].Monthly != 73 {
+		t.Fatalf("expected EC2 to be summed across stacks: %+v", dev.Services)
+	}
+	if r.TotalMonthly != 193.45 {
+		t.Fatalf("unexpected rollup total: %.2f", r.TotalMonthly)
+	}
+
+	var buf bytes.Buffer
+	if err := r.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != "environment,service,hourly_usd,monthly_usd" {
+		t.Fatalf("unexpected csv header: %q", lines[0])
+	}
+	if !strings.Contains(buf.String(), "dev,TOTAL,0.1450,105.85") {
+		t.Fatalf("missing dev total row:\n%s", buf.String())
+	}
+
+	if _, err := BuildRollup(stacks, "team"); err == nil {
+		t.Fatal("expected error for invalid group-by")
+	}
+}
@@ -0,0 +1,112 @@
+package cost
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/PydaVi/brainctl/internal/config"
+)
+
+// UsageFileName é o usage file do Infracost gerado no workspace a partir do contrato.
+const UsageFileName = "brainctl.infracost-usage.yml"
+
+type infracostUsageFile struct {
+	Version       string                    `yaml:"version"`
+	ResourceUsage map[string]map[string]any `yaml:"resource_usage"`
+}
+
+// BuildUsageFile traduz cost.usage e a capacidade do ASG para o formato de usage file
+// do Infracost. Os endereços usam [*] porque os recursos dos módulos têm count.
+func BuildUsageFile(cfg *config.AppConfig) ([]byte, error) {
+	usage := cfg.Cost.Usage
+	resources := map[string]map[string]any{}
+
+	switch cfg.Workload.Type {
+	case "k8s-workers":
+		if usage.NATDataProcessedGBMonth > 0 {
+			resources["aws_nat_gateway.cluster[*]"] = map[string]any{
+				"monthly_data_processed_gb": usage.NATDataProcessedGBMonth,
+			}
+		}
+	default:
+		// O Infracost não deduz o SO da AMI; sem operating_system, Windows sai com preço Linux.
+		appOS := priceOS(cfg.EC2.OS)
+		if cfg.AppScaling.Enabled {
+			resources["aws_autoscaling_group.app[*]"] = map[string]any{
+				"instances":        cfg.AppScaling.DesiredCapacity,
+				"operating_system": appOS,
+			}
+		} else {
+			resources["aws_instance.app[*]"] = map[string]any{"operating_system": appOS}
+		}
+		if cfg.DB.Enabled && cfg.DB.Mode == "ec2" {
+			resources["aws_instance.db[*]"] = map[string]any{"operating_system": priceOS(cfg.DB.OS)}
+		}
+		if usage.LogIngestionGBMonth > 0 {
+			resources["aws_cloudwatch_log_group.brainctl[*]"] = map[string]any{
+				"monthly_data_ingested_gb": usage.LogIngestionGBMonth,
+			}
+		}
+	}
+
+	return yaml.Marshal(infracostUsageFile{Version: "0.1", ResourceUsage: resources})
+}
+
+// WriteUsageFile grava o usage file no workspace e retorna o caminho para --usage-file.
+func WriteUsageFile(workspaceDir string, cfg *config.AppConfig) (string, error) {
+	b, err := BuildUsageFile(cfg)
+	if err != nil {
+		return "", fmt.Errorf("build infracost usage file: %w", err)
+	}
+	path := filepath.Join(workspaceDir, UsageFileName)
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return "", fmt.Errorf("write infracost usage file: %w", err)
+	}
+	return path, nil
+}
+
+// ApplySnapshotUsage completa a linha "EBS Snapshots" com cost.usage.snapshot_storage_gb.
+// O Infracost não precifica políticas DLM, então a linha só existe (zerada) quando o
+// breakdown contém aws_dlm_lifecycle_policy; o valor vem da tabela de preços embarcada.
+func ApplySnapshotUsage(r *Report, cfg *config.AppConfig) error {
+	gb := cfg.Cost.Usage.SnapshotStorageGB
+	if gb <= 0 {
+		return nil
+	}
+	idx := -1
+	for i, s := range r.Services {
+		if s.Service == "EBS Snapshots" {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return nil
+	}
+
+	prices, err := LoadPriceTable()
+	if err != nil {
+		return err
+	}
+	multiplier, ok := prices.RegionMultipliers[cfg.App.Region]
+	if !ok {
+		return fmt.Errorf("region %q not in offline price table %s", cfg.App.Region, prices.Version)
+	}
+	monthly := gb * prices.EBSSnapshotGBMonth * multiplier
+
+	agg := map[string]*ServiceCost{}
+	for i := range r.Services {
+		s := r.Services[i]
+		agg[s.Service] = &s
+	}
+	agg["EBS Snapshots"].Monthly += monthly
+	agg["EBS Snapshots"].Hourly += monthly / monthlyHours
+
+	out := finalizeReport(agg)
+	out.PriceTable = r.PriceTable
+	*r = *out
+	return nil
+}
@@ -0,0 +1,79 @@
+package cost
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildUsageFileEC2App(t *testing.T) {
+	cfg := offlineTestConfig("ec2-app")
+	cfg.LB.Enabled = true
+	cfg.LB.SubnetIDs = []string{"subnet-a", "subnet-b"}
+	cfg.AppScaling.Enabled = true
+	cfg.AppScaling.MinSize = 2
+	cfg.AppScaling.MaxSize = 4
+	cfg.AppScaling.DesiredCapacity = 3
+	cfg.Cost.Usage.LogIngestionGBMonth = 12
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	b, err := BuildUsageFile(cfg)
+	if err != nil {
+		t.Fatalf("BuildUsageFile: %v", err)
+	}
+	out := string(b)
+	for _, want := range []string{
+		"version: \"0.1\"",
+		"aws_autoscaling_group.app[*]:",
+		"instances: 3",
+		"operating_system: windows",
+		"aws_cloudwatch_log_group.brainctl[*]:",
+		"monthly_data_ingested_gb: 12",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("usage file missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestApplySnapshotUsage(t *testing.T) {
+	cfg := offlineTestConfig("ec2-app")
+	cfg.Cost.Usage.SnapshotStorageGB = 100
+
+	raw := []byte(`{"projects":[{"breakdown":{"resources":[
+  {"resourceType":"aws_instance","hourlyCost":"0.06","monthlyCost":"43.80"},
+  {"resourceType":"aws_dlm_lifecycle_policy","hourlyCost":"","monthlyCost":""},
+  {"resourceType":"aws_cloudwatch_metric_alarm","hourlyCost":"","monthlyCost":"0.20"}
+]}}]}`)
+	report, err := ParseInfracostJSON(raw)
+	if err != nil {
+		t.Fatalf("ParseInfracostJSON: %v", err)
+	}
+	if err := ApplySnapshotUsage(report, cfg); err != nil {
+		t.Fatalf("ApplySnapshotUsage: %v", err)
+	}
+
+	got := map[string]float64{}
+	for _, s := range report.Services {
+		got[s.Service] = s.Monthly
+	}
+	if got["EBS Snapshots"] != 5 {
+		t.Fatalf("expected 100 GB x 0.05 = 5.00 for snapshots, got %+v", report.Services)
+	}
+	if got["CloudWatch Alarms"] != 0.2 {
+		t.Fatalf("expected alarms to be classified, got %+v", report.Services)
+	}
+	if report.TotalMonthly != 49 {
+		t.Fatalf("unexpected total: %.2f", report.TotalMonthly)
+	}
+
+	// sem política DLM no breakdown não há snapshot para cobrar
+	plain, _ := ParseInfracostJSON([]byte(`{"projects":[{"breakdown":{"resources":[{"resourceType":"aws_instance","monthlyCost":"43.80"}]}}]}`))
+	if err := ApplySnapshotUsage(plain, cfg); err != nil {
+		t.Fatalf("ApplySnapshotUsage: %v", err)
+	}
+	if len(plain.Services) != 1 {
+		t.Fatalf("expected snapshot usage to be ignored without DLM, got %+v", plain.Services)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"text/template"
@@ -46,6 +47,7 @@ inputs = {
   vpc_id      = {{ quote .Infrastructure.VpcID }}
   vpc_cidr    = {{ quote .Infrastructure.VpcCIDR }}
   subnet_id   = {{ quote .Infrastructure.SubnetID }}
+  default_tags = {{ hclStringMap .DefaultTags }}
 
   {{- if eq .WorkloadType "ec2-app" }}
   endpoint_subnet_ids = {{ hclStringList .Infrastructure.SubnetIDs }}
@@ -56,11 +58,14 @@ inputs = {
   app_user_data_mode  = {{ quote .EC2.UserDataMode }}
   app_user_data_base64 = {{ quote .AppUserDataB64 }}
   imds_v2_required    = {{ .EC2.IMDSv2Required }}
+  app_os              = {{ quote .EC2.OS }}
   allowed_rdp_cidr    = {{ quote .AllowedRDPCIDR }}
+  allowed_ssh_cidr    = {{ quote .AllowedSSHCIDR }}
 
   enable_db           = {{ .DB.Enabled }}
   db_mode             = {{ quote .DB.Mode }}
   db_instance_type    = {{ quote .DB.InstanceType }}
+  db_os               = {{ quote .DBOS }}
   db_ami_id           = {{ quote .DB.AMI }}
   db_user_data_mode   = {{ quote .DB.UserDataMode }}
   db_user_data_base64 = {{ quote .DBUserDataB64 }}
@@ -84,6 +89,42 @@ inputs = {
   lb_listener_port = {{ .LB.ListenerPort }}
   app_port         = {{ .LB.TargetPort }}
   lb_allowed_cidr  = {{ quote .AllowedLBCIDR }}
+  lb_target_protocol = {{ quote .LB.TargetProtocol }}
+
+  lb_certificate_arn               = {{ quote .LB.TLS.CertificateARN }}
+  lb_tls_domain                    = {{ quote .LB.TLS.Domain }}
+  lb_tls_subject_alternative_names = {{ hclStringList .LB.TLS.SubjectAlternativeNames }}
+  lb_tls_hosted_zone_id            = {{ quote .LB.TLS.HostedZoneID }}
+  lb_ssl_policy                    = {{ quote .LB.TLS.SSLPolicy }}
+  lb_redirect_http                 = {{ boolValue .LB.TLS.RedirectHTTP }}
+
+  lb_health_check_path                = {{ quote .LB.HealthCheck.Path }}
+  lb_health_check_port                = {{ .LB.HealthCheck.Port }}
+  lb_health_check_matcher             = {{ quote .LB.HealthCheck.Matcher }}
+  lb_health_check_interval            = {{ .LB.HealthCheck.Interval }}
+  lb_health_check_timeout             = {{ .LB.HealthCheck.Timeout }}
+  lb_health_check_healthy_threshold   = {{ .LB.HealthCheck.HealthyThreshold }}
+  lb_health_check_unhealthy_threshold = {{ .LB.HealthCheck.UnhealthyThreshold }}
+  lb_deregistration_delay             = {{ .LBDeregistrationDelay }}
+  lb_stickiness_enabled               = {{ .LB.Stickiness.Enabled }}
+  lb_stickiness_type                  = {{ quote .LB.Stickiness.Type }}
+  lb_stickiness_cookie_name           = {{ quote .LB.Stickiness.CookieName }}
+  lb_stickiness_duration              = {{ .LB.Stickiness.DurationSeconds }}
+  lb_routes                           = {{ hclLBRoutes .LB.Routes }}
+
+  lb_waf_enabled                = {{ .LB.WAF.Enabled }}
+  lb_waf_web_acl_arn            = {{ quote .LB.WAF.WebACLARN }}
+  lb_waf_managed_rules          = {{ hclStringList .LBWAFManagedRules }}
+  lb_waf_rate_limit             = {{ .LB.WAF.RateLimit }}
+  lb_waf_blocked_requests_alarm = {{ .LB.WAF.BlockedRequestsAlarm }}
+
+  enable_dns           = {{ .DNS.Enabled }}
+  dns_zone_id          = {{ quote .DNS.ZoneID }}
+  dns_zone_name        = {{ quote .DNS.ZoneName }}
+  dns_private_zone     = {{ .DNS.Private }}
+  dns_record_name      = {{ quote .DNS.RecordName }}
+  dns_ttl              = {{ .DNS.TTL }}
+  dns_instance_records = {{ .DNS.InstanceRecords }}
 
   enable_app_asg          = {{ .AppScaling.Enabled }}
   app_asg_subnet_ids      = {{ hclStringList .AppScaling.SubnetIDs }}
@@ -91,6 +132,29 @@ inputs = {
   app_asg_max_size        = {{ .AppScaling.MaxSize }}
   app_asg_desired_capacity = {{ .AppScaling.DesiredCapacity }}
   app_asg_cpu_target      = {{ .AppScaling.CPUTarget }}
+  app_asg_request_count_target = {{ .AppScaling.RequestsPerTarget }}
+  app_asg_schedules            = {{ hclSchedules .AppScaling.Schedules }}
+  app_asg_step_policies        = {{ hclSteps .AppScaling.StepPolicies }}
+
+  app_asg_instance_types           = {{ hclStringList .AppScaling.Instances.Types }}
+  app_asg_on_demand_base_capacity  = {{ .AppScaling.Instances.OnDemandBaseCapacity }}
+  app_asg_on_demand_percentage     = {{ if .AppScaling.Instances.OnDemandPercentage }}{{ .AppScaling.Instances.OnDemandPercentage }}{{ else }}100{{ end }}
+  app_asg_spot_allocation_strategy = {{ quote (or .AppScaling.Instances.SpotAllocationStrategy "price-capacity-optimized") }}
+  app_asg_capacity_rebalance       = {{ .AppScaling.Instances.CapacityRebalance }}
+
+  app_asg_warm_pool_enabled               = {{ .AppScaling.WarmPool.Enabled }}
+  app_asg_warm_pool_min_size              = {{ .AppScaling.WarmPool.MinSize }}
+  app_asg_warm_pool_max_prepared_capacity = {{ if .AppScaling.WarmPool.MaxPreparedCapacity }}{{ .AppScaling.WarmPool.MaxPreparedCapacity }}{{ else }}null{{ end }}
+  app_asg_warm_pool_state                 = {{ quote (or .AppScaling.WarmPool.PoolState "Stopped") }}
+  app_asg_warm_pool_reuse_on_scale_in     = {{ .AppScaling.WarmPool.ReuseOnScaleIn }}
+
+  app_asg_rollout_enabled                = {{ .AppScaling.Rollout.Enabled }}
+  app_asg_rollout_min_healthy_percentage = {{ intValue .AppScaling.Rollout.MinHealthyPercentage }}
+  app_asg_rollout_instance_warmup        = {{ intValue .AppScaling.Rollout.InstanceWarmup }}
+  app_asg_rollout_checkpoint_percentages = {{ hclIntList .AppScaling.Rollout.CheckpointPercentages }}
+  app_asg_rollout_checkpoint_delay       = {{ intValue .AppScaling.Rollout.CheckpointDelay }}
+  app_asg_rollout_auto_rollback          = {{ boolValue .AppScaling.Rollout.AutoRollback }}
+  app_asg_rollout_alarm_names            = {{ hclStringList .AppScaling.Rollout.RollbackAlarms }}
 
   enable_observability   = {{ .ObservabilityEnabled }}
   enable_ssm_endpoints   = {{ .ObservabilityEnableSSMEndpoints }}
@@ -120,8 +184,9 @@ inputs = {
   control_plane_ami         = {{ quote .K8s.ControlPlaneAMI }}
   worker_ami                = {{ quote .K8s.WorkerAMI }}
   control_plane_type        = {{ quote .K8s.ControlPlaneInstanceType }}
-  worker_type               = {{ quote .K8s.WorkerInstanceType }}
-  worker_count              = {{ .K8s.WorkerCount }}
+  control_plane_count       = {{ .K8s.ControlPlaneCount }}
+  join_token_ttl_minutes    = {{ .K8s.JoinTokenTTLMinutes }}
+  node_pools                = {{ hclNodePools .K8s.EffectiveNodePools }}
   kubernetes_version        = {{ quote .K8s.KubernetesVersion }}
   pod_cidr                  = {{ quote .K8s.PodCIDR }}
   key_name                  = {{ quote .K8s.KeyName }}
@@ -166,12 +231,11 @@ func Generate(wsDir string, cfg *config.AppConfig, contractPath string) error {
 	if err != nil {
 		return fmt.Errorf("resolve contract path: %w", err)
 	}
-	repoRoot, err := findRepoRoot(filepath.Dir(absContract))
+	repoRoot, err := FindRepoRoot(filepath.Dir(absContract))
 	if err != nil {
 		return fmt.Errorf("resolve repo root: %w", err)
 	}
-	moduleRoot := filepath.Join(repoRoot, "modules")
-	moduleTarget := filepath.Join(moduleRoot, cfg.Workload.Type)
+	moduleTarget := filepath.Join(repoRoot, "modules", cfg.Workload.Type)
 	moduleLink := filepath.Join(wsDir, "modules", cfg.Workload.Type)
 
 	if err := os.MkdirAll(filepath.Dir(moduleLink), 0o755); err != nil {
@@ -181,7 +245,11 @@ func Generate(wsDir string, cfg *config.AppConfig, contractPath string) error {
 		return fmt.Errorf("prepare module link: %w", err)
 	}
 
-	rendered, err := renderTerragruntHCL(cfg, absContract, filepath.ToSlash(filepath.Join("modules", cfg.Workload.Type)))
+	contractRef, err := filepath.Rel(repoRoot, absContract)
+	if err != nil {
+		contractRef = absContract
+	}
+	rendered, err := renderTerragruntHCL(cfg, absContract, filepath.ToSlash(contractRef), filepath.ToSlash(filepath.Join("modules", cfg.Workload.Type)))
 	if err != nil {
 		return fmt.Errorf("render terragrunt.hcl: %w", err)
 	}
@@ -194,14 +262,21 @@ func Generate(wsDir string, cfg *config.AppConfig, contractPath string) error {
 
 // renderTerragruntHCL aplica o template com os valores já normalizados.
 // A separação mantém a lógica de template isolada do filesystem.
-func renderTerragruntHCL(cfg *config.AppConfig, contractPath string, moduleSource string) ([]byte, error) {
+func renderTerragruntHCL(cfg *config.AppConfig, contractPath, contractRef, moduleSource string) ([]byte, error) {
 	tpl, err := template.New("terragrunt.hcl").Funcs(template.FuncMap{
 		"quote":         strconv.Quote,
 		"hclStringList": hclStringList,
 		"hclIngressRules": func(rules []config.IngressRule) string {
 			return hclIngressRules(rules)
 		},
-		"boolValue": boolValue,
+		"boolValue":    boolValue,
+		"intValue":     intValue,
+		"hclStringMap": hclStringMap,
+		"hclLBRoutes":  hclLBRoutes,
+		"hclIntList":   hclIntList,
+		"hclSchedules": hclScalingSchedules,
+		"hclSteps":     hclStepPolicies,
+		"hclNodePools": HCLNodePools,
 	}).Parse(terragruntHCLTemplate)
 	if err != nil {
 		return nil, fmt.Errorf("parse template: %w", err)
@@ -214,9 +289,9 @@ func renderTerragruntHCL(cfg *config.AppConfig, contractPath string, moduleSourc
 		BackendKey:                         backendKey(cfg),
 		BackendRegion:                      cfg.Terraform.Backend.Region,
 		ModuleSource:                       moduleSource,
+		DefaultTags:                        ResourceTags(cfg, contractRef),
 		WorkloadType:                       cfg.Workload.Type,
-		AppUserDataB64:                     base64.StdEncoding.EncodeToString([]byte(cfg.EC2.UserData)),
-		DBUserDataB64:                      base64.StdEncoding.EncodeToString([]byte(cfg.DB.UserData)),
+		DBOS:                               effectiveOS(cfg.DB.OS, cfg.EC2.OS),
 		ObservabilityEnabled:               derefBool(cfg.Observability.Enabled),
 		ObservabilityEnableSSMEndpoints:    derefBool(cfg.Observability.EnableSSMEndpoints),
 		ObservabilityEnableSSMPrivateDNS:   derefBool(cfg.Observability.EnableSSMPrivateDNS),
@@ -225,10 +300,35 @@ func renderTerragruntHCL(cfg *config.AppConfig, contractPath string, moduleSourc
 		RecoveryEnableRunbooks:             derefBool(cfg.Recovery.EnableRunbooks),
 		RecoveryDrillRegisterToTargetGroup: derefBool(cfg.Recovery.Drill.RegisterToTargetGroup),
 		AllowedRDPCIDR:                     effectiveCIDR(cfg.EC2.AllowedRDPCIDR, cfg.Infrastructure.VpcCIDR),
+		AllowedSSHCIDR:                     effectiveCIDR(cfg.EC2.AllowedSSHCIDR, cfg.Infrastructure.VpcCIDR),
 		AllowedLBCIDR:                      effectiveCIDR(cfg.LB.AllowedCIDR, cfg.Infrastructure.VpcCIDR),
+		LBDeregistrationDelay:              300,
+		LBWAFManagedRules:                  cfg.LB.WAF.ManagedRules,
 		AllowedEgressCIDRs:                 effectiveEgressCIDRs(cfg.Infrastructure.AllowedEgressCIDRs, cfg.Infrastructure.VpcCIDR),
 	}
 
+	// O módulo compõe o user data com o bootstrap padrão; aqui enviamos só o corpo,
+	// sem wrapper <powershell> ou shebang, para não duplicá-los no modo merge.
+	if cfg.Workload.Type == "ec2-app" {
+		appUserData, err := config.NormalizeUserData(cfg.EC2.OS, cfg.EC2.UserData)
+		if err != nil {
+			return nil, fmt.Errorf("ec2.user_data: %w", err)
+		}
+		dbUserData, err := config.NormalizeUserData(data.DBOS, cfg.DB.UserData)
+		if err != nil {
+			return nil, fmt.Errorf("db.user_data: %w", err)
+		}
+		data.AppUserDataB64 = base64.StdEncoding.EncodeToString([]byte(appUserData))
+		data.DBUserDataB64 = base64.StdEncoding.EncodeToString([]byte(dbUserData))
+	}
+	// Sem ARN nem regras explícitas (ex.: WAF desligado) mantemos o default do módulo.
+	if len(data.LBWAFManagedRules) == 0 {
+		data.LBWAFManagedRules = config.WAFManagedRules
+	}
+	if cfg.LB.DeregistrationDelay != nil {
+		data.LBDeregistrationDelay = *cfg.LB.DeregistrationDelay
+	}
+
 	var buf bytes.Buffer
 	if err := tpl.Execute(&buf, data); err != nil {
 		return nil, fmt.Errorf("execute template: %w", err)
@@ -245,9 +345,11 @@ type terragruntTemplateData struct {
 	BackendKey                         string
 	BackendRegion                      string
 	ModuleSource                       string
+	DefaultTags                        map[string]string
 	WorkloadType                       string
 	AppUserDataB64                     string
 	DBUserDataB64                      string
+	DBOS                               string
 	ObservabilityEnabled               bool
 	ObservabilityEnableSSMEndpoints    bool
 	ObservabilityEnableSSMPrivateDNS   bool
@@ -256,10 +358,27 @@ type terragruntTemplateData struct {
 	RecoveryEnableRunbooks             bool
 	RecoveryDrillRegisterToTargetGroup bool
 	AllowedRDPCIDR                     string
+	AllowedSSHCIDR                     string
 	AllowedLBCIDR                      string
+	LBDeregistrationDelay              int
+	LBWAFManagedRules                  []string
 	AllowedEgressCIDRs                 []string
 }
 
+// ResourceTags combina as tags do contrato com as tags gerenciadas pelo brainctl.
+// contractRef é o caminho do contrato relativo ao repo, para não variar entre máquinas
+// (um caminho absoluto geraria drift de tags a cada operador diferente).
+func ResourceTags(cfg *config.AppConfig, contractRef string) map[string]string {
+	tags := make(map[string]string, len(cfg.Tags)+3)
+	for k, v := range cfg.Tags {
+		tags[k] = v
+	}
+	tags[config.ManagedTagPrefix+"contract"] = contractRef
+	tags[config.ManagedTagPrefix+"blueprint"] = cfg.Workload.Type
+	tags[config.ManagedTagPrefix+"version"] = cfg.Workload.Version
+	return tags
+}
+
 // effectiveCIDR aplica fallback explícito para manter o template simples.
 func effectiveCIDR(primary, fallback string) string {
 	if strings.TrimSpace(primary) != "" {
@@ -268,6 +387,18 @@ func effectiveCIDR(primary, fallback string) string {
 	return fallback
 }
 
+// effectiveOS aplica o mesmo fallback do Validate (db.os segue ec2.os) para bancos
+// desabilitados ou em RDS, onde db.os fica vazio mas o módulo exige um valor válido.
+func effectiveOS(primary, fallback string) string {
+	if primary != "" {
+		return primary
+	}
+	if fallback != "" {
+		return fallback
+	}
+	return config.OSWindows2022
+}
+
 // effectiveEgressCIDRs garante que exista ao menos um CIDR de egress permitido.
 func effectiveEgressCIDRs(cidrs []string, fallback string) []string {
 	if len(cidrs) > 0 {
@@ -290,9 +421,9 @@ func backendKey(cfg *config.AppConfig) string {
 	return fmt.Sprintf("%s/%s", prefix, base)
 }
 
-// findRepoRoot sobe a árvore a partir do contrato até encontrar o diretório do repo.
+// FindRepoRoot sobe a árvore a partir do contrato até encontrar o diretório do repo.
 // Isso evita depender do cwd e facilita execução da CLI fora do root.
-func findRepoRoot(startDir string) (string, error) {
+func FindRepoRoot(startDir string) (string, error) {
 	dir := startDir
 	for {
 		if dir == "" || dir == "." {
@@ -310,6 +441,65 @@ func findRepoRoot(startDir string) (string, error) {
 	return "", fmt.Errorf("repo root not found starting at %s", startDir)
 }
 
+// ModuleLinkStatus descreve o estado do módulo linkado dentro do workspace.
+// State assume um dos valores: missing, ok, copied, stale ou broken.
+type ModuleLinkStatus struct {
+	LinkPath string
+	Target   string
+	State    string
+}
+
+// InspectModuleLink verifica, sem alterar nada, se o link do módulo no workspace
+// aponta para o módulo real do repo. Usado pelo doctor para diagnosticar
+// workspaces antigos antes que o erro apareça no meio do terragrunt init.
+func InspectModuleLink(cfg *config.AppConfig, contractPath string) (ModuleLinkStatus, error) {
+	absContract, err := filepath.Abs(contractPath)
+	if err != nil {
+		return ModuleLinkStatus{}, fmt.Errorf("resolve contract path: %w", err)
+	}
+	repoRoot, err := FindRepoRoot(filepath.Dir(absContract))
+	if err != nil {
+		return ModuleLinkStatus{}, fmt.Errorf("resolve repo root: %w", err)
+	}
+
+	status := ModuleLinkStatus{
+		LinkPath: filepath.Join(WorkspaceDir(cfg), "modules", cfg.Workload.Type),
+		Target:   filepath.Join(repoRoot, "modules", cfg.Workload.Type),
+	}
+	if !fileExists(status.Target) {
+		return status, fmt.Errorf("module %s not found in repo", status.Target)
+	}
+
+	info, err := os.Lstat(status.LinkPath)
+	if os.IsNotExist(err) {
+		status.State = "missing"
+		return status, nil
+	}
+	if err != nil {
+		return status, fmt.Errorf("stat module link %s: %w", status.LinkPath, err)
+	}
+
+	if info.Mode()&os.ModeSymlink == 0 {
+		// Fallback de cópia: funciona, mas pode ficar desatualizado em relação ao repo.
+		status.State = "copied"
+		return status, nil
+	}
+
+	current, err := os.Readlink(status.LinkPath)
+	if err != nil {
+		return status, fmt.Errorf("readlink %s: %w", status.LinkPath, err)
+	}
+	switch {
+	case current != status.Target:
+		status.State = "stale"
+	case !fileExists(current):
+		status.State = "broken"
+	default:
+		status.State = "ok"
+	}
+	return status, nil
+}
+
 // ensureModuleLink aponta o módulo do workspace para o módulo real do repo.
 // Preferimos symlink por ser rápido; se indisponível, copiamos o diretório.
 func ensureModuleLink(linkPath, target string) error {
@@ -393,6 +583,24 @@ func hclStringList(values []string) string {
 	return fmt.Sprintf("[%s]", strings.Join(quoted, ", "))
 }
 
+// hclStringMap formata mapas de string como literal HCL, com chaves ordenadas
+// para que o terragrunt.hcl gerado seja estável entre execuções.
+func hclStringMap(values map[string]string) string {
+	if len(values) == 0 {
+		return "{}"
+	}
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	entries := make([]string, 0, len(keys))
+	for _, k := range keys {
+		entries = append(entries, fmt.Sprintf("%s = %s", strconv.Quote(k), strconv.Quote(values[k])))
+	}
+	return fmt.Sprintf("{ %s }", strings.Join(entries, ", "))
+}
+
 // hclIngressRules serializa ingress rules como literal HCL.
 // Optamos por strings para evitar reimplementação de HCL AST.
 func hclIngressRules(rules []config.IngressRule) string {
@@ -414,6 +622,123 @@ func hclIngressRules(rules []config.IngressRule) string {
 	return fmt.Sprintf("[%s]", strings.Join(entries, ", "))
 }
 
+// hclIntList renderiza []int como lista HCL.
+func hclIntList(values []int) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		parts = append(parts, strconv.Itoa(v))
+	}
+	return fmt.Sprintf("[%s]", strings.Join(parts, ", "))
+}
+
+// hclLBRoutes renderiza lb.routes como list(object) com todos os atributos,
+// já que o módulo não usa optional() na variável lb_routes.
+func hclLBRoutes(routes []config.LBRouteConfig) string {
+	if len(routes) == 0 {
+		return "[]"
+	}
+	entries := make([]string, 0, len(routes))
+	for _, r := range routes {
+		entries = append(entries, fmt.Sprintf(
+			`{ name = %s, path_patterns = %s, target_port = %d, priority = %d, health_check_path = %s }`,
+			strconv.Quote(r.Name),
+			hclStringList(r.PathPatterns),
+			r.TargetPort,
+			r.Priority,
+			strconv.Quote(r.HealthCheckPath),
+		))
+	}
+	return fmt.Sprintf("[%s]", strings.Join(entries, ", "))
+}
+
+// hclScalingSchedules renderiza app_scaling.schedules; tamanhos omitidos viram -1,
+// que o aws_autoscaling_schedule interpreta como "não alterar".
+func hclScalingSchedules(schedules []config.ScalingSchedule) string {
+	if len(schedules) == 0 {
+		return "[]"
+	}
+	entries := make([]string, 0, len(schedules))
+	for _, sc := range schedules {
+		entries = append(entries, fmt.Sprintf(
+			`{ name = %s, recurrence = %s, time_zone = %s, min_size = %d, max_size = %d, desired_capacity = %d }`,
+			strconv.Quote(sc.Name),
+			strconv.Quote(sc.Recurrence),
+			strconv.Quote(sc.TimeZone),
+			intOrUnset(sc.MinSize),
+			intOrUnset(sc.MaxSize),
+			intOrUnset(sc.DesiredCapacity),
+		))
+	}
+	return fmt.Sprintf("[%s]", strings.Join(entries, ", "))
+}
+
+// hclStepPolicies renderiza app_scaling.step_policies; bounds nil viram null.
+func hclStepPolicies(policies []config.StepScalingPolicy) string {
+	if len(policies) == 0 {
+		return "[]"
+	}
+	entries := make([]string, 0, len(policies))
+	for _, p := range policies {
+		steps := make([]string, 0, len(p.Steps))
+		for _, st := range p.Steps {
+			steps = append(steps, fmt.Sprintf("{ lower_bound = %s, upper_bound = %s, adjustment = %d }", hclFloatOrNull(st.LowerBound), hclFloatOrNull(st.UpperBound), st.Adjustment))
+		}
+		entries = append(entries, fmt.Sprintf(
+			`{ name = %s, metric_name = %s, namespace = %s, statistic = %s, dimensions = %s, comparison_operator = %s, threshold = %s, period = %d, evaluation_periods = %d, adjustment_type = %s, steps = [%s] }`,
+			strconv.Quote(p.Name),
+			strconv.Quote(p.MetricName),
+			strconv.Quote(p.Namespace),
+			strconv.Quote(p.Statistic),
+			hclStringMap(p.Dimensions),
+			strconv.Quote(p.ComparisonOperator),
+			strconv.FormatFloat(p.Threshold, 'f', -1, 64),
+			p.Period,
+			p.EvaluationPeriods,
+			strconv.Quote(p.AdjustmentType),
+			strings.Join(steps, ", "),
+		))
+	}
+	return fmt.Sprintf("[%s]", strings.Join(entries, ", "))
+}
+
+// HCLNodePools renderiza os node pools efetivos (worker_count vira o pool "default").
+// Exportado para o gerador legado do k8s-workers usar a mesma serialização.
+func HCLNodePools(pools []config.K8sNodePoolConfig) string {
+	entries := make([]string, 0, len(pools))
+	for _, p := range pools {
+		taints := make([]string, 0, len(p.Taints))
+		for _, t := range p.Taints {
+			taints = append(taints, fmt.Sprintf("{ key = %s, value = %s, effect = %s }", strconv.Quote(t.Key), strconv.Quote(t.Value), strconv.Quote(t.Effect)))
+		}
+		entries = append(entries, fmt.Sprintf(
+			`{ name = %s, instance_types = %s, min_size = %d, max_size = %d, desired_capacity = %d, labels = %s, taints = [%s], spot = %t }`,
+			strconv.Quote(p.Name),
+			hclStringList(p.InstanceTypes),
+			p.MinSize,
+			p.MaxSize,
+			p.DesiredCapacity,
+			hclStringMap(p.Labels),
+			strings.Join(taints, ", "),
+			p.Spot,
+		))
+	}
+	return fmt.Sprintf("[%s]", strings.Join(entries, ", "))
+}
+
+func intOrUnset(v *int) int {
+	if v == nil {
+		return -1
+	}
+	return *v
+}
+
+func hclFloatOrNull(v *float64) string {
+	if v == nil {
+		return "null"
+	}
+	return strconv.FormatFloat(*v, 'f', -1, 64)
+}
+
 // boolValue converte ponteiros em bools estáveis no template.
 // Escolhemos default false para evitar nil deref mesmo com config inválida.
 func boolValue(v *bool) bool {
@@ -423,6 +748,15 @@ func boolValue(v *bool) bool {
 	return *v
 }
 
+// intValue faz o mesmo para inteiros opcionais; os defaults ficam no Validate
+// e, com o recurso desligado, o módulo ignora o valor.
+func intValue(v *int) int {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
 // derefBool aplica o mesmo comportamento para campos obrigatórios já validados.
 func derefBool(v *bool) bool {
 	if v == nil {
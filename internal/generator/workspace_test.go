@@ -0,0 +1,179 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PydaVi/brainctl/internal/config"
+)
+
+func TestInspectModuleLink(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.WriteFile(filepath.Join(repo, "go.mod"), []byte("module x\n"), 0o644); err != nil {
+		t.Fatalf("write go.mod: %v", err)
+	}
+	target := filepath.Join(repo, "modules", "ec2-app")
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		t.Fatalf("mkdir module: %v", err)
+	}
+	stackDir := filepath.Join(repo, "stacks", "ec2-app", "dev")
+	if err := os.MkdirAll(stackDir, 0o755); err != nil {
+		t.Fatalf("mkdir stack: %v", err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	if err := os.Chdir(repo); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+
+	cfg := &config.AppConfig{}
+	cfg.Workload.Type = "ec2-app"
+	cfg.App.Name = "app"
+	cfg.App.Environment = "dev"
+	contract := filepath.Join(stackDir, "app.yaml")
+
+	st, err := InspectModuleLink(cfg, contract)
+	if err != nil || st.State != "missing" {
+		t.Fatalf("expected missing link, got %+v (%v)", st, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(st.LinkPath), 0o755); err != nil {
+		t.Fatalf("mkdir workspace: %v", err)
+	}
+	if err := os.Symlink(filepath.Join(repo, "modules", "old"), st.LinkPath); err != nil {
+		t.Skipf("symlink unsupported: %v", err)
+	}
+	if st, _ = InspectModuleLink(cfg, contract); st.State != "stale" {
+		t.Fatalf("expected stale link, got %q", st.State)
+	}
+
+	if err := os.Remove(st.LinkPath); err != nil {
+		t.Fatalf("remove link: %v", err)
+	}
+	if err := ensureModuleLink(st.LinkPath, st.Target); err != nil {
+		t.Fatalf("ensureModuleLink: %v", err)
+	}
+	if st, _ = InspectModuleLink(cfg, contract); st.State != "ok" {
+		t.Fatalf("expected ok link, got %q", st.State)
+	}
+}
+
+func TestRenderTerragruntHCLDefaultTags(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.AppConfig{}
+	cfg.Workload.Type = "k8s-workers"
+	cfg.Workload.Version = "v1"
+	cfg.App.Name = "app"
+	cfg.App.Environment = "dev"
+	cfg.Tags = map[string]string{"owner": "time-plataforma", "cost_center": "cc-42"}
+
+	out, err := renderTerragruntHCL(cfg, "/repo/stacks/k8s/dev/app.yaml", "stacks/k8s/dev/app.yaml", "modules/k8s-workers")
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	want := `default_tags = { "brainctl:blueprint" = "k8s-workers", "brainctl:contract" = "stacks/k8s/dev/app.yaml", "brainctl:version" = "v1", "cost_center" = "cc-42", "owner" = "time-plataforma" }`
+	if !strings.Contains(string(out), want) {
+		t.Fatalf("expected %s in:\n%s", want, out)
+	}
+}
+
+func TestRenderTerragruntHCLLBTargets(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.AppConfig{}
+	cfg.Workload.Type = "ec2-app"
+	cfg.App.Name = "app"
+	cfg.App.Environment = "dev"
+	cfg.LB.Enabled = true
+	cfg.LB.HealthCheck.Path = "/healthz"
+	cfg.LB.Routes = []config.LBRouteConfig{{Name: "api", PathPatterns: []string{"/api/*"}, TargetPort: 8081, Priority: 10}}
+
+	out, err := renderTerragruntHCL(cfg, "/repo/stacks/app/dev/app.yaml", "stacks/app/dev/app.yaml", "modules/ec2-app")
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	for _, want := range []string{
+		`lb_health_check_path                = "/healthz"`,
+		`lb_deregistration_delay             = 300`,
+		`lb_routes                           = [{ name = "api", path_patterns = ["/api/*"], target_port = 8081, priority = 10, health_check_path = "" }]`,
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Fatalf("expected %s in:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderTerragruntHCLScalingPolicies(t *testing.T) {
+	t.Parallel()
+
+	zero, lower := 0, 0.0
+	cfg := &config.AppConfig{}
+	cfg.Workload.Type = "ec2-app"
+	cfg.App.Name = "app"
+	cfg.App.Environment = "dev"
+	cfg.AppScaling.Enabled = true
+	cfg.AppScaling.Schedules = []config.ScalingSchedule{{Name: "night-off", Recurrence: "0 20 * * 1-5", TimeZone: "UTC", MinSize: &zero, DesiredCapacity: &zero}}
+	cfg.AppScaling.StepPolicies = []config.StepScalingPolicy{{
+		Name: "cpu-burst", MetricName: "CPUUtilization", Namespace: "AWS/EC2", Statistic: "Average",
+		ComparisonOperator: "GreaterThanOrEqualToThreshold", Threshold: 80, Period: 60, EvaluationPeriods: 2,
+		AdjustmentType: "ChangeInCapacity", Steps: []config.ScalingStep{{LowerBound: &lower, Adjustment: 2}},
+	}}
+	cfg.AppScaling.Rollout.Enabled = true
+	cfg.AppScaling.Rollout.InstanceWarmup = &zero
+
+	out, err := renderTerragruntHCL(cfg, "/repo/stacks/app/dev/app.yaml", "stacks/app/dev/app.yaml", "modules/ec2-app")
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	for _, want := range []string{
+		`app_asg_schedules            = [{ name = "night-off", recurrence = "0 20 * * 1-5", time_zone = "UTC", min_size = 0, max_size = -1, desired_capacity = 0 }]`,
+		`steps = [{ lower_bound = 0, upper_bound = null, adjustment = 2 }]`,
+		`dimensions = {}`,
+		`app_asg_warm_pool_max_prepared_capacity = null`,
+		`app_asg_rollout_instance_warmup        = 0`,
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Fatalf("expected %s in:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderTerragruntHCLNodePools(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.AppConfig{}
+	cfg.Workload.Type = "k8s-workers"
+	cfg.App.Name = "lab"
+	cfg.App.Environment = "dev"
+	cfg.K8s.WorkerInstanceType = "t3.medium"
+	cfg.K8s.WorkerCount = 2
+
+	out, err := renderTerragruntHCL(cfg, "/repo/stacks/k8s/dev/app.yaml", "stacks/k8s/dev/app.yaml", "modules/k8s-workers")
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	want := `node_pools                = [{ name = "default", instance_types = ["t3.medium"], min_size = 2, max_size = 2, desired_capacity = 2, labels = {}, taints = [], spot = false }]`
+	if !strings.Contains(string(out), want) {
+		t.Fatalf("expected worker_count shorthand pool in:\n%s", out)
+	}
+
+	cfg.K8s.NodePools = []config.K8sNodePoolConfig{{
+		Name: "batch", InstanceTypes: []string{"m5.large"}, MinSize: 0, MaxSize: 4, DesiredCapacity: 1, Spot: true,
+		Labels: map[string]string{"workload": "batch"}, Taints: []config.K8sTaint{{Key: "dedicated", Value: "batch", Effect: "NoSchedule"}},
+	}}
+	out, err = renderTerragruntHCL(cfg, "/repo/stacks/k8s/dev/app.yaml", "stacks/k8s/dev/app.yaml", "modules/k8s-workers")
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	want = `labels = { "workload" = "batch" }, taints = [{ key = "dedicated", value = "batch", effect = "NoSchedule" }], spot = true }]`
+	if !strings.Contains(string(out), want) {
+		t.Fatalf("expected %s in:\n%s", want, out)
+	}
+}
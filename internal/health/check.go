@@ -0,0 +1,217 @@
+package health
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/PydaVi/brainctl/internal/outputs"
+)
+
+// Instance associa o estado EC2 ao papel da instância no blueprint.
+type Instance struct {
+	Role  string `json:"role" yaml:"role"`
+	ID    string `json:"id" yaml:"id"`
+	State string `json:"state" yaml:"state"`
+}
+
+// Issue registra uma consulta que falhou sem abortar as demais.
+type Issue struct {
+	Check   string `json:"check" yaml:"check"`
+	Message string `json:"message" yaml:"message"`
+}
+
+// Report é a visão ao vivo do ambiente, anexada ao status.
+type Report struct {
+	Healthy      bool           `json:"healthy" yaml:"healthy"`
+	Instances    []Instance     `json:"instances,omitempty" yaml:"instances,omitempty"`
+	ASG          *ASGState      `json:"asg,omitempty" yaml:"asg,omitempty"`
+	Targets      []TargetHealth `json:"targets,omitempty" yaml:"targets,omitempty"`
+	Alarms       []AlarmState   `json:"alarms,omitempty" yaml:"alarms,omitempty"`
+	LastSnapshot *Snapshot      `json:"last_snapshot,omitempty" yaml:"last_snapshot,omitempty"`
+	Issues       []Issue        `json:"issues,omitempty" yaml:"issues,omitempty"`
+}
+
+// Target identifica o ambiente cujos recursos serão consultados.
+type Target struct {
+	App         string
+	Environment string
+	Outputs     map[string]any
+}
+
+// Check consulta a AWS a partir dos outputs do terraform. Cada etapa é independente:
+// uma falha vira Issue e o relatório segue com o que foi possível coletar.
+func Check(c Client, t Target) *Report {
+	r := &Report{}
+	v := t.Outputs
+
+	if asgName := outputs.AsString(v["app_asg_name"], ""); asgName != "" {
+		asg, err := c.DescribeAutoScalingGroup(asgName)
+		if err != nil {
+			r.addIssue("asg", err)
+		} else {
+			r.ASG = &asg
+		}
+	}
+
+	roles, ids := instanceRoles(v, r.ASG)
+	if len(ids) > 0 {
+		states, err := c.DescribeInstances(ids)
+		if err != nil {
+			r.addIssue("instances", err)
+		} else {
+			for _, s := range states {
+				r.Instances = append(r.Instances, Instance{Role: roles[s.ID], ID: s.ID, State: s.State})
+			}
+		}
+	}
+
+	if tg := outputs.AsString(v["alb_target_group_arn"], ""); tg != "" {
+		targets, err := c.DescribeTargetHealth(tg)
+		if err != nil {
+			r.addIssue("targets", err)
+		} else {
+			r.Targets = targets
+		}
+	}
+
+	if names := outputs.AsStringSlice(v["observability_alarm_names"]); len(names) > 0 {
+		alarms, err := c.DescribeAlarms(names)
+		if err != nil {
+			r.addIssue("alarms", err)
+		} else {
+			r.Alarms = alarms
+		}
+	}
+
+	if outputs.AsString(v["recovery_enabled"], "") == "true" {
+		snap, err := c.LatestSnapshot(map[string]string{
+			"ManagedBy":   "brainctl",
+			"App":         t.App,
+			"Environment": t.Environment,
+		})
+		if err != nil {
+			r.addIssue("snapshots", err)
+		} else {
+			r.LastSnapshot = snap
+		}
+	}
+
+	r.Healthy = r.evaluate()
+	return r
+}
+
+// instanceRoles mapeia os ids de instância dos outputs para o papel no blueprint.
+// Com ASG, as instâncias vêm do próprio grupo (os outputs não acompanham o scale-out).
+func instanceRoles(v map[string]any, asg *ASGState) (map[string]string, []string) {
+	roles := map[string]string{}
+	var ids []string
+	add := func(role string, list ...string) {
+		for _, id := range list {
+			if id == "" {
+				continue
+			}
+			if _, seen := roles[id]; seen {
+				continue
+			}
+			roles[id] = role
+			ids = append(ids, id)
+		}
+	}
+
+	if asg != nil {
+		add("app", asg.Instances...)
+	} else {
+		add("app", outputs.AsStringSlice(v["app_instance_ids"])...)
+		add("app", outputs.AsString(v["instance_id"], ""))
+	}
+	add("db", outputs.AsString(v["db_instance_id"], ""))
+	if ids := outputs.AsStringSlice(v["control_plane_instance_ids"]); len(ids) > 0 {
+		add("control-plane", ids...)
+	} else {
+		add("control-plane", outputs.AsString(v["control_plane_instance_id"], ""))
+	}
+	add("worker", outputs.AsStringSlice(v["worker_instance_ids"])...)
+	return roles, ids
+}
+
+func (r *Report) addIssue(check string, err error) {
+	r.Issues = append(r.Issues, Issue{Check: check, Message: err.Error()})
+}
+
+func (r *Report) evaluate() bool {
+	if len(r.Issues) > 0 {
+		return false
+	}
+	for _, i := range r.Instances {
+		if i.State != "running" {
+			return false
+		}
+	}
+	if r.ASG != nil && r.ASG.InService < r.ASG.Desired {
+		return false
+	}
+	for _, t := range r.Targets {
+		if t.State != "healthy" {
+			return false
+		}
+	}
+	for _, a := range r.Alarms {
+		if a.State == "ALARM" {
+			return false
+		}
+	}
+	return true
+}
+
+// Render imprime a seção LIVE do status em texto.
+func (r *Report) Render(w io.Writer, now time.Time) {
+	verdict := "healthy"
+	if !r.Healthy {
+		verdict = "DEGRADED"
+	}
+	fmt.Fprintf(w, "  LIVE (%s)\n", verdict)
+
+	for _, i := range r.Instances {
+		fmt.Fprintf(w, "    %-13s: %s %s\n", i.Role, i.ID, i.State)
+	}
+	if r.ASG != nil {
+		fmt.Fprintf(w, "    asg          : %s in_service=%d desired=%d (min=%d max=%d)\n", r.ASG.Name, r.ASG.InService, r.ASG.Desired, r.ASG.MinSize, r.ASG.MaxSize)
+	}
+	if len(r.Targets) > 0 {
+		healthy := 0
+		for _, t := range r.Targets {
+			if t.State == "healthy" {
+				healthy++
+			}
+		}
+		fmt.Fprintf(w, "    targets      : %d/%d healthy\n", healthy, len(r.Targets))
+		for _, t := range r.Targets {
+			if t.State != "healthy" {
+				fmt.Fprintf(w, "      - %s %s %s\n", t.ID, t.State, t.Reason)
+			}
+		}
+	}
+	if len(r.Alarms) > 0 {
+		firing := 0
+		for _, a := range r.Alarms {
+			if a.State == "ALARM" {
+				firing++
+			}
+		}
+		fmt.Fprintf(w, "    alarms       : %d/%d in ALARM\n", firing, len(r.Alarms))
+		for _, a := range r.Alarms {
+			if a.State != "OK" {
+				fmt.Fprintf(w, "      - %s %s\n", a.Name, a.State)
+			}
+		}
+	}
+	if r.LastSnapshot != nil {
+		age := now.Sub(r.LastSnapshot.StartTime).Truncate(time.Minute)
+		fmt.Fprintf(w, "    last_snapshot: %s %s (%s ago, %s)\n", r.LastSnapshot.ID, r.LastSnapshot.StartTime.UTC().Format(time.RFC3339), age, r.LastSnapshot.State)
+	}
+	for _, i := range r.Issues {
+		fmt.Fprintf(w, "    error        : %s: %s\n", i.Check, i.Message)
+	}
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,95 @@
+package health
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckHealthyASGStack(t *testing.T) {
+	t.Parallel()
+
+	snapTime := time.Date(2026, 10, 18, 3, 0, 0, 0, time.UTC)
+	fake := &FakeClient{
+		Instances: map[string]string{"i-a": "running", "i-b": "running", "i-db": "running"},
+		ASGs:      map[string]ASGState{"app-asg": {Desired: 2, MinSize: 2, MaxSize: 4, InService: 2, Instances: []string{"i-a", "i-b"}}},
+		Targets:   map[string][]TargetHealth{"arn:tg": {{ID: "i-a", State: "healthy"}, {ID: "i-b", State: "healthy"}}},
+		Alarms:    map[string]AlarmState{"cpu-high": {State: "OK"}},
+		Snapshot:  &Snapshot{ID: "snap-1", StartTime: snapTime, State: "completed"},
+	}
+
+	r := Check(fake, Target{App: "billing", Environment: "prod", Outputs: map[string]any{
+		"app_asg_name":              "app-asg",
+		"db_instance_id":            "i-db",
+		"alb_target_group_arn":      "arn:tg",
+		"observability_alarm_names": []any{"cpu-high"},
+		"recovery_enabled":          "true",
+	}})
+
+	if !r.Healthy || len(r.Issues) != 0 {
+		t.Fatalf("expected healthy report, got %+v", r)
+	}
+	if len(r.Instances) != 3 || r.Instances[2].Role != "db" {
+		t.Fatalf("unexpected instances: %+v", r.Instances)
+	}
+	if fake.SnapshotTags["App"] != "billing" || fake.SnapshotTags["Environment"] != "prod" {
+		t.Fatalf("unexpected snapshot filter: %+v", fake.SnapshotTags)
+	}
+
+	var buf bytes.Buffer
+	r.Render(&buf, snapTime.Add(90*time.Minute))
+	for _, want := range []string{"LIVE (healthy)", "in_service=2 desired=2", "2/2 healthy", "snap-1", "1h30m0s ago"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Fatalf("expected %q in:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestCheckDegraded(t *testing.T) {
+	t.Parallel()
+
+	fake := &FakeClient{
+		Instances: map[string]string{"i-cp": "running", "i-w1": "stopped"},
+		ErrAlarms: errors.New("AccessDenied"),
+	}
+	r := Check(fake, Target{Outputs: map[string]any{
+		"control_plane_instance_id": "i-cp",
+		"worker_instance_ids":       []any{"i-w1", "i-w2"},
+		"observability_alarm_names": []any{"a"},
+	}})
+
+	if r.Healthy {
+		t.Fatal("expected degraded report")
+	}
+	if r.Instances[2].State != "not-found" || r.Instances[1].Role != "worker" {
+		t.Fatalf("unexpected instances: %+v", r.Instances)
+	}
+	if len(r.Issues) != 1 || r.Issues[0].Check != "alarms" {
+		t.Fatalf("expected alarms issue, got %+v", r.Issues)
+	}
+}
+
+func TestCLIClientDescribeAutoScalingGroup(t *testing.T) {
+	t.Parallel()
+
+	var gotArgs []string
+	c := &CLIClient{Region: "us-east-1", run: func(name string, args ...string) ([]byte, error) {
+		gotArgs = args
+		return []byte(`{"AutoScalingGroups":[{"MinSize":1,"MaxSize":3,"DesiredCapacity":2,"Instances":[
+			{"InstanceId":"i-1","LifecycleState":"InService"},
+			{"InstanceId":"i-2","LifecycleState":"Pending"}]}]}`), nil
+	}}
+
+	asg, err := c.DescribeAutoScalingGroup("app-asg")
+	if err != nil {
+		t.Fatalf("DescribeAutoScalingGroup: %v", err)
+	}
+	if asg.Desired != 2 || asg.InService != 1 || len(asg.Instances) != 2 {
+		t.Fatalf("unexpected asg: %+v", asg)
+	}
+	if strings.Join(gotArgs, " ") != "autoscaling describe-auto-scaling-groups --auto-scaling-group-names app-asg --region us-east-1 --output json" {
+		t.Fatalf("unexpected args: %v", gotArgs)
+	}
+}
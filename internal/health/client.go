@@ -0,0 +1,225 @@
+// Package health consulta o estado real dos recursos na AWS para o `status --live`.
+// O acesso à AWS fica atrás da interface Client para que o CLI use o binário `aws`
+// e os testes usem o FakeClient, sem depender de credenciais.
+package health
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/PydaVi/brainctl/internal/awscli"
+)
+
+// InstanceState é o estado EC2 de uma instância (running, stopped, ...).
+type InstanceState struct {
+	ID    string `json:"id" yaml:"id"`
+	State string `json:"state" yaml:"state"`
+}
+
+// ASGState resume capacidade desejada e instâncias InService de um Auto Scaling Group.
+type ASGState struct {
+	Name      string   `json:"name" yaml:"name"`
+	MinSize   int      `json:"min_size" yaml:"min_size"`
+	MaxSize   int      `json:"max_size" yaml:"max_size"`
+	Desired   int      `json:"desired_capacity" yaml:"desired_capacity"`
+	InService int      `json:"in_service" yaml:"in_service"`
+	Instances []string `json:"instances,omitempty" yaml:"instances,omitempty"`
+}
+
+// TargetHealth é a saúde de um target registrado no target group.
+type TargetHealth struct {
+	ID     string `json:"id" yaml:"id"`
+	Port   int    `json:"port,omitempty" yaml:"port,omitempty"`
+	State  string `json:"state" yaml:"state"`
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// AlarmState é o estado atual de um alarme CloudWatch.
+type AlarmState struct {
+	Name   string `json:"name" yaml:"name"`
+	State  string `json:"state" yaml:"state"`
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// Snapshot identifica o snapshot mais recente criado pelas políticas DLM.
+type Snapshot struct {
+	ID        string    `json:"id" yaml:"id"`
+	StartTime time.Time `json:"start_time" yaml:"start_time"`
+	State     string    `json:"state" yaml:"state"`
+}
+
+// Client é a fronteira com a AWS usada pelo status --live.
+type Client interface {
+	DescribeInstances(ids []string) ([]InstanceState, error)
+	DescribeAutoScalingGroup(name string) (ASGState, error)
+	DescribeTargetHealth(targetGroupARN string) ([]TargetHealth, error)
+	DescribeAlarms(names []string) ([]AlarmState, error)
+	LatestSnapshot(tags map[string]string) (*Snapshot, error)
+}
+
+// CLIClient implementa Client chamando o AWS CLI v2 com saída JSON.
+// Usamos o binário oficial (como no doctor) para não adicionar o SDK como dependência.
+type CLIClient struct {
+	Region string
+	run    awscli.RunFunc
+}
+
+// NewCLIClient constrói o client para a região do contrato.
+func NewCLIClient(region string) *CLIClient {
+	return &CLIClient{Region: region, run: awscli.Run}
+}
+
+func (c *CLIClient) call(out any, args ...string) error {
+	return awscli.Call(c.run, c.Region, out, args...)
+}
+
+// DescribeInstances retorna o estado EC2 na ordem dos ids informados.
+func (c *CLIClient) DescribeInstances(ids []string) ([]InstanceState, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var resp struct {
+		Reservations []struct {
+			Instances []struct {
+				InstanceID string `json:"InstanceId"`
+				State      struct {
+					Name string `json:"Name"`
+				} `json:"State"`
+			} `json:"Instances"`
+		} `json:"Reservations"`
+	}
+	args := append([]string{"ec2", "describe-instances", "--instance-ids"}, ids...)
+	if err := c.call(&resp, args...); err != nil {
+		return nil, err
+	}
+
+	byID := map[string]string{}
+	for _, res := range resp.Reservations {
+		for _, inst := range res.Instances {
+			byID[inst.InstanceID] = inst.State.Name
+		}
+	}
+	out := make([]InstanceState, 0, len(ids))
+	for _, id := range ids {
+		state := byID[id]
+		if state == "" {
+			state = "not-found"
+		}
+		out = append(out, InstanceState{ID: id, State: state})
+	}
+	return out, nil
+}
+
+// DescribeAutoScalingGroup retorna capacidade e instâncias InService do ASG.
+func (c *CLIClient) DescribeAutoScalingGroup(name string) (ASGState, error) {
+	var resp struct {
+		AutoScalingGroups []struct {
+			MinSize         int `json:"MinSize"`
+			MaxSize         int `json:"MaxSize"`
+			DesiredCapacity int `json:"DesiredCapacity"`
+			Instances       []struct {
+				InstanceID     string `json:"InstanceId"`
+				LifecycleState string `json:"LifecycleState"`
+			} `json:"Instances"`
+		} `json:"AutoScalingGroups"`
+	}
+	if err := c.call(&resp, "autoscaling", "describe-auto-scaling-groups", "--auto-scaling-group-names", name); err != nil {
+		return ASGState{}, err
+	}
+	if len(resp.AutoScalingGroups) == 0 {
+		return ASGState{}, fmt.Errorf("auto scaling group %q not found", name)
+	}
+
+	g := resp.AutoScalingGroups[0]
+	st := ASGState{Name: name, MinSize: g.MinSize, MaxSize: g.MaxSize, Desired: g.DesiredCapacity}
+	for _, inst := range g.Instances {
+		st.Instances = append(st.Instances, inst.InstanceID)
+		if inst.LifecycleState == "InService" {
+			st.InService++
+		}
+	}
+	return st, nil
+}
+
+// DescribeTargetHealth retorna a saúde dos targets do target group.
+func (c *CLIClient) DescribeTargetHealth(targetGroupARN string) ([]TargetHealth, error) {
+	var resp struct {
+		TargetHealthDescriptions []struct {
+			Target struct {
+				ID   string `json:"Id"`
+				Port int    `json:"Port"`
+			} `json:"Target"`
+			TargetHealth struct {
+				State  string `json:"State"`
+				Reason string `json:"Reason"`
+			} `json:"TargetHealth"`
+		} `json:"TargetHealthDescriptions"`
+	}
+	if err := c.call(&resp, "elbv2", "describe-target-health", "--target-group-arn", targetGroupARN); err != nil {
+		return nil, err
+	}
+
+	out := make([]TargetHealth, 0, len(resp.TargetHealthDescriptions))
+	for _, d := range resp.TargetHealthDescriptions {
+		out = append(out, TargetHealth{ID: d.Target.ID, Port: d.Target.Port, State: d.TargetHealth.State, Reason: d.TargetHealth.Reason})
+	}
+	return out, nil
+}
+
+// DescribeAlarms retorna o estado dos alarmes pelo nome.
+func (c *CLIClient) DescribeAlarms(names []string) ([]AlarmState, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	var resp struct {
+		MetricAlarms []struct {
+			AlarmName   string `json:"AlarmName"`
+			StateValue  string `json:"StateValue"`
+			StateReason string `json:"StateReason"`
+		} `json:"MetricAlarms"`
+	}
+	args := append([]string{"cloudwatch", "describe-alarms", "--alarm-names"}, names...)
+	if err := c.call(&resp, args...); err != nil {
+		return nil, err
+	}
+
+	out := make([]AlarmState, 0, len(resp.MetricAlarms))
+	for _, a := range resp.MetricAlarms {
+		out = append(out, AlarmState{Name: a.AlarmName, State: a.StateValue, Reason: a.StateReason})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// LatestSnapshot retorna o snapshot EBS mais recente da conta com as tags informadas.
+func (c *CLIClient) LatestSnapshot(tags map[string]string) (*Snapshot, error) {
+	var resp struct {
+		Snapshots []struct {
+			SnapshotID string    `json:"SnapshotId"`
+			StartTime  time.Time `json:"StartTime"`
+			State      string    `json:"State"`
+		} `json:"Snapshots"`
+	}
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	args := []string{"ec2", "describe-snapshots", "--owner-ids", "self", "--filters"}
+	for _, k := range keys {
+		args = append(args, fmt.Sprintf("Name=tag:%s,Values=%s", k, tags[k]))
+	}
+	if err := c.call(&resp, args...); err != nil {
+		return nil, err
+	}
+
+	var latest *Snapshot
+	for _, s := range resp.Snapshots {
+		if latest == nil || s.StartTime.After(latest.StartTime) {
+			latest = &Snapshot{ID: s.SnapshotID, StartTime: s.StartTime, State: s.State}
+		}
+	}
+	return latest, nil
+}
@@ -0,0 +1,78 @@
+package health
+
+import "fmt"
+
+// FakeClient é um Client em memória para testes e execuções sem AWS.
+// Campos Err* forçam falhas por consulta.
+type FakeClient struct {
+	Instances map[string]string
+	ASGs      map[string]ASGState
+	Targets   map[string][]TargetHealth
+	Alarms    map[string]AlarmState
+	Snapshot  *Snapshot
+
+	ErrInstances error
+	ErrASG       error
+	ErrTargets   error
+	ErrAlarms    error
+	ErrSnapshot  error
+
+	// SnapshotTags guarda o último filtro recebido em LatestSnapshot.
+	SnapshotTags map[string]string
+}
+
+func (f *FakeClient) DescribeInstances(ids []string) ([]InstanceState, error) {
+	if f.ErrInstances != nil {
+		return nil, f.ErrInstances
+	}
+	out := make([]InstanceState, 0, len(ids))
+	for _, id := range ids {
+		state, ok := f.Instances[id]
+		if !ok {
+			state = "not-found"
+		}
+		out = append(out, InstanceState{ID: id, State: state})
+	}
+	return out, nil
+}
+
+func (f *FakeClient) DescribeAutoScalingGroup(name string) (ASGState, error) {
+	if f.ErrASG != nil {
+		return ASGState{}, f.ErrASG
+	}
+	asg, ok := f.ASGs[name]
+	if !ok {
+		return ASGState{}, fmt.Errorf("auto scaling group %q not found", name)
+	}
+	asg.Name = name
+	return asg, nil
+}
+
+func (f *FakeClient) DescribeTargetHealth(targetGroupARN string) ([]TargetHealth, error) {
+	if f.ErrTargets != nil {
+		return nil, f.ErrTargets
+	}
+	return f.Targets[targetGroupARN], nil
+}
+
+func (f *FakeClient) DescribeAlarms(names []string) ([]AlarmState, error) {
+	if f.ErrAlarms != nil {
+		return nil, f.ErrAlarms
+	}
+	var out []AlarmState
+	for _, n := range names {
+		if a, ok := f.Alarms[n]; ok {
+			a.Name = n
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func (f *FakeClient) LatestSnapshot(tags map[string]string) (*Snapshot, error) {
+	f.SnapshotTags = tags
+	if f.ErrSnapshot != nil {
+		return nil, f.ErrSnapshot
+	}
+	return f.Snapshot, nil
+}
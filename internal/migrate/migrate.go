@@ -0,0 +1,173 @@
+// Package migrate reescreve contratos (app.yaml) entre versões de blueprint.
+// Os passos operam sobre yaml.Node para preservar comentários e ordem das chaves.
+package migrate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/PydaVi/brainctl/internal/config"
+)
+
+// Step migra o contrato de uma versão do blueprint para a seguinte.
+type Step struct {
+	From        string
+	To          string
+	Description string
+	Apply       func(doc *yaml.Node) error
+}
+
+// Deprecation marca um campo agendado para remoção. Com Replacement, `migrate` renomeia
+// o campo; sem, o campo é removido (só use quando a remoção não muda o comportamento).
+type Deprecation struct {
+	Path        string
+	RemovedIn   string
+	Replacement string
+	Message     string
+}
+
+// Warning formata o aviso exibido em plan/apply enquanto o campo existir no contrato.
+func (d Deprecation) Warning() string {
+	msg := fmt.Sprintf("%s está obsoleto e será removido em %s", d.Path, d.RemovedIn)
+	if d.Replacement != "" {
+		msg += fmt.Sprintf("; use %s", d.Replacement)
+	}
+	if d.Message != "" {
+		msg += " (" + d.Message + ")"
+	}
+	return msg + ". Execute `brainctl migrate` para atualizar o contrato."
+}
+
+// DefaultVersion é a versão assumida quando o contrato não declara workload.version.
+const DefaultVersion = "v1"
+
+// Blueprint reúne o que o migrate precisa saber de um blueprint do catálogo.
+type Blueprint struct {
+	Type         string
+	Latest       string
+	Steps        []Step
+	Deprecations []Deprecation
+}
+
+// CheckDeprecations lista os avisos dos campos obsoletos presentes no documento.
+func CheckDeprecations(doc *yaml.Node, deprecations []Deprecation) []string {
+	var out []string
+	for _, d := range deprecations {
+		if config.LookupYAMLPath(doc, d.Path) != nil {
+			out = append(out, d.Warning())
+		}
+	}
+	return out
+}
+
+// Run aplica, em ordem: fixação de workload.type/version (contratos antigos dependem do
+// default), os passos From->To até target e a correção dos campos obsoletos.
+// Retorna a descrição de cada alteração; vazio significa contrato já atualizado.
+func Run(doc *yaml.Node, bp Blueprint, target string) ([]string, error) {
+	if target == "" {
+		target = bp.Latest
+	}
+	var changes []string
+
+	current := DefaultVersion
+	if n := config.LookupYAMLPath(doc, "workload.version"); n != nil && n.Value != "" {
+		current = n.Value
+	} else {
+		if err := pinWorkload(doc, bp.Type, current); err != nil {
+			return nil, err
+		}
+		changes = append(changes, fmt.Sprintf("workload fixado em %s@%s (antes implícito pelo default)", bp.Type, current))
+	}
+
+	if compareVersions(current, target) > 0 {
+		return nil, fmt.Errorf("contract is at %s, newer than target %s (downgrade is not supported)", current, target)
+	}
+	for compareVersions(current, target) < 0 {
+		step, ok := findStep(bp.Steps, current)
+		if !ok {
+			return nil, fmt.Errorf("no migration step for %s from %s", bp.Type, current)
+		}
+		if err := step.Apply(doc); err != nil {
+			return nil, fmt.Errorf("migrate %s %s->%s: %w", bp.Type, step.From, step.To, err)
+		}
+		if err := config.SetYAMLPath(doc, "workload.version", step.To); err != nil {
+			return nil, err
+		}
+		changes = append(changes, fmt.Sprintf("%s -> %s: %s", step.From, step.To, step.Description))
+		current = step.To
+	}
+
+	for _, d := range bp.Deprecations {
+		node := config.LookupYAMLPath(doc, d.Path)
+		if node == nil {
+			continue
+		}
+		if d.Replacement != "" && config.LookupYAMLPath(doc, d.Replacement) == nil {
+			var v any
+			if err := node.Decode(&v); err != nil {
+				return nil, fmt.Errorf("decode %s: %w", d.Path, err)
+			}
+			if err := config.SetYAMLPath(doc, d.Replacement, v); err != nil {
+				return nil, err
+			}
+			changes = append(changes, fmt.Sprintf("%s renomeado para %s", d.Path, d.Replacement))
+		} else {
+			changes = append(changes, fmt.Sprintf("%s removido (%s)", d.Path, d.Message))
+		}
+		config.DeleteYAMLPath(doc, d.Path)
+	}
+	return changes, nil
+}
+
+func findStep(steps []Step, from string) (Step, bool) {
+	for _, s := range steps {
+		if s.From == from {
+			return s, true
+		}
+	}
+	return Step{}, false
+}
+
+// pinWorkload insere o bloco workload no topo do contrato (ou completa o existente).
+func pinWorkload(doc *yaml.Node, workloadType, version string) error {
+	if config.LookupYAMLPath(doc, "workload") != nil {
+		if config.LookupYAMLPath(doc, "workload.type") == nil {
+			if err := config.SetYAMLPath(doc, "workload.type", workloadType); err != nil {
+				return err
+			}
+		}
+		return config.SetYAMLPath(doc, "workload.version", version)
+	}
+
+	root := doc
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("contract root must be a mapping")
+	}
+	var block yaml.Node
+	if err := block.Encode(map[string]string{"type": workloadType, "version": version}); err != nil {
+		return err
+	}
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "workload"}
+	root.Content = append([]*yaml.Node{key, &block}, root.Content...)
+	return nil
+}
+
+// compareVersions compara "vN" numericamente (v10 > v9).
+func compareVersions(a, b string) int {
+	na, _ := strconv.Atoi(strings.TrimPrefix(a, "v"))
+	nb, _ := strconv.Atoi(strings.TrimPrefix(b, "v"))
+	switch {
+	case na < nb:
+		return -1
+	case na > nb:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,98 @@
+package migrate
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/PydaVi/brainctl/internal/config"
+)
+
+func parseDoc(t *testing.T, src string) *yaml.Node {
+	t.Helper()
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(src), &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	return &doc
+}
+
+func TestRunChainsStepsAndFixesDeprecations(t *testing.T) {
+	t.Parallel()
+
+	src := `# contrato legado
+app:
+  name: billing
+ec2:
+  instance_type: t3.micro # tamanho
+  imds_v2_required: true
+  legacy_port: 8080
+`
+	bp := Blueprint{
+		Type:   "ec2-app",
+		Latest: "v2",
+		Steps: []Step{{
+			From: "v1", To: "v2", Description: "ec2.legacy_port vira lb.target_port",
+			Apply: func(doc *yaml.Node) error {
+				n := config.LookupYAMLPath(doc, "ec2.legacy_port")
+				if n == nil {
+					return nil
+				}
+				if err := config.SetYAMLPath(doc, "lb.target_port", n.Value); err != nil {
+					return err
+				}
+				config.DeleteYAMLPath(doc, "ec2.legacy_port")
+				return nil
+			},
+		}},
+		Deprecations: []Deprecation{
+			{Path: "ec2.imds_v2_required", RemovedIn: "v3", Message: "sem efeito"},
+		},
+	}
+
+	doc := parseDoc(t, src)
+	if w := CheckDeprecations(doc, bp.Deprecations); len(w) != 1 || !strings.Contains(w[0], "ec2.imds_v2_required") {
+		t.Fatalf("unexpected warnings: %v", w)
+	}
+
+	changes, err := Run(doc, bp, "")
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(changes) != 3 {
+		t.Fatalf("expected pin, v1->v2 and deprecation fix, got %v", changes)
+	}
+
+	out, err := config.EncodeYAMLDocument(doc, []byte(src))
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	got := string(out)
+	for _, want := range []string{"workload:\n  type: ec2-app\n  version: v2\n", "instance_type: t3.micro # tamanho", "lb:\n  target_port: \"8080\""} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("output missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "imds_v2_required") || strings.Contains(got, "legacy_port") {
+		t.Fatalf("expected migrated fields to be removed:\n%s", got)
+	}
+
+	// idempotente: segunda execução não altera nada
+	again, err := Run(doc, bp, "")
+	if err != nil || len(again) != 0 {
+		t.Fatalf("expected no changes on second run, got %v (%v)", again, err)
+	}
+}
+
+func TestRunRejectsDowngradeAndMissingSteps(t *testing.T) {
+	t.Parallel()
+
+	bp := Blueprint{Type: "ec2-app", Latest: "v3"}
+	if _, err := Run(parseDoc(t, "workload:\n  type: ec2-app\n  version: v1\n"), bp, ""); err == nil {
+		t.Fatal("expected error when no step exists from v1")
+	}
+	if _, err := Run(parseDoc(t, "workload:\n  type: ec2-app\n  version: v3\n"), bp, "v2"); err == nil {
+		t.Fatal("expected error on downgrade")
+	}
+}
@@ -0,0 +1,235 @@
+package outputs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ExportFormats lista os formatos aceitos por `brainctl output --format`.
+var ExportFormats = []string{"json", "env", "shell", "yaml", "ansible-inventory", "ssm-config"}
+
+// ExportOptions carrega o contexto do contrato usado por inventory/ssm-config.
+type ExportOptions struct {
+	// HostPrefix prefixa os aliases de host (ex: billing-prod).
+	HostPrefix string
+	Region     string
+	// SSHUser é o usuário padrão do SSH via SSM; vazio omite a diretiva User.
+	SSHUser string
+}
+
+// Host é uma instância exposta pelos outputs, agrupada pelo papel no blueprint.
+type Host struct {
+	Role      string
+	Name      string
+	ID        string
+	PrivateIP string
+	PublicIP  string
+}
+
+var envKeyInvalid = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// Export escreve os outputs já simplificados (chave->valor) no formato pedido.
+func Export(w io.Writer, format string, v map[string]any, opts ExportOptions) error {
+	switch format {
+	case "json":
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	case "env":
+		return writeEnv(w, v, false)
+	case "shell":
+		return writeEnv(w, v, true)
+	case "yaml":
+		b, err := yaml.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	case "ansible-inventory":
+		return writeAnsibleInventory(w, Hosts(v, opts.HostPrefix), opts)
+	case "ssm-config":
+		writeSSMConfig(w, Hosts(v, opts.HostPrefix), opts)
+		return nil
+	default:
+		return fmt.Errorf("invalid output format %q (allowed: %s)", format, strings.Join(ExportFormats, ", "))
+	}
+}
+
+// EnvKey converte o nome do output em variável de ambiente (alb_dns_name -> ALB_DNS_NAME).
+func EnvKey(name string) string {
+	return envKeyInvalid.ReplaceAllString(strings.ToUpper(name), "_")
+}
+
+// flatValue converte o valor do output em string: listas viram CSV e mapas viram JSON.
+func flatValue(x any) (string, bool) {
+	switch t := x.(type) {
+	case nil:
+		return "", false
+	case []any:
+		return strings.Join(AsStringSlice(t), ","), true
+	case map[string]any:
+		b, err := json.Marshal(t)
+		if err != nil {
+			return "", false
+		}
+		return string(b), true
+	default:
+		return AsString(t, ""), true
+	}
+}
+
+func writeEnv(w io.Writer, v map[string]any, shell bool) error {
+	for _, k := range sortedKeys(v) {
+		val, ok := flatValue(v[k])
+		if !ok {
+			continue
+		}
+		if shell {
+			fmt.Fprintf(w, "export %s=%s\n", EnvKey(k), shellQuote(val))
+		} else {
+			fmt.Fprintf(w, "%s=%s\n", EnvKey(k), dotenvQuote(val))
+		}
+	}
+	return nil
+}
+
+// shellQuote usa aspas simples, escapando aspas simples internas ('\”).
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// dotenvQuote só adiciona aspas duplas quando o valor precisa.
+func dotenvQuote(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\"'#$\\=`\n") {
+		return s
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, `$`, `\$`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// Hosts extrai as instâncias dos outputs de qualquer blueprint, na ordem app, db,
+// control-plane e workers.
+func Hosts(v map[string]any, prefix string) []Host {
+	var hosts []Host
+	add := func(role string, ids, privateIPs, publicIPs []string) {
+		for i, id := range ids {
+			h := Host{Role: role, ID: id, Name: fmt.Sprintf("%s-%d", role, i+1)}
+			if len(ids) == 1 {
+				h.Name = role
+			}
+			if prefix != "" {
+				h.Name = prefix + "-" + h.Name
+			}
+			if i < len(privateIPs) {
+				h.PrivateIP = privateIPs[i]
+			}
+			if i < len(publicIPs) {
+				h.PublicIP = publicIPs[i]
+			}
+			hosts = append(hosts, h)
+		}
+	}
+
+	if ids := AsStringSlice(v["app_instance_ids"]); len(ids) > 0 {
+		add("app", ids, AsStringSlice(v["app_private_ips"]), nil)
+	} else if id := AsString(v["instance_id"], ""); id != "" {
+		add("app", []string{id}, optional(v["private_ip"]), optional(v["public_ip"]))
+	}
+	if id := AsString(v["db_instance_id"], ""); id != "" {
+		add("db", []string{id}, optional(v["db_private_ip"]), nil)
+	}
+	if ids := AsStringSlice(v["control_plane_instance_ids"]); len(ids) > 0 {
+		add("control-plane", ids, AsStringSlice(v["control_plane_private_ips"]), optional(v["control_plane_public_ip"]))
+	} else if id := AsString(v["control_plane_instance_id"], ""); id != "" {
+		add("control-plane", []string{id}, optional(v["control_plane_private_ip"]), optional(v["control_plane_public_ip"]))
+	}
+	add("worker", AsStringSlice(v["worker_instance_ids"]), AsStringSlice(v["worker_private_ips"]), nil)
+	return hosts
+}
+
+func optional(x any) []string {
+	if s := AsString(x, ""); s != "" {
+		return []string{s}
+	}
+	return nil
+}
+
+// writeAnsibleInventory gera um inventory YAML com um grupo por papel.
+// A conexão padrão é aws_ssm, que não exige porta 22 aberta nem IP público.
+func writeAnsibleInventory(w io.Writer, hosts []Host, opts ExportOptions) error {
+	type hostVars struct {
+		AnsibleHost string `yaml:"ansible_host"`
+		InstanceID  string `yaml:"instance_id"`
+		PrivateIP   string `yaml:"private_ip,omitempty"`
+		PublicIP    string `yaml:"public_ip,omitempty"`
+	}
+	type group struct {
+		Hosts map[string]hostVars `yaml:"hosts"`
+	}
+
+	children := map[string]group{}
+	for _, h := range hosts {
+		name := strings.ReplaceAll(h.Role, "-", "_")
+		g, ok := children[name]
+		if !ok {
+			g = group{Hosts: map[string]hostVars{}}
+			children[name] = g
+		}
+		g.Hosts[h.Name] = hostVars{AnsibleHost: h.ID, InstanceID: h.ID, PrivateIP: h.PrivateIP, PublicIP: h.PublicIP}
+	}
+
+	vars := map[string]string{"ansible_connection": "aws_ssm"}
+	if opts.Region != "" {
+		vars["ansible_aws_ssm_region"] = opts.Region
+	}
+	if opts.SSHUser != "" {
+		vars["ansible_user"] = opts.SSHUser
+	}
+
+	inv := map[string]any{"all": map[string]any{"vars": vars, "children": children}}
+	b, err := yaml.Marshal(inv)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// writeSSMConfig gera blocos de ~/.ssh/config que usam o Session Manager como ProxyCommand,
+// com o comando de `aws ssm start-session` equivalente como comentário.
+func writeSSMConfig(w io.Writer, hosts []Host, opts ExportOptions) {
+	region := ""
+	if opts.Region != "" {
+		region = " --region " + opts.Region
+	}
+	fmt.Fprintln(w, "# gerado por brainctl output --format ssm-config")
+	for _, h := range hosts {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "# %s: aws ssm start-session --target %s%s\n", h.Role, h.ID, region)
+		fmt.Fprintf(w, "Host %s\n", h.Name)
+		fmt.Fprintf(w, "  HostName %s\n", h.ID)
+		if opts.SSHUser != "" {
+			fmt.Fprintf(w, "  User %s\n", opts.SSHUser)
+		}
+		fmt.Fprintf(w, "  ProxyCommand sh -c \"aws ssm start-session --target %%h --document-name AWS-StartSSHSession --parameters 'portNumber=%%p'%s\"\n", region)
+	}
+}
+
+func sortedKeys(v map[string]any) []string {
+	keys := make([]string, 0, len(v))
+	for k := range v {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
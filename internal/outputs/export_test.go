@@ -0,0 +1,110 @@
+package outputs
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestExportEnvAndShell(t *testing.T) {
+	t.Parallel()
+
+	v := map[string]any{
+		"alb_dns_name":              "app-123.us-east-1.elb.amazonaws.com",
+		"observability_alarm_names": []any{"cpu-high", "disk-low"},
+		"validation_command":        "ssh ubuntu@host 'kubectl get nodes'",
+		"db_instance_id":            nil,
+	}
+
+	var env bytes.Buffer
+	if err := Export(&env, "env", v, ExportOptions{}); err != nil {
+		t.Fatalf("env: %v", err)
+	}
+	for _, want := range []string{
+		"ALB_DNS_NAME=app-123.us-east-1.elb.amazonaws.com\n",
+		"OBSERVABILITY_ALARM_NAMES=cpu-high,disk-low\n",
+		`VALIDATION_COMMAND="ssh ubuntu@host 'kubectl get nodes'"`,
+	} {
+		if !strings.Contains(env.String(), want) {
+			t.Fatalf("expected %q in env output:\n%s", want, env.String())
+		}
+	}
+	if strings.Contains(env.String(), "DB_INSTANCE_ID") {
+		t.Fatalf("null outputs must be skipped:\n%s", env.String())
+	}
+
+	var sh bytes.Buffer
+	if err := Export(&sh, "shell", v, ExportOptions{}); err != nil {
+		t.Fatalf("shell: %v", err)
+	}
+	if !strings.Contains(sh.String(), `export VALIDATION_COMMAND='ssh ubuntu@host '\''kubectl get nodes'\'''`) {
+		t.Fatalf("unexpected shell quoting:\n%s", sh.String())
+	}
+}
+
+func TestExportAnsibleInventoryGroupsByRole(t *testing.T) {
+	t.Parallel()
+
+	v := map[string]any{
+		"control_plane_instance_id": "i-cp",
+		"control_plane_private_ip":  "10.0.1.10",
+		"worker_instance_ids":       []any{"i-w1", "i-w2"},
+		"worker_private_ips":        []any{"10.0.1.11", "10.0.1.12"},
+	}
+
+	var buf bytes.Buffer
+	if err := Export(&buf, "ansible-inventory", v, ExportOptions{HostPrefix: "lab-dev", Region: "us-east-1", SSHUser: "ubuntu"}); err != nil {
+		t.Fatalf("inventory: %v", err)
+	}
+
+	var inv struct {
+		All struct {
+			Vars     map[string]string `yaml:"vars"`
+			Children map[string]struct {
+				Hosts map[string]map[string]string `yaml:"hosts"`
+			} `yaml:"children"`
+		} `yaml:"all"`
+	}
+	if err := yaml.Unmarshal(buf.Bytes(), &inv); err != nil {
+		t.Fatalf("parse inventory: %v\n%s", err, buf.String())
+	}
+	if inv.All.Vars["ansible_connection"] != "aws_ssm" || inv.All.Vars["ansible_aws_ssm_region"] != "us-east-1" {
+		t.Fatalf("unexpected vars: %+v", inv.All.Vars)
+	}
+	if got := inv.All.Children["worker"].Hosts["lab-dev-worker-2"]["private_ip"]; got != "10.0.1.12" {
+		t.Fatalf("unexpected worker-2 ip %q:\n%s", got, buf.String())
+	}
+	if got := inv.All.Children["control_plane"].Hosts["lab-dev-control-plane"]["instance_id"]; got != "i-cp" {
+		t.Fatalf("unexpected control plane host %q:\n%s", got, buf.String())
+	}
+}
+
+func TestExportSSMConfig(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := Export(&buf, "ssm-config", map[string]any{"instance_id": "i-app", "private_ip": "10.0.0.5"}, ExportOptions{HostPrefix: "billing-prod", Region: "sa-east-1"}); err != nil {
+		t.Fatalf("ssm-config: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"Host billing-prod-app\n",
+		"  HostName i-app\n",
+		"--document-name AWS-StartSSHSession --parameters 'portNumber=%p' --region sa-east-1",
+		"# app: aws ssm start-session --target i-app --region sa-east-1",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestExportRejectsUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	if err := Export(&bytes.Buffer{}, "toml", nil, ExportOptions{}); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
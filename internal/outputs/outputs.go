@@ -24,108 +24,8 @@ func ParseTerraformOutputJSON(raw []byte) (map[string]any, error) {
 	return out, nil
 }
 
-// PrintPrettyStatus renderiza um resumo humano amigável para o comando status.
-func PrintPrettyStatus(v map[string]any) {
-	fmt.Println("Resources:")
-
-	fmt.Println("  APP")
-	if asgName := asString(v["app_asg_name"], ""); asgName != "" {
-		fmt.Printf("    asg_name   : %s\n", asgName)
-		fmt.Printf("    asg_size   : min=%s desired=%s max=%s\n", asString(v["app_asg_min_size"], "?"), asString(v["app_asg_desired_capacity"], "?"), asString(v["app_asg_max_size"], "?"))
-	} else {
-		fmt.Printf("    instance_id: %s\n", asString(v["instance_id"], "(none)"))
-		fmt.Printf("    private_ip : %s\n", asString(v["private_ip"], "(none)"))
-		fmt.Printf("    public_ip  : %s\n", asString(v["public_ip"], "(none)"))
-	}
-	fmt.Printf("    sg         : %s (%s)\n", asString(v["security_group_name"], "(none)"), asString(v["security_group_id"], "(none)"))
-	fmt.Println()
-
-	if dbID := asString(v["db_instance_id"], ""); dbID != "" {
-		fmt.Println("  DB")
-		fmt.Printf("    instance_id: %s\n", dbID)
-		fmt.Printf("    private_ip : %s\n", asString(v["db_private_ip"], "(none)"))
-		fmt.Printf("    sg         : %s (%s)\n", asString(v["db_security_group_name"], "(none)"), asString(v["db_security_group_id"], "(none)"))
-		fmt.Println()
-	}
-
-	if albDNS := asString(v["alb_dns_name"], ""); albDNS != "" {
-		fmt.Println("  ALB")
-		fmt.Printf("    dns_name   : %s\n", albDNS)
-		fmt.Println()
-	}
-
-	appDash := asString(v["observability_app_dashboard_name"], "")
-	dbDash := asString(v["observability_db_dashboard_name"], "")
-	sreDash := asString(v["observability_sre_dashboard_name"], "")
-	sloDash := asString(v["observability_slo_dashboard_name"], "")
-	execDash := asString(v["observability_executive_dashboard_name"], "")
-	infraDash := asString(v["observability_infra_dashboard_name"], "")
-	if appDash != "" || dbDash != "" || sreDash != "" || sloDash != "" || execDash != "" || infraDash != "" {
-		fmt.Println("  OBSERVABILITY")
-		if appDash != "" {
-			fmt.Printf("    app_dash   : %s\n", appDash)
-			fmt.Printf("    app_url    : %s\n", asString(v["observability_app_dashboard_url"], "(none)"))
-		}
-		if dbDash != "" {
-			fmt.Printf("    db_dash    : %s\n", dbDash)
-			fmt.Printf("    db_url     : %s\n", asString(v["observability_db_dashboard_url"], "(none)"))
-		}
-		if sreDash != "" {
-			fmt.Printf("    sre_dash   : %s\n", sreDash)
-			fmt.Printf("    sre_url    : %s\n", asString(v["observability_sre_dashboard_url"], "(none)"))
-		}
-		if sloDash != "" {
-			fmt.Printf("    slo_dash   : %s\n", sloDash)
-			fmt.Printf("    slo_url    : %s\n", asString(v["observability_slo_dashboard_url"], "(none)"))
-		}
-		if execDash != "" {
-			fmt.Printf("    exec_dash  : %s\n", execDash)
-			fmt.Printf("    exec_url   : %s\n", asString(v["observability_executive_dashboard_url"], "(none)"))
-		}
-		if infraDash != "" {
-			fmt.Printf("    infra_dash : %s\n", infraDash)
-			fmt.Printf("    infra_url  : %s\n", asString(v["observability_infra_dashboard_url"], "(none)"))
-		}
-
-		snsTopic := asString(v["observability_sns_topic_arn"], "")
-		email := asString(v["observability_alert_email"], "")
-		if snsTopic != "" {
-			fmt.Printf("    sns_topic  : %s\n", snsTopic)
-			fmt.Printf("    sns_sev1   : %s\n", asString(v["observability_sns_topic_sev1_arn"], "(none)"))
-			fmt.Printf("    sns_sev2   : %s\n", asString(v["observability_sns_topic_sev2_arn"], "(none)"))
-			fmt.Printf("    sns_sev3   : %s\n", asString(v["observability_sns_topic_sev3_arn"], "(none)"))
-			fmt.Printf("    alert_email: %s\n", email)
-		}
-		fmt.Printf("    log_group  : %s\n", asString(v["observability_log_group_name"], "(none)"))
-		fmt.Printf("    ssm_profile: %s\n", asString(v["observability_ssm_profile_name"], "(none)"))
-
-		alarmNames := asStringSlice(v["observability_alarm_names"])
-		if len(alarmNames) == 0 {
-			fmt.Println("    alarms     : (none)")
-		} else {
-			fmt.Println("    alarms:")
-			for _, name := range alarmNames {
-				fmt.Printf("      - %s\n", name)
-			}
-		}
-		fmt.Println()
-	}
-	recoveryEnabled := asString(v["recovery_enabled"], "")
-	if recoveryEnabled == "true" {
-		fmt.Println("  RECOVERY")
-		fmt.Printf("    snapshot_utc : %s\n", asString(v["recovery_snapshot_time_utc"], "(none)"))
-		fmt.Printf("    retention    : %s days\n", asString(v["recovery_retention_days"], "(none)"))
-		fmt.Printf("    app_policy   : %s\n", asString(v["recovery_app_policy_id"], "(none)"))
-		fmt.Printf("    db_policy    : %s\n", asString(v["recovery_db_policy_id"], "(none)"))
-		fmt.Printf("    app_runbook  : %s\n", asString(v["recovery_app_runbook_name"], "(none)"))
-		fmt.Printf("    db_runbook   : %s\n", asString(v["recovery_db_runbook_name"], "(none)"))
-		fmt.Println()
-	}
-
-}
-
-// asString converte valores genéricos em string com fallback.
-func asString(x any, fallback string) string {
+// AsString converte valores genéricos em string com fallback.
+func AsString(x any, fallback string) string {
 	if x == nil {
 		return fallback
 	}
@@ -144,15 +44,15 @@ func asString(x any, fallback string) string {
 	}
 }
 
-// asStringSlice converte um array genérico para []string ignorando vazios.
-func asStringSlice(x any) []string {
+// AsStringSlice converte um array genérico para []string ignorando vazios.
+func AsStringSlice(x any) []string {
 	vals, ok := x.([]any)
 	if !ok {
 		return nil
 	}
 	out := make([]string, 0, len(vals))
 	for _, item := range vals {
-		s := asString(item, "")
+		s := AsString(item, "")
 		if s != "" {
 			out = append(out, s)
 		}
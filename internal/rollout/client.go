@@ -0,0 +1,112 @@
+// Package rollout acompanha e controla o instance refresh do ASG da aplicação
+// (`brainctl rollout status|cancel`). Como no health, o acesso à AWS fica atrás
+// da interface Client: o CLI usa o binário `aws` e os testes usam o FakeClient.
+package rollout
+
+import (
+	"time"
+
+	"github.com/PydaVi/brainctl/internal/awscli"
+)
+
+// Refresh é o estado de um instance refresh do Auto Scaling Group.
+type Refresh struct {
+	ID                   string     `json:"id" yaml:"id"`
+	Status               string     `json:"status" yaml:"status"`
+	StatusReason         string     `json:"status_reason,omitempty" yaml:"status_reason,omitempty"`
+	PercentageComplete   int        `json:"percentage_complete" yaml:"percentage_complete"`
+	InstancesToUpdate    int        `json:"instances_to_update" yaml:"instances_to_update"`
+	StartTime            *time.Time `json:"start_time,omitempty" yaml:"start_time,omitempty"`
+	EndTime              *time.Time `json:"end_time,omitempty" yaml:"end_time,omitempty"`
+	CheckpointPercentage int        `json:"checkpoint_percentage,omitempty" yaml:"checkpoint_percentage,omitempty"`
+}
+
+// Client é a fronteira com a AWS usada pelo comando rollout.
+type Client interface {
+	DescribeInstanceRefreshes(asgName string) ([]Refresh, error)
+	CancelInstanceRefresh(asgName string) (string, error)
+	RollbackInstanceRefresh(asgName string) (string, error)
+}
+
+// CLIClient implementa Client chamando o AWS CLI v2 com saída JSON.
+type CLIClient struct {
+	Region string
+	run    awscli.RunFunc
+}
+
+// NewCLIClient constrói o client para a região do contrato.
+func NewCLIClient(region string) *CLIClient {
+	return &CLIClient{Region: region, run: awscli.Run}
+}
+
+func (c *CLIClient) call(out any, args ...string) error {
+	return awscli.Call(c.run, c.Region, out, args...)
+}
+
+// DescribeInstanceRefreshes retorna os refreshes do ASG, do mais recente para o mais antigo.
+func (c *CLIClient) DescribeInstanceRefreshes(asgName string) ([]Refresh, error) {
+	var resp struct {
+		InstanceRefreshes []struct {
+			InstanceRefreshID  string     `json:"InstanceRefreshId"`
+			Status             string     `json:"Status"`
+			StatusReason       string     `json:"StatusReason"`
+			PercentageComplete int        `json:"PercentageComplete"`
+			InstancesToUpdate  int        `json:"InstancesToUpdate"`
+			StartTime          *time.Time `json:"StartTime"`
+			EndTime            *time.Time `json:"EndTime"`
+			Preferences        struct {
+				CheckpointPercentages []int `json:"CheckpointPercentages"`
+			} `json:"Preferences"`
+		} `json:"InstanceRefreshes"`
+	}
+	if err := c.call(&resp, "autoscaling", "describe-instance-refreshes", "--auto-scaling-group-name", asgName); err != nil {
+		return nil, err
+	}
+
+	out := make([]Refresh, 0, len(resp.InstanceRefreshes))
+	for _, r := range resp.InstanceRefreshes {
+		out = append(out, Refresh{
+			ID:                   r.InstanceRefreshID,
+			Status:               r.Status,
+			StatusReason:         r.StatusReason,
+			PercentageComplete:   r.PercentageComplete,
+			InstancesToUpdate:    r.InstancesToUpdate,
+			StartTime:            r.StartTime,
+			EndTime:              r.EndTime,
+			CheckpointPercentage: nextCheckpoint(r.Preferences.CheckpointPercentages, r.PercentageComplete),
+		})
+	}
+	return out, nil
+}
+
+// CancelInstanceRefresh interrompe o refresh ativo; instâncias já substituídas permanecem.
+func (c *CLIClient) CancelInstanceRefresh(asgName string) (string, error) {
+	var resp struct {
+		InstanceRefreshID string `json:"InstanceRefreshId"`
+	}
+	if err := c.call(&resp, "autoscaling", "cancel-instance-refresh", "--auto-scaling-group-name", asgName); err != nil {
+		return "", err
+	}
+	return resp.InstanceRefreshID, nil
+}
+
+// RollbackInstanceRefresh cancela o refresh ativo e volta a frota ao launch template anterior.
+func (c *CLIClient) RollbackInstanceRefresh(asgName string) (string, error) {
+	var resp struct {
+		InstanceRefreshID string `json:"InstanceRefreshId"`
+	}
+	if err := c.call(&resp, "autoscaling", "rollback-instance-refresh", "--auto-scaling-group-name", asgName); err != nil {
+		return "", err
+	}
+	return resp.InstanceRefreshID, nil
+}
+
+// nextCheckpoint retorna o próximo checkpoint ainda não atingido (0 sem checkpoints).
+func nextCheckpoint(checkpoints []int, complete int) int {
+	for _, p := range checkpoints {
+		if p > complete {
+			return p
+		}
+	}
+	return 0
+}
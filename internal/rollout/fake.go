@@ -0,0 +1,56 @@
+package rollout
+
+import "fmt"
+
+// FakeClient é um Client em memória para testes. Refreshes é consumido em
+// sequência a cada Describe (o último estado se repete), simulando progresso.
+type FakeClient struct {
+	Refreshes [][]Refresh
+
+	ErrDescribe error
+	ErrCancel   error
+
+	// Cancelled/RolledBack registram os ASGs recebidos nas chamadas de controle.
+	Cancelled  []string
+	RolledBack []string
+	calls      int
+}
+
+func (f *FakeClient) DescribeInstanceRefreshes(asgName string) ([]Refresh, error) {
+	if f.ErrDescribe != nil {
+		return nil, f.ErrDescribe
+	}
+	if len(f.Refreshes) == 0 {
+		return nil, nil
+	}
+	idx := min(f.calls, len(f.Refreshes)-1)
+	f.calls++
+	return f.Refreshes[idx], nil
+}
+
+func (f *FakeClient) CancelInstanceRefresh(asgName string) (string, error) {
+	if f.ErrCancel != nil {
+		return "", f.ErrCancel
+	}
+	f.Cancelled = append(f.Cancelled, asgName)
+	return f.activeID(asgName)
+}
+
+func (f *FakeClient) RollbackInstanceRefresh(asgName string) (string, error) {
+	if f.ErrCancel != nil {
+		return "", f.ErrCancel
+	}
+	f.RolledBack = append(f.RolledBack, asgName)
+	return f.activeID(asgName)
+}
+
+func (f *FakeClient) activeID(asgName string) (string, error) {
+	for _, set := range f.Refreshes {
+		for _, r := range set {
+			if r.Active() {
+				return r.ID, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no active instance refresh for %s", asgName)
+}
@@ -0,0 +1,86 @@
+package rollout
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// Estados finais do instance refresh; qualquer outro (Pending, InProgress,
+// Cancelling, RollbackInProgress, Baking) significa refresh em andamento.
+var terminalStatuses = map[string]bool{
+	"Successful":         true,
+	"Failed":             true,
+	"Cancelled":          true,
+	"RollbackSuccessful": true,
+	"RollbackFailed":     true,
+}
+
+// Active indica se o refresh ainda está em andamento.
+func (r Refresh) Active() bool {
+	return !terminalStatuses[r.Status]
+}
+
+// Latest retorna o refresh mais recente do ASG (nil quando nunca houve rollout).
+func Latest(c Client, asgName string) (*Refresh, error) {
+	refreshes, err := c.DescribeInstanceRefreshes(asgName)
+	if err != nil {
+		return nil, err
+	}
+	if len(refreshes) == 0 {
+		return nil, nil
+	}
+	latest := refreshes[0]
+	for _, r := range refreshes[1:] {
+		if r.StartTime != nil && (latest.StartTime == nil || r.StartTime.After(*latest.StartTime)) {
+			latest = r
+		}
+	}
+	return &latest, nil
+}
+
+// Watch consulta o refresh mais recente a cada interval até ele terminar,
+// chamando report a cada mudança de status ou progresso.
+func Watch(c Client, asgName string, interval time.Duration, report func(*Refresh)) (*Refresh, error) {
+	var last *Refresh
+	for {
+		r, err := Latest(c, asgName)
+		if err != nil {
+			return nil, err
+		}
+		if r == nil {
+			return nil, nil
+		}
+		if last == nil || last.Status != r.Status || last.PercentageComplete != r.PercentageComplete {
+			report(r)
+		}
+		if !r.Active() {
+			return r, nil
+		}
+		last = r
+		time.Sleep(interval)
+	}
+}
+
+// Render escreve o resumo humano do refresh.
+func Render(w io.Writer, asgName string, r *Refresh) {
+	if r == nil {
+		fmt.Fprintf(w, "ASG %s: nenhum instance refresh registrado\n", asgName)
+		return
+	}
+	fmt.Fprintf(w, "ASG %s: refresh %s\n", asgName, r.ID)
+	fmt.Fprintf(w, "  status    : %s\n", r.Status)
+	fmt.Fprintf(w, "  progresso : %d%% (%d instâncias restantes)\n", r.PercentageComplete, r.InstancesToUpdate)
+	if r.CheckpointPercentage > 0 && r.Active() {
+		fmt.Fprintf(w, "  checkpoint: próximo em %d%%\n", r.CheckpointPercentage)
+	}
+	if r.StartTime != nil {
+		fmt.Fprintf(w, "  início    : %s\n", r.StartTime.UTC().Format(time.RFC3339))
+	}
+	if r.EndTime != nil {
+		fmt.Fprintf(w, "  fim       : %s\n", r.EndTime.UTC().Format(time.RFC3339))
+	}
+	if r.StatusReason != "" {
+		fmt.Fprintf(w, "  motivo    : %s\n", r.StatusReason)
+	}
+}
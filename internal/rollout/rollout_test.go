@@ -0,0 +1,75 @@
+package rollout
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatchReportsProgressUntilTerminal(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	fake := &FakeClient{Refreshes: [][]Refresh{
+		{{ID: "r-2", Status: "InProgress", PercentageComplete: 0, StartTime: &start}},
+		{{ID: "r-2", Status: "InProgress", PercentageComplete: 0, StartTime: &start}},
+		{{ID: "r-2", Status: "InProgress", PercentageComplete: 50, StartTime: &start}},
+		{{ID: "r-2", Status: "Successful", PercentageComplete: 100, StartTime: &start}},
+	}}
+
+	var seen []int
+	final, err := Watch(fake, "app-asg", time.Millisecond, func(r *Refresh) { seen = append(seen, r.PercentageComplete) })
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	if final == nil || final.Status != "Successful" || final.Active() {
+		t.Fatalf("expected successful terminal refresh, got %+v", final)
+	}
+	if len(seen) != 3 || seen[0] != 0 || seen[1] != 50 || seen[2] != 100 {
+		t.Fatalf("expected one report per change, got %v", seen)
+	}
+}
+
+func TestLatestPicksMostRecentStart(t *testing.T) {
+	t.Parallel()
+
+	older := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(24 * time.Hour)
+	fake := &FakeClient{Refreshes: [][]Refresh{{
+		{ID: "old", Status: "Successful", StartTime: &older},
+		{ID: "new", Status: "Baking", StartTime: &newer, CheckpointPercentage: 100},
+	}}}
+
+	r, err := Latest(fake, "app-asg")
+	if err != nil || r == nil || r.ID != "new" || !r.Active() {
+		t.Fatalf("expected active refresh new, got %+v (%v)", r, err)
+	}
+
+	var buf bytes.Buffer
+	Render(&buf, "app-asg", r)
+	if !strings.Contains(buf.String(), "status    : Baking") || !strings.Contains(buf.String(), "próximo em 100%") {
+		t.Fatalf("unexpected render:\n%s", buf.String())
+	}
+}
+
+func TestCLIClientDescribeInstanceRefreshes(t *testing.T) {
+	t.Parallel()
+
+	var gotArgs []string
+	c := &CLIClient{Region: "us-east-1", run: func(name string, args ...string) ([]byte, error) {
+		gotArgs = args
+		return []byte(`{"InstanceRefreshes":[{"InstanceRefreshId":"r-1","Status":"InProgress","PercentageComplete":30,"InstancesToUpdate":2,"StartTime":"2026-10-01T12:00:00Z","Preferences":{"CheckpointPercentages":[50,100]}}]}`), nil
+	}}
+
+	refreshes, err := c.DescribeInstanceRefreshes("app-asg")
+	if err != nil {
+		t.Fatalf("DescribeInstanceRefreshes: %v", err)
+	}
+	if len(refreshes) != 1 || refreshes[0].ID != "r-1" || refreshes[0].InstancesToUpdate != 2 || refreshes[0].CheckpointPercentage != 50 {
+		t.Fatalf("unexpected refreshes: %+v", refreshes)
+	}
+	if strings.Join(gotArgs, " ") != "autoscaling describe-instance-refreshes --auto-scaling-group-name app-asg --region us-east-1 --output json" {
+		t.Fatalf("unexpected aws args: %v", gotArgs)
+	}
+}
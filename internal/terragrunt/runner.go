@@ -111,7 +111,7 @@ func (r *Runner) ApplyPlan(planFile string, autoApprove bool) error {
 }
 
 // StatePull verifica se o state remoto está acessível via Terragrunt.
-// No MVP, qualquer erro é tratado como state ausente/inacessível.
+// State vazio conta como ausente; falhas de acesso retornam o erro com o stderr.
 func (r *Runner) StatePull() (present bool, err error) {
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
@@ -123,9 +123,9 @@ func (r *Runner) StatePull() (present bool, err error) {
 	cmd.Stdin = os.Stdin
 
 	if err := cmd.Run(); err != nil {
-		return false, nil
+		return false, fmt.Errorf("terragrunt state pull: %w: %s", err, bytes.TrimSpace(stderr.Bytes()))
 	}
-	return true, nil
+	return len(bytes.TrimSpace(stdout.Bytes())) > 0, nil
 }
 
 func (r *Runner) run(args ...string) error {
@@ -1,8 +1,15 @@
 provider "aws" {
   region = var.region
+
+  default_tags {
+    tags = var.default_tags
+  }
 }
 
+# Só consultamos as AMIs dos SOs em uso (sem AMI custom), para não exigir
+# permissão/latência de lookup desnecessária.
 data "aws_ami" "windows_2022" {
+  count       = contains(local.ami_lookup_os, "windows-2022") ? 1 : 0
   most_recent = true
   owners      = ["amazon"]
 
@@ -12,6 +19,28 @@ data "aws_ami" "windows_2022" {
   }
 }
 
+data "aws_ami" "amazon_linux_2023" {
+  count       = contains(local.ami_lookup_os, "amazon-linux-2023") ? 1 : 0
+  most_recent = true
+  owners      = ["amazon"]
+
+  filter {
+    name   = "name"
+    values = ["al2023-ami-2023.*-x86_64"]
+  }
+}
+
+data "aws_ami" "ubuntu_2204" {
+  count       = contains(local.ami_lookup_os, "ubuntu-22.04") ? 1 : 0
+  most_recent = true
+  owners      = ["099720109477"]
+
+  filter {
+    name   = "name"
+    values = ["ubuntu/images/hvm-ssd/ubuntu-jammy-22.04-amd64-server-*"]
+  }
+}
+
 data "aws_caller_identity" "current" {}
 
 locals {
@@ -24,14 +53,32 @@ locals {
   db_subnet_group_subnet_ids    = var.enable_lb && length(var.lb_subnet_ids) > 0 ? var.lb_subnet_ids : [var.subnet_id]
   endpoint_subnet_ids          = length(var.endpoint_subnet_ids) > 0 ? var.endpoint_subnet_ids : [var.subnet_id]
 
-  resolved_app_ami = var.app_ami_id != "" ? var.app_ami_id : data.aws_ami.windows_2022.id
-  resolved_db_ami  = var.db_ami_id  != "" ? var.db_ami_id  : data.aws_ami.windows_2022.id
+  app_is_windows = var.app_os == "windows-2022"
+  db_is_windows  = var.db_os == "windows-2022"
+
+  ami_lookup_os = distinct(compact([
+    var.app_ami_id == "" ? var.app_os : "",
+    var.enable_db && var.db_mode == "ec2" && var.db_ami_id == "" ? var.db_os : "",
+  ]))
+  os_ami_ids = {
+    "windows-2022"      = try(data.aws_ami.windows_2022[0].id, "")
+    "amazon-linux-2023" = try(data.aws_ami.amazon_linux_2023[0].id, "")
+    "ubuntu-22.04"      = try(data.aws_ami.ubuntu_2204[0].id, "")
+  }
+
+  resolved_app_ami = var.app_ami_id != "" ? var.app_ami_id : local.os_ami_ids[var.app_os]
+  resolved_db_ami  = var.db_ami_id  != "" ? var.db_ami_id  : local.os_ami_ids[var.db_os]
 
   app_custom_user_data = var.app_user_data_base64 != "" ? base64decode(var.app_user_data_base64) : ""
   db_custom_user_data  = var.db_user_data_base64  != "" ? base64decode(var.db_user_data_base64)  : ""
 
-  app_default_user_data = var.enable_observability ? local.cw_user_data_app : ""
-  db_default_user_data  = var.enable_observability ? local.cw_user_data_db  : ""
+  # No Linux, user data custom pode ser script bash (sem shebang; o brainctl remove)
+  # ou #cloud-config. cloud-config não concatena com bash: em merge vira multipart MIME.
+  app_custom_is_cloud_config = !local.app_is_windows && startswith(trimspace(local.app_custom_user_data), "#cloud-config")
+  db_custom_is_cloud_config  = !local.db_is_windows && startswith(trimspace(local.db_custom_user_data), "#cloud-config")
+
+  app_default_user_data = var.enable_observability ? (local.app_is_windows ? local.cw_user_data_app : local.cw_user_data_app_linux) : ""
+  db_default_user_data  = var.enable_observability ? (local.db_is_windows ? local.cw_user_data_db : local.cw_user_data_db_linux) : ""
 
   app_effective_user_data_script = (
     var.app_user_data_mode == "default" ? local.app_default_user_data :
@@ -52,17 +99,46 @@ locals {
   )
 
   app_effective_user_data = (
-    trimspace(local.app_effective_user_data_script) != ""
-    ? format("<powershell>\n%s\n</powershell>", trimspace(local.app_effective_user_data_script))
-    : ""
+    trimspace(local.app_effective_user_data_script) == "" ? "" :
+    local.app_is_windows ? format("<powershell>\n%s\n</powershell>", trimspace(local.app_effective_user_data_script)) :
+    local.app_custom_is_cloud_config && var.app_user_data_mode != "default" ? (
+      var.app_user_data_mode == "merge" && local.app_default_user_data != ""
+      ? format(local.cloud_init_multipart, trimspace(local.app_default_user_data), trimspace(local.app_custom_user_data))
+      : trimspace(local.app_custom_user_data)
+    ) :
+    format("#!/bin/bash\n%s\n", trimspace(local.app_effective_user_data_script))
   )
 
   db_effective_user_data = (
-    trimspace(local.db_effective_user_data_script) != ""
-    ? format("<powershell>\n%s\n</powershell>", trimspace(local.db_effective_user_data_script))
-    : ""
+    trimspace(local.db_effective_user_data_script) == "" ? "" :
+    local.db_is_windows ? format("<powershell>\n%s\n</powershell>", trimspace(local.db_effective_user_data_script)) :
+    local.db_custom_is_cloud_config && var.db_user_data_mode != "default" ? (
+      var.db_user_data_mode == "merge" && local.db_default_user_data != ""
+      ? format(local.cloud_init_multipart, trimspace(local.db_default_user_data), trimspace(local.db_custom_user_data))
+      : trimspace(local.db_custom_user_data)
+    ) :
+    format("#!/bin/bash\n%s\n", trimspace(local.db_effective_user_data_script))
   )
 
+  # Bootstrap padrão (shell) + cloud-config do contrato; o cloud-init executa o script
+  # na fase scripts-user, depois dos módulos do cloud-config.
+  cloud_init_multipart = <<-EOT
+Content-Type: multipart/mixed; boundary="BRAINCTL-BOUNDARY"
+MIME-Version: 1.0
+
+--BRAINCTL-BOUNDARY
+Content-Type: text/x-shellscript; charset="utf-8"
+
+#!/bin/bash
+%s
+
+--BRAINCTL-BOUNDARY
+Content-Type: text/cloud-config; charset="utf-8"
+
+%s
+--BRAINCTL-BOUNDARY--
+EOT
+
   sns_enabled   = var.enable_observability && var.alert_email != ""
   alarm_actions = local.sns_enabled ? [aws_sns_topic.alerts[0].arn] : []
   alarm_actions_sev1 = local.sns_enabled ? [aws_sns_topic.alerts_sev1[0].arn] : []
@@ -182,6 +258,107 @@ locals {
     }
   })
 
+  ############################################
+  # CLOUDWATCH CONFIG LINUX (APP/DB)
+  ############################################
+
+  # aggregation_dimensions publica disk_used_percent só com InstanceId+path, sem
+  # device/fstype (que variam entre AL2023 e Ubuntu), para alarmes estáveis.
+  cw_agent_config_app_linux = jsonencode({
+    agent = {
+      metrics_collection_interval = 60
+    }
+    metrics = {
+      namespace = "BrainCTL/${var.name}/${var.environment}"
+      append_dimensions = {
+        InstanceId = "$${aws:InstanceId}"
+      }
+      aggregation_dimensions = [["InstanceId"], ["InstanceId", "path"]]
+      metrics_collected = {
+        disk = {
+          measurement = ["used_percent"]
+          resources   = ["/"]
+          drop_device = true
+        }
+        mem = {
+          measurement = ["mem_used_percent"]
+        }
+        netstat = {
+          measurement = ["tcp_established"]
+        }
+      }
+    }
+    logs = {
+      logs_collected = {
+        files = {
+          collect_list = [
+            {
+              file_path       = "/var/log/messages"
+              log_group_name  = local.cw_log_group_name
+              log_stream_name = "${local.app_instance_label}/syslog"
+              timezone        = "UTC"
+            },
+            {
+              file_path       = "/var/log/syslog"
+              log_group_name  = local.cw_log_group_name
+              log_stream_name = "${local.app_instance_label}/syslog"
+              timezone        = "UTC"
+            },
+            {
+              file_path       = "/var/log/cloud-init-output.log"
+              log_group_name  = local.cw_log_group_name
+              log_stream_name = "${local.app_instance_label}/cloud-init"
+              timezone        = "UTC"
+            }
+          ]
+        }
+      }
+    }
+  })
+
+  cw_agent_config_db_linux = jsonencode({
+    agent = {
+      metrics_collection_interval = 60
+    }
+    metrics = {
+      namespace = "BrainCTL/${var.name}/${var.environment}"
+      append_dimensions = {
+        InstanceId = "$${aws:InstanceId}"
+      }
+      aggregation_dimensions = [["InstanceId"], ["InstanceId", "path"]]
+      metrics_collected = {
+        disk = {
+          measurement = ["used_percent"]
+          resources   = ["/"]
+          drop_device = true
+        }
+        mem = {
+          measurement = ["mem_used_percent"]
+        }
+      }
+    }
+    logs = {
+      logs_collected = {
+        files = {
+          collect_list = [
+            {
+              file_path       = "/var/log/messages"
+              log_group_name  = local.cw_log_group_name
+              log_stream_name = "${local.db_instance_label}/syslog"
+              timezone        = "UTC"
+            },
+            {
+              file_path       = "/var/log/syslog"
+              log_group_name  = local.cw_log_group_name
+              log_stream_name = "${local.db_instance_label}/syslog"
+              timezone        = "UTC"
+            }
+          ]
+        }
+      }
+    }
+  })
+
   ############################################
   # USER DATA - APP (SEM WRAPPER)
   ############################################
@@ -264,5 +441,78 @@ if (Test-Path $cwCtl) {
 else {
   Write-BrainctlLog "CloudWatch Agent bootstrap skipped (agent not present in AMI)"
 }
+EOT
+
+  ############################################
+  # USER DATA LINUX - APP/DB (SEM SHEBANG)
+  ############################################
+
+  # Instala o agente quando a AMI não o traz: AL2023 via dnf; Ubuntu via o .deb
+  # oficial do bucket S3 regional. O AL2023 não tem rsyslog (só journald), então ele
+  # é instalado para gerar o /var/log/messages coletado pelo agente.
+  cw_agent_install_linux = <<-EOT
+cw_ctl=/opt/aws/amazon-cloudwatch-agent/bin/amazon-cloudwatch-agent-ctl
+if command -v dnf >/dev/null 2>&1; then
+  if [ ! -x "$cw_ctl" ]; then
+    dnf install -y amazon-cloudwatch-agent >/dev/null 2>&1 || brainctl_log "dnf install amazon-cloudwatch-agent failed"
+  fi
+  if [ ! -f /var/log/messages ]; then
+    { dnf install -y rsyslog && systemctl enable --now rsyslog; } >/dev/null 2>&1 || brainctl_log "rsyslog install failed; /var/log/messages will not be shipped"
+  fi
+elif [ ! -x "$cw_ctl" ] && command -v dpkg >/dev/null 2>&1; then
+  cw_deb=/tmp/amazon-cloudwatch-agent.deb
+  cw_url="https://amazoncloudwatch-agent-${var.region}.s3.${var.region}.amazonaws.com/ubuntu/$(dpkg --print-architecture)/latest/amazon-cloudwatch-agent.deb"
+  if curl -fsSL --retry 3 -o "$cw_deb" "$cw_url" && dpkg -i -E "$cw_deb" >/dev/null 2>&1; then
+    rm -f "$cw_deb"
+  else
+    brainctl_log "deb install amazon-cloudwatch-agent failed ($cw_url)"
+  fi
+fi
+EOT
+
+  cw_user_data_app_linux = <<-EOT
+brainctl_log() { echo "$(date '+%Y-%m-%d %H:%M:%S') [APP] $*" >> /var/log/brainctl-userdata.log; }
+
+brainctl_log "Starting CloudWatch bootstrap"
+
+mkdir -p /opt/aws/amazon-cloudwatch-agent/etc
+cat > /opt/aws/amazon-cloudwatch-agent/etc/config.json <<'BRAINCTL_CWAGENT'
+${local.cw_agent_config_app_linux}
+BRAINCTL_CWAGENT
+
+${local.cw_agent_install_linux}
+
+if [ -x "$cw_ctl" ]; then
+  if "$cw_ctl" -a fetch-config -m ec2 -s -c file:/opt/aws/amazon-cloudwatch-agent/etc/config.json; then
+    brainctl_log "CloudWatch Agent bootstrap completed"
+  else
+    brainctl_log "CloudWatch Agent bootstrap failed but provisioning will continue"
+  fi
+else
+  brainctl_log "CloudWatch Agent bootstrap skipped (agent not present in AMI)"
+fi
+EOT
+
+  cw_user_data_db_linux = <<-EOT
+brainctl_log() { echo "$(date '+%Y-%m-%d %H:%M:%S') [DB] $*" >> /var/log/brainctl-userdata.log; }
+
+brainctl_log "Starting CloudWatch bootstrap"
+
+mkdir -p /opt/aws/amazon-cloudwatch-agent/etc
+cat > /opt/aws/amazon-cloudwatch-agent/etc/config.json <<'BRAINCTL_CWAGENT'
+${local.cw_agent_config_db_linux}
+BRAINCTL_CWAGENT
+
+${local.cw_agent_install_linux}
+
+if [ -x "$cw_ctl" ]; then
+  if "$cw_ctl" -a fetch-config -m ec2 -s -c file:/opt/aws/amazon-cloudwatch-agent/etc/config.json; then
+    brainctl_log "CloudWatch Agent bootstrap completed"
+  else
+    brainctl_log "CloudWatch Agent bootstrap failed but provisioning will continue"
+  fi
+else
+  brainctl_log "CloudWatch Agent bootstrap skipped (agent not present in AMI)"
+fi
 EOT
 }
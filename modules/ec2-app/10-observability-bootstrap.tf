@@ -277,6 +277,9 @@ resource "aws_ssm_document" "cwagent_apply_config" {
       {
         action = "aws:runPowerShellScript"
         name   = "ApplyCloudWatchAgentConfig"
+        precondition = {
+          StringEquals = ["platformType", "Windows"]
+        }
         inputs = {
           runCommand = [
             "$ErrorActionPreference = 'Stop'",
@@ -295,6 +298,29 @@ resource "aws_ssm_document" "cwagent_apply_config" {
             "}"
           ]
         }
+      },
+      {
+        action = "aws:runShellScript"
+        name   = "ApplyCloudWatchAgentConfigLinux"
+        precondition = {
+          StringEquals = ["platformType", "Linux"]
+        }
+        inputs = {
+          runCommand = [
+            "set -euo pipefail",
+            "config_path=/opt/aws/amazon-cloudwatch-agent/etc/config.json",
+            "agent_ctl=/opt/aws/amazon-cloudwatch-agent/bin/amazon-cloudwatch-agent-ctl",
+            "mkdir -p /opt/aws/amazon-cloudwatch-agent/etc",
+            "cat > \"$config_path\" <<'BRAINCTL_CWAGENT'",
+            "{{ ConfigContent }}",
+            "BRAINCTL_CWAGENT",
+            "if [ -x \"$agent_ctl\" ]; then",
+            "  \"$agent_ctl\" -a fetch-config -m ec2 -s -c file:\"$config_path\"",
+            "else",
+            "  echo 'CloudWatch Agent control script not found' >&2; exit 1",
+            "fi"
+          ]
+        }
       }
     ]
   })
@@ -330,7 +356,7 @@ resource "aws_ssm_association" "cwagent_apply_app" {
 
   # aws_ssm_association.parameters expects map(string) for provider versions used by brainctl.
   parameters = {
-    ConfigContent = local.cw_agent_config_app
+    ConfigContent = local.app_is_windows ? local.cw_agent_config_app : local.cw_agent_config_app_linux
   }
 
   max_concurrency = "100%"
@@ -361,7 +387,7 @@ resource "aws_ssm_association" "cwagent_apply_db" {
 
   # Keep as plain string (not single-item list) to avoid tuple type errors in Terraform.
   parameters = {
-    ConfigContent = local.cw_agent_config_db
+    ConfigContent = local.db_is_windows ? local.cw_agent_config_db : local.cw_agent_config_db_linux
   }
 
   max_concurrency = "100%"
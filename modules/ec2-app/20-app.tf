@@ -1,14 +1,21 @@
 locals {
+  # Acesso administrativo segue o SO: RDP no Windows, SSH no Linux.
+  app_admin_ingress_rule = local.app_is_windows ? {
+    description = "RDP"
+    from_port   = 3389
+    to_port     = 3389
+    protocol    = "tcp"
+    cidr_blocks = [var.allowed_rdp_cidr]
+    } : {
+    description = "SSH"
+    from_port   = 22
+    to_port     = 22
+    protocol    = "tcp"
+    cidr_blocks = [trimspace(var.allowed_ssh_cidr) != "" ? var.allowed_ssh_cidr : var.vpc_cidr]
+  }
+
   app_ingress_rules_raw = concat(
-    [
-      {
-        description = "RDP"
-        from_port   = 3389
-        to_port     = 3389
-        protocol    = "tcp"
-        cidr_blocks = [var.allowed_rdp_cidr]
-      }
-    ],
+    [local.app_admin_ingress_rule],
     var.app_extra_ingress_rules,
   )
 
@@ -75,13 +82,13 @@ resource "aws_instance" "app" {
     encrypted = true
   }
 
-  volume_tags = {
+  volume_tags = merge(var.default_tags, {
     Name        = "${var.name}-${var.environment}-app-root"
     Environment = var.environment
     ManagedBy   = "brainctl"
     App         = var.name
     BackupScope = "app"
-  }
+  })
 
   tags = {
     Name        = "${var.name}-${var.environment}"
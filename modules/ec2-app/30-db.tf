@@ -58,13 +58,13 @@ resource "aws_instance" "db" {
     encrypted = true
   }
 
-  volume_tags = {
+  volume_tags = merge(var.default_tags, {
     Name        = "${var.name}-${var.environment}-db-root"
     Environment = var.environment
     ManagedBy   = "brainctl"
     App         = var.name
     BackupScope = "db"
-  }
+  })
 
   tags = {
     Name        = "${var.name}-${var.environment}-db"
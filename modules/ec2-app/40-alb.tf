@@ -1,4 +1,24 @@
 locals {
+  # TLS liga o listener HTTPS (443); a porta 80 fica só para o redirect, quando habilitado.
+  lb_tls_enabled     = var.enable_lb && (var.lb_certificate_arn != "" || var.lb_tls_domain != "")
+  lb_request_cert    = var.enable_lb && var.lb_tls_domain != ""
+  lb_http_listener   = var.enable_lb && (!local.lb_tls_enabled || var.lb_redirect_http)
+  lb_certificate_arn = var.lb_certificate_arn != "" ? var.lb_certificate_arn : try(aws_acm_certificate_validation.alb[0].certificate_arn, "")
+
+  # Rotas por path: um target group por rota, registrado nas mesmas instâncias app.
+  lb_routes            = var.enable_lb ? { for r in var.lb_routes : r.name => r } : {}
+  lb_health_check_port = var.lb_health_check_port > 0 ? tostring(var.lb_health_check_port) : "traffic-port"
+  lb_extra_app_ports = var.enable_lb ? toset([
+    for p in distinct(concat([for r in values(local.lb_routes) : r.target_port], var.lb_health_check_port > 0 ? [var.lb_health_check_port] : [])) :
+    tostring(p) if p != var.app_port
+  ]) : toset([])
+  lb_route_attachments = var.enable_lb && !var.enable_app_asg ? {
+    for pair in setproduct(keys(local.lb_routes), range(var.app_instance_count)) :
+    "${pair[0]}-${pair[1]}" => { route = pair[0], index = pair[1] }
+  } : {}
+  lb_route_target_group_arns = [for tg in aws_lb_target_group.route : tg.arn]
+  lb_forward_listener_arn    = local.lb_tls_enabled ? one(aws_lb_listener.https[*].arn) : one(aws_lb_listener.http[*].arn)
+
   alb_ingress_rules_raw = concat(
     [
       {
@@ -9,6 +29,15 @@ locals {
         cidr_blocks = [var.lb_allowed_cidr]
       }
     ],
+    local.lb_tls_enabled && var.lb_redirect_http ? [
+      {
+        description = "HTTP redirect"
+        from_port   = 80
+        to_port     = 80
+        protocol    = "tcp"
+        cidr_blocks = [var.lb_allowed_cidr]
+      }
+    ] : [],
     var.alb_extra_ingress_rules,
   )
 
@@ -67,6 +96,17 @@ resource "aws_security_group_rule" "app_from_alb" {
   description              = "App port from ALB"
 }
 
+resource "aws_security_group_rule" "app_from_alb_extra" {
+  for_each                 = local.lb_extra_app_ports
+  type                     = "ingress"
+  security_group_id        = aws_security_group.app_sg.id
+  from_port                = tonumber(each.value)
+  to_port                  = tonumber(each.value)
+  protocol                 = "tcp"
+  source_security_group_id = aws_security_group.alb_sg[0].id
+  description              = "Route/health check port from ALB"
+}
+
 resource "aws_lb" "app_alb" {
   count              = var.enable_lb ? 1 : 0
   name               = substr("${var.name}-${var.environment}-alb", 0, 32)
@@ -83,20 +123,29 @@ resource "aws_lb" "app_alb" {
 }
 
 resource "aws_lb_target_group" "app_tg" {
-  count    = var.enable_lb ? 1 : 0
-  name     = substr("${var.name}-${var.environment}-tg", 0, 32)
-  port     = var.app_port
-  protocol = "HTTP"
-  vpc_id   = var.vpc_id
+  count                = var.enable_lb ? 1 : 0
+  name                 = substr("${var.name}-${var.environment}-tg", 0, 32)
+  port                 = var.app_port
+  protocol             = var.lb_target_protocol
+  vpc_id               = var.vpc_id
+  deregistration_delay = var.lb_deregistration_delay
 
   health_check {
-    path                = "/"
-    protocol            = "HTTP"
-    matcher             = "200-399"
-    interval            = 30
-    timeout             = 5
-    healthy_threshold   = 2
-    unhealthy_threshold = 2
+    path                = var.lb_health_check_path
+    port                = local.lb_health_check_port
+    protocol            = var.lb_target_protocol
+    matcher             = var.lb_health_check_matcher
+    interval            = var.lb_health_check_interval
+    timeout             = var.lb_health_check_timeout
+    healthy_threshold   = var.lb_health_check_healthy_threshold
+    unhealthy_threshold = var.lb_health_check_unhealthy_threshold
+  }
+
+  stickiness {
+    enabled         = var.lb_stickiness_enabled
+    type            = var.lb_stickiness_type
+    cookie_duration = var.lb_stickiness_duration
+    cookie_name     = var.lb_stickiness_type == "app_cookie" ? var.lb_stickiness_cookie_name : null
   }
 
   tags = {
@@ -106,12 +155,110 @@ resource "aws_lb_target_group" "app_tg" {
   }
 }
 
+resource "aws_lb_target_group" "route" {
+  for_each             = local.lb_routes
+  name                 = substr("${var.name}-${var.environment}-${each.key}", 0, 32)
+  port                 = each.value.target_port
+  protocol             = var.lb_target_protocol
+  vpc_id               = var.vpc_id
+  deregistration_delay = var.lb_deregistration_delay
+
+  health_check {
+    path                = each.value.health_check_path != "" ? each.value.health_check_path : var.lb_health_check_path
+    port                = local.lb_health_check_port
+    protocol            = var.lb_target_protocol
+    matcher             = var.lb_health_check_matcher
+    interval            = var.lb_health_check_interval
+    timeout             = var.lb_health_check_timeout
+    healthy_threshold   = var.lb_health_check_healthy_threshold
+    unhealthy_threshold = var.lb_health_check_unhealthy_threshold
+  }
+
+  stickiness {
+    enabled         = var.lb_stickiness_enabled
+    type            = var.lb_stickiness_type
+    cookie_duration = var.lb_stickiness_duration
+    cookie_name     = var.lb_stickiness_type == "app_cookie" ? var.lb_stickiness_cookie_name : null
+  }
+
+  tags = {
+    Name        = "${var.name}-${var.environment}-${each.key}"
+    Environment = var.environment
+    ManagedBy   = "brainctl"
+  }
+}
+
+resource "aws_acm_certificate" "alb" {
+  count                     = local.lb_request_cert ? 1 : 0
+  domain_name               = var.lb_tls_domain
+  subject_alternative_names = var.lb_tls_subject_alternative_names
+  validation_method         = "DNS"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+
+  tags = {
+    Name        = "${var.name}-${var.environment}-alb-cert"
+    Environment = var.environment
+    ManagedBy   = "brainctl"
+  }
+}
+
+resource "aws_route53_record" "alb_cert_validation" {
+  for_each = local.lb_request_cert ? {
+    for dvo in aws_acm_certificate.alb[0].domain_validation_options : dvo.domain_name => {
+      name   = dvo.resource_record_name
+      record = dvo.resource_record_value
+      type   = dvo.resource_record_type
+    }
+  } : {}
+
+  allow_overwrite = true
+  zone_id         = var.lb_tls_hosted_zone_id
+  name            = each.value.name
+  type            = each.value.type
+  ttl             = 60
+  records         = [each.value.record]
+}
+
+resource "aws_acm_certificate_validation" "alb" {
+  count                   = local.lb_request_cert ? 1 : 0
+  certificate_arn         = aws_acm_certificate.alb[0].arn
+  validation_record_fqdns = [for r in aws_route53_record.alb_cert_validation : r.fqdn]
+}
+
+# Sem TLS: listener HTTP encaminha para o target group (comportamento original).
+# Com TLS: a porta 80 só redireciona para HTTPS.
 resource "aws_lb_listener" "http" {
-  count             = var.enable_lb ? 1 : 0
+  count             = local.lb_http_listener ? 1 : 0
   load_balancer_arn = aws_lb.app_alb[0].arn
-  port              = var.lb_listener_port
+  port              = local.lb_tls_enabled ? 80 : var.lb_listener_port
   protocol          = "HTTP"
 
+  default_action {
+    type             = local.lb_tls_enabled ? "redirect" : "forward"
+    target_group_arn = local.lb_tls_enabled ? null : aws_lb_target_group.app_tg[0].arn
+
+    dynamic "redirect" {
+      for_each = local.lb_tls_enabled ? [1] : []
+      content {
+        port        = "443"
+        protocol    = "HTTPS"
+        status_code = "HTTP_301"
+      }
+    }
+  }
+}
+
+resource "aws_lb_listener" "https" {
+  count             = local.lb_tls_enabled ? 1 : 0
+  load_balancer_arn = aws_lb.app_alb[0].arn
+  port              = 443
+  protocol          = "HTTPS"
+  ssl_policy        = var.lb_ssl_policy
+  certificate_arn   = local.lb_certificate_arn
+
   default_action {
     type             = "forward"
     target_group_arn = aws_lb_target_group.app_tg[0].arn
@@ -124,3 +271,29 @@ resource "aws_lb_target_group_attachment" "app_attach" {
   target_id        = aws_instance.app[count.index].id
   port             = var.app_port
 }
+
+resource "aws_lb_target_group_attachment" "route_attach" {
+  for_each         = local.lb_route_attachments
+  target_group_arn = aws_lb_target_group.route[each.value.route].arn
+  target_id        = aws_instance.app[each.value.index].id
+  port             = local.lb_routes[each.value.route].target_port
+}
+
+# Regras avaliadas antes da default_action do listener que encaminha tráfego
+# (HTTPS com TLS; HTTP caso contrário).
+resource "aws_lb_listener_rule" "route" {
+  for_each     = local.lb_routes
+  listener_arn = local.lb_forward_listener_arn
+  priority     = each.value.priority
+
+  action {
+    type             = "forward"
+    target_group_arn = aws_lb_target_group.route[each.key].arn
+  }
+
+  condition {
+    path_pattern {
+      values = each.value.path_patterns
+    }
+  }
+}
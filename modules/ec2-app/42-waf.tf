@@ -0,0 +1,97 @@
+locals {
+  waf_enabled    = var.enable_lb && var.lb_waf_enabled
+  waf_create_acl = local.waf_enabled && var.lb_waf_web_acl_arn == ""
+
+  # Chaves do contrato (lb.waf.managed_rules) -> rule groups gerenciados da AWS.
+  waf_managed_rule_groups = {
+    core             = { name = "AWSManagedRulesCommonRuleSet", priority = 10 }
+    known_bad_inputs = { name = "AWSManagedRulesKnownBadInputsRuleSet", priority = 20 }
+    ip_reputation    = { name = "AWSManagedRulesAmazonIpReputationList", priority = 30 }
+  }
+  waf_rules = { for k in var.lb_waf_managed_rules : k => local.waf_managed_rule_groups[k] }
+
+  waf_web_acl_arn = var.lb_waf_web_acl_arn != "" ? var.lb_waf_web_acl_arn : try(aws_wafv2_web_acl.alb[0].arn, "")
+  # ACL existente: nome extraído do ARN regional (arn:aws:wafv2:<region>:<account>:regional/webacl/<name>/<id>).
+  waf_web_acl_name = !local.waf_enabled ? null : (
+    local.waf_create_acl ? "${var.name}-${var.environment}-alb" : element(split("/", var.lb_waf_web_acl_arn), 2)
+  )
+}
+
+resource "aws_wafv2_web_acl" "alb" {
+  count = local.waf_create_acl ? 1 : 0
+  name  = "${var.name}-${var.environment}-alb"
+  scope = "REGIONAL"
+
+  default_action {
+    allow {}
+  }
+
+  # Rate limit por IP avaliado antes dos rule groups gerenciados.
+  dynamic "rule" {
+    for_each = var.lb_waf_rate_limit > 0 ? [var.lb_waf_rate_limit] : []
+    content {
+      name     = "rate-limit"
+      priority = 1
+
+      action {
+        block {}
+      }
+
+      statement {
+        rate_based_statement {
+          limit              = rule.value
+          aggregate_key_type = "IP"
+        }
+      }
+
+      visibility_config {
+        cloudwatch_metrics_enabled = true
+        metric_name                = "rate-limit"
+        sampled_requests_enabled   = true
+      }
+    }
+  }
+
+  dynamic "rule" {
+    for_each = local.waf_rules
+    content {
+      name     = rule.value.name
+      priority = rule.value.priority
+
+      override_action {
+        none {}
+      }
+
+      statement {
+        managed_rule_group_statement {
+          name        = rule.value.name
+          vendor_name = "AWS"
+        }
+      }
+
+      visibility_config {
+        cloudwatch_metrics_enabled = true
+        metric_name                = rule.value.name
+        sampled_requests_enabled   = true
+      }
+    }
+  }
+
+  visibility_config {
+    cloudwatch_metrics_enabled = true
+    metric_name                = "${var.name}-${var.environment}-alb"
+    sampled_requests_enabled   = true
+  }
+
+  tags = {
+    Name        = "${var.name}-${var.environment}-alb-waf"
+    Environment = var.environment
+    ManagedBy   = "brainctl"
+  }
+}
+
+resource "aws_wafv2_web_acl_association" "alb" {
+  count        = local.waf_enabled ? 1 : 0
+  resource_arn = aws_lb.app_alb[0].arn
+  web_acl_arn  = local.waf_web_acl_arn
+}
@@ -0,0 +1,36 @@
+locals {
+  dns_alb_record       = var.enable_dns && var.enable_lb
+  dns_instance_records = var.enable_dns && var.dns_instance_records && !var.enable_lb && !var.enable_app_asg
+  dns_zone_id          = var.dns_zone_id != "" ? var.dns_zone_id : try(data.aws_route53_zone.dns[0].zone_id, "")
+}
+
+data "aws_route53_zone" "dns" {
+  count        = var.enable_dns && var.dns_zone_id == "" ? 1 : 0
+  name         = var.dns_zone_name
+  private_zone = var.dns_private_zone
+}
+
+# Alias do ALB: acompanha mudanças de IP do balanceador sem TTL manual.
+resource "aws_route53_record" "alb" {
+  count   = local.dns_alb_record ? 1 : 0
+  zone_id = local.dns_zone_id
+  name    = var.dns_record_name
+  type    = "A"
+
+  alias {
+    name                   = aws_lb.app_alb[0].dns_name
+    zone_id                = aws_lb.app_alb[0].zone_id
+    evaluate_target_health = true
+  }
+}
+
+# Sem LB: um registro A por instância app (IP privado). Com uma instância o
+# registro usa o nome base; com várias, recebe o sufixo -1, -2, ...
+resource "aws_route53_record" "app_instance" {
+  count   = local.dns_instance_records ? var.app_instance_count : 0
+  zone_id = local.dns_zone_id
+  name    = var.app_instance_count == 1 ? var.dns_record_name : "${var.dns_record_name}-${count.index + 1}"
+  type    = "A"
+  ttl     = var.dns_ttl
+  records = [aws_instance.app[count.index].private_ip]
+}
@@ -1,3 +1,19 @@
+locals {
+  # Alarmes de saúde do target group (criados pela observabilidade com ASG + LB)
+  # interrompem o refresh; os nomes são fixos para não criar dependência cíclica.
+  app_rollout_default_alarms = var.enable_observability && var.enable_lb ? [
+    "brainctl-${var.name}-${var.environment}-sev1-app-tg-unhealthy-hosts",
+    "brainctl-${var.name}-${var.environment}-sev2-app-tg-5xx-high",
+  ] : []
+  app_rollout_alarm_names = distinct(concat(local.app_rollout_default_alarms, var.app_asg_rollout_alarm_names))
+
+  app_step_policies = { for p in var.app_asg_step_policies : p.name => p }
+
+  # Com rollout a versão é explícita: a mudança dela dispara o instance refresh.
+  app_launch_template_version = var.enable_app_asg && var.app_asg_rollout_enabled ? tostring(aws_launch_template.app[0].latest_version) : "$Latest"
+  app_asg_mixed_instances     = length(var.app_asg_instance_types) > 0
+}
+
 resource "aws_launch_template" "app" {
   count = var.enable_app_asg ? 1 : 0
 
@@ -20,23 +36,23 @@ resource "aws_launch_template" "app" {
 
   tag_specifications {
     resource_type = "instance"
-    tags = {
+    tags = merge(var.default_tags, {
       Name        = "${var.name}-${var.environment}"
       Environment = var.environment
       ManagedBy   = "brainctl"
       Role        = "app"
-    }
+    })
   }
 
   tag_specifications {
     resource_type = "volume"
-    tags = {
+    tags = merge(var.default_tags, {
       Name        = "${var.name}-${var.environment}-app-root"
       Environment = var.environment
       ManagedBy   = "brainctl"
       App         = var.name
       BackupScope = "app"
-    }
+    })
   }
 }
 
@@ -50,11 +66,79 @@ resource "aws_autoscaling_group" "app" {
   health_check_type         = var.enable_lb ? "ELB" : "EC2"
   health_check_grace_period = 300
   vpc_zone_identifier       = var.app_asg_subnet_ids
-  target_group_arns         = var.enable_lb ? [aws_lb_target_group.app_tg[0].arn] : []
+  target_group_arns         = var.enable_lb ? concat([aws_lb_target_group.app_tg[0].arn], local.lb_route_target_group_arns) : []
+  capacity_rebalance        = local.app_asg_mixed_instances ? var.app_asg_capacity_rebalance : null
 
-  launch_template {
-    id      = aws_launch_template.app[0].id
-    version = "$Latest"
+  dynamic "launch_template" {
+    for_each = local.app_asg_mixed_instances ? [] : [1]
+    content {
+      id      = aws_launch_template.app[0].id
+      version = local.app_launch_template_version
+    }
+  }
+
+  # Mixed instances: os overrides substituem o instance_type do launch template;
+  # a ordem da lista define a prioridade da parcela on-demand.
+  dynamic "mixed_instances_policy" {
+    for_each = local.app_asg_mixed_instances ? [1] : []
+    content {
+      instances_distribution {
+        on_demand_base_capacity                  = var.app_asg_on_demand_base_capacity
+        on_demand_percentage_above_base_capacity = var.app_asg_on_demand_percentage
+        on_demand_allocation_strategy            = "prioritized"
+        spot_allocation_strategy                 = var.app_asg_spot_allocation_strategy
+      }
+
+      launch_template {
+        launch_template_specification {
+          launch_template_id = aws_launch_template.app[0].id
+          version            = local.app_launch_template_version
+        }
+
+        dynamic "override" {
+          for_each = var.app_asg_instance_types
+          content {
+            instance_type = override.value
+          }
+        }
+      }
+    }
+  }
+
+  dynamic "warm_pool" {
+    for_each = var.app_asg_warm_pool_enabled ? [1] : []
+    content {
+      pool_state                  = var.app_asg_warm_pool_state
+      min_size                    = var.app_asg_warm_pool_min_size
+      max_group_prepared_capacity = var.app_asg_warm_pool_max_prepared_capacity
+
+      instance_reuse_policy {
+        reuse_on_scale_in = var.app_asg_warm_pool_reuse_on_scale_in
+      }
+    }
+  }
+
+  dynamic "instance_refresh" {
+    for_each = var.app_asg_rollout_enabled ? [1] : []
+    content {
+      strategy = "Rolling"
+
+      preferences {
+        min_healthy_percentage = var.app_asg_rollout_min_healthy_percentage
+        instance_warmup        = var.app_asg_rollout_instance_warmup
+        checkpoint_percentages = length(var.app_asg_rollout_checkpoint_percentages) > 0 ? var.app_asg_rollout_checkpoint_percentages : null
+        checkpoint_delay       = length(var.app_asg_rollout_checkpoint_percentages) > 0 ? var.app_asg_rollout_checkpoint_delay : null
+        auto_rollback          = var.app_asg_rollout_auto_rollback
+        skip_matching          = true
+
+        dynamic "alarm_specification" {
+          for_each = length(local.app_rollout_alarm_names) > 0 ? [1] : []
+          content {
+            alarms = local.app_rollout_alarm_names
+          }
+        }
+      }
+    }
   }
 
   tag {
@@ -80,6 +164,12 @@ resource "aws_autoscaling_group" "app" {
     value               = "app"
     propagate_at_launch = true
   }
+
+  # desired_capacity é só o valor inicial: target tracking, step policies e
+  # schedules o ajustam em runtime e um apply não deve reverter a capacidade.
+  lifecycle {
+    ignore_changes = [desired_capacity]
+  }
 }
 
 resource "aws_autoscaling_policy" "app_cpu_target" {
@@ -96,3 +186,72 @@ resource "aws_autoscaling_policy" "app_cpu_target" {
     target_value = var.app_asg_cpu_target
   }
 }
+
+resource "aws_autoscaling_policy" "app_request_count_target" {
+  count = var.enable_app_asg && var.enable_lb && var.app_asg_request_count_target > 0 ? 1 : 0
+
+  name                   = "${var.name}-${var.environment}-asg-request-count-target"
+  autoscaling_group_name = aws_autoscaling_group.app[0].name
+  policy_type            = "TargetTrackingScaling"
+
+  target_tracking_configuration {
+    predefined_metric_specification {
+      predefined_metric_type = "ALBRequestCountPerTarget"
+      resource_label         = "${aws_lb.app_alb[0].arn_suffix}/${aws_lb_target_group.app_tg[0].arn_suffix}"
+    }
+    target_value = var.app_asg_request_count_target
+  }
+}
+
+resource "aws_autoscaling_schedule" "app" {
+  for_each = var.enable_app_asg ? { for s in var.app_asg_schedules : s.name => s } : {}
+
+  scheduled_action_name  = "${var.name}-${var.environment}-${each.key}"
+  autoscaling_group_name = aws_autoscaling_group.app[0].name
+  recurrence             = each.value.recurrence
+  time_zone              = each.value.time_zone
+  min_size               = each.value.min_size
+  max_size               = each.value.max_size
+  desired_capacity       = each.value.desired_capacity
+}
+
+resource "aws_autoscaling_policy" "app_step" {
+  for_each = var.enable_app_asg ? local.app_step_policies : {}
+
+  name                   = "${var.name}-${var.environment}-asg-step-${each.key}"
+  autoscaling_group_name = aws_autoscaling_group.app[0].name
+  policy_type            = "StepScaling"
+  adjustment_type        = each.value.adjustment_type
+
+  dynamic "step_adjustment" {
+    for_each = each.value.steps
+    content {
+      metric_interval_lower_bound = step_adjustment.value.lower_bound
+      metric_interval_upper_bound = step_adjustment.value.upper_bound
+      scaling_adjustment          = step_adjustment.value.adjustment
+    }
+  }
+}
+
+resource "aws_cloudwatch_metric_alarm" "app_step" {
+  for_each = var.enable_app_asg ? local.app_step_policies : {}
+
+  alarm_name          = "${var.name}-${var.environment}-asg-step-${each.key}"
+  alarm_description   = "Dispara a step scaling policy ${each.key} do ASG"
+  namespace           = each.value.namespace
+  metric_name         = each.value.metric_name
+  statistic           = each.value.statistic
+  comparison_operator = each.value.comparison_operator
+  threshold           = each.value.threshold
+  period              = each.value.period
+  evaluation_periods  = each.value.evaluation_periods
+  alarm_actions       = [aws_autoscaling_policy.app_step[each.key].arn]
+
+  # Sem dimensões explícitas a métrica é lida no escopo do próprio ASG.
+  dimensions = length(each.value.dimensions) > 0 ? each.value.dimensions : tomap({ AutoScalingGroupName = aws_autoscaling_group.app[0].name })
+
+  tags = merge(var.default_tags, {
+    Environment = var.environment
+    ManagedBy   = "brainctl"
+  })
+}
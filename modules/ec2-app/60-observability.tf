@@ -10,6 +10,8 @@ module "observability" {
   environment = var.environment
   region      = var.region
   cw_agent_namespace = "BrainCTL/${var.name}/${var.environment}"
+  app_os_family      = local.app_is_windows ? "windows" : "linux"
+  db_os_family       = local.db_is_windows ? "windows" : "linux"
 
   cpu_high_threshold = var.cpu_high_threshold
   app_asg_min_size   = var.app_asg_min_size
@@ -24,6 +26,10 @@ module "observability" {
   alb_arn_suffix = var.enable_lb ? aws_lb.app_alb[0].arn_suffix : null
   tg_arn_suffix  = var.enable_lb ? aws_lb_target_group.app_tg[0].arn_suffix : null
 
+  enable_waf                  = local.waf_enabled
+  waf_web_acl_name            = local.waf_web_acl_name
+  waf_blocked_alarm_threshold = var.lb_waf_blocked_requests_alarm
+
   alarm_actions      = local.alarm_actions
   alarm_actions_sev1 = local.alarm_actions_sev1
   alarm_actions_sev2 = local.alarm_actions_sev2
@@ -47,7 +47,7 @@ resource "aws_cloudwatch_dashboard" "app" {
             region  = var.region
             stat    = "Average"
             period  = 60
-            metrics = [[var.cw_agent_namespace, "mem_used_percent", "InstanceId", var.app_instance_id, "objectname", "Memory"]]
+            metrics = [local.app_mem_metric]
             yAxis = {
               left = {
                 min = 0
@@ -63,12 +63,12 @@ resource "aws_cloudwatch_dashboard" "app" {
           width  = 12
           height = 6
           properties = {
-            title   = "APP LogicalDisk % Free"
+            title   = "APP ${local.app_disk_label}"
             view    = "timeSeries"
             region  = var.region
             stat    = "Average"
             period  = 60
-            metrics = [[var.cw_agent_namespace, "LogicalDisk % Free Space", "InstanceId", var.app_instance_id, "objectname", "LogicalDisk", "instance", "C:"]]
+            metrics = [local.app_disk_metric]
           }
         },
         {
@@ -101,7 +101,7 @@ resource "aws_cloudwatch_dashboard" "app" {
             region  = var.region
             stat    = "Average"
             period  = 60
-            metrics = [[var.cw_agent_namespace, "tcp_connections_established", "InstanceId", var.app_instance_id, "objectname", "TCPv4"]]
+            metrics = [local.app_tcp_metric]
           }
         }
       ],
@@ -472,7 +472,7 @@ resource "aws_cloudwatch_dashboard" "sre" {
             region  = var.region
             stat    = "Average"
             period  = 60
-            metrics = [[var.cw_agent_namespace, "mem_used_percent", "InstanceId", var.app_instance_id, "objectname", "Memory"]]
+            metrics = [local.app_mem_metric]
           }
         }
       ],
@@ -617,7 +617,7 @@ resource "aws_cloudwatch_dashboard" "infra" {
             region  = var.region
             stat    = "Average"
             period  = 60
-            metrics = [[var.cw_agent_namespace, "mem_used_percent", "InstanceId", var.app_instance_id, "objectname", "Memory"]]
+            metrics = [local.app_mem_metric]
           }
         },
         {
@@ -627,12 +627,12 @@ resource "aws_cloudwatch_dashboard" "infra" {
           width  = 12
           height = 6
           properties = {
-            title   = "Infra APP Disk Free %"
+            title   = "Infra APP ${local.app_disk_label}"
             view    = "timeSeries"
             region  = var.region
             stat    = "Average"
             period  = 60
-            metrics = [[var.cw_agent_namespace, "LogicalDisk % Free Space", "InstanceId", var.app_instance_id, "objectname", "LogicalDisk", "instance", "C:"]]
+            metrics = [local.app_disk_metric]
           }
         },
         {
@@ -680,7 +680,7 @@ resource "aws_cloudwatch_dashboard" "infra" {
             region  = var.region
             stat    = "Average"
             period  = 60
-            metrics = [[var.cw_agent_namespace, "tcp_connections_established", "InstanceId", var.app_instance_id, "objectname", "TCPv4"]]
+            metrics = [local.app_tcp_metric]
           }
         }
       ],
@@ -905,12 +905,12 @@ resource "aws_cloudwatch_dashboard" "db_ec2" {
         width  = 12
         height = 6
         properties = {
-          title   = "DB Disk Free % (CloudWatch Agent)"
+          title   = "DB ${local.db_disk_label} (CloudWatch Agent)"
           view    = "timeSeries"
           region  = var.region
           stat    = "Average"
           period  = 60
-          metrics = [[var.cw_agent_namespace, "LogicalDisk % Free Space", "InstanceId", var.db_instance_id, "objectname", "LogicalDisk", "instance", "C:"]]
+          metrics = [local.db_disk_metric]
         }
       }
     ]
@@ -988,6 +988,19 @@ resource "aws_cloudwatch_dashboard" "db_rds" {
 }
 
 locals {
+  # Métricas do CloudWatch Agent mudam de nome/dimensão por SO: no Windows vêm dos
+  # performance counters (LogicalDisk % Free Space); no Linux, de disk/mem/netstat.
+  app_is_windows = var.app_os_family == "windows"
+  db_is_windows  = var.db_os_family == "windows"
+
+  app_mem_metric = local.app_is_windows ? tolist([var.cw_agent_namespace, "mem_used_percent", "InstanceId", var.app_instance_id, "objectname", "Memory"]) : tolist([var.cw_agent_namespace, "mem_used_percent", "InstanceId", var.app_instance_id])
+  app_tcp_metric = local.app_is_windows ? tolist([var.cw_agent_namespace, "tcp_connections_established", "InstanceId", var.app_instance_id, "objectname", "TCPv4"]) : tolist([var.cw_agent_namespace, "netstat_tcp_established", "InstanceId", var.app_instance_id])
+
+  app_disk_metric = local.app_is_windows ? tolist([var.cw_agent_namespace, "LogicalDisk % Free Space", "InstanceId", var.app_instance_id, "objectname", "LogicalDisk", "instance", "C:"]) : tolist([var.cw_agent_namespace, "disk_used_percent", "InstanceId", var.app_instance_id, "path", "/"])
+  db_disk_metric  = local.db_is_windows ? tolist([var.cw_agent_namespace, "LogicalDisk % Free Space", "InstanceId", var.db_instance_id, "objectname", "LogicalDisk", "instance", "C:"]) : tolist([var.cw_agent_namespace, "disk_used_percent", "InstanceId", var.db_instance_id, "path", "/"])
+  app_disk_label  = local.app_is_windows ? "Disk Free %" : "Disk Used %"
+  db_disk_label   = local.db_is_windows ? "Disk Free %" : "Disk Used %"
+
   alarm_actions_sev1 = length(var.alarm_actions_sev1) > 0 ? var.alarm_actions_sev1 : var.alarm_actions
   alarm_actions_sev2 = length(var.alarm_actions_sev2) > 0 ? var.alarm_actions_sev2 : var.alarm_actions
   alarm_actions_sev3 = length(var.alarm_actions_sev3) > 0 ? var.alarm_actions_sev3 : var.alarm_actions
@@ -1113,21 +1126,24 @@ resource "aws_cloudwatch_metric_alarm" "app_unreachable" {
 resource "aws_cloudwatch_metric_alarm" "app_disk_low_free" {
   count               = var.enable_observability && !var.enable_app_asg ? 1 : 0
   alarm_name          = "brainctl-${var.name}-${var.environment}-sev3-app-disk-low-free"
-  comparison_operator = "LessThanThreshold"
+  comparison_operator = local.app_is_windows ? "LessThanThreshold" : "GreaterThanThreshold"
   evaluation_periods  = 2
-  metric_name         = "LogicalDisk % Free Space"
+  metric_name         = local.app_is_windows ? "LogicalDisk % Free Space" : "disk_used_percent"
   namespace           = var.cw_agent_namespace
   period              = 60
   statistic           = "Average"
-  threshold           = 15
+  threshold           = local.app_is_windows ? 15 : 85
   alarm_description   = "Disco com pouco espaço livre na APP"
   alarm_actions       = local.alarm_actions_sev3
   ok_actions          = local.alarm_actions_sev3
-  dimensions = {
+  dimensions = local.app_is_windows ? tomap({
     InstanceId = var.app_instance_id
     objectname = "LogicalDisk"
     instance   = "C:"
-  }
+    }) : tomap({
+    InstanceId = var.app_instance_id
+    path       = "/"
+  })
 }
 
 resource "aws_cloudwatch_metric_alarm" "app_asg_cpu_high" {
@@ -1264,6 +1280,28 @@ resource "aws_cloudwatch_metric_alarm" "app_tg_4xx_high" {
   }
 }
 
+# Pico de bloqueios costuma indicar ataque ou falso positivo de regra após deploy.
+resource "aws_cloudwatch_metric_alarm" "app_waf_blocked_requests_high" {
+  count               = var.enable_observability && var.enable_waf ? 1 : 0
+  alarm_name          = "brainctl-${var.name}-${var.environment}-sev2-app-waf-blocked-requests-high"
+  comparison_operator = "GreaterThanThreshold"
+  evaluation_periods  = 1
+  metric_name         = "BlockedRequests"
+  namespace           = "AWS/WAFV2"
+  period              = 300
+  statistic           = "Sum"
+  threshold           = var.waf_blocked_alarm_threshold
+  alarm_description   = "WAF do ALB com pico de requisições bloqueadas"
+  treat_missing_data  = "notBreaching"
+  alarm_actions       = local.alarm_actions_sev2
+  ok_actions          = local.alarm_actions_sev2
+  dimensions = {
+    WebACL = var.waf_web_acl_name
+    Region = var.region
+    Rule   = "ALL"
+  }
+}
+
 resource "aws_cloudwatch_metric_alarm" "db_ec2_cpu_high" {
   count               = var.enable_observability && var.enable_db && var.db_mode == "ec2" ? 1 : 0
   alarm_name          = "brainctl-${var.name}-${var.environment}-sev3-db-cpu-high"
@@ -63,6 +63,21 @@ variable "alb_arn_suffix" {
   default = null
 }
 
+variable "enable_waf" {
+  type    = bool
+  default = false
+}
+
+variable "waf_web_acl_name" {
+  type    = string
+  default = null
+}
+
+variable "waf_blocked_alarm_threshold" {
+  type    = number
+  default = 100
+}
+
 variable "tg_arn_suffix" {
   type    = string
   default = null
@@ -91,3 +106,15 @@ variable "alarm_actions_sev3" {
 variable "cw_agent_namespace" {
   type = string
 }
+
+variable "app_os_family" {
+  description = "Família do SO da APP (windows | linux); define nomes/dimensões das métricas do CloudWatch Agent"
+  type        = string
+  default     = "windows"
+}
+
+variable "db_os_family" {
+  description = "Família do SO do DB em EC2 (windows | linux)"
+  type        = string
+  default     = "windows"
+}
@@ -268,3 +268,28 @@ output "recovery_drill_schedule_name" {
   description = "Nome do schedule mensal de DR drill"
   value       = var.enable_recovery_mode && var.recovery_drill_enabled ? module.recovery.recovery_drill_schedule_name : null
 }
+
+output "alb_certificate_arn" {
+  description = "ARN do certificado ACM usado no listener HTTPS (null sem TLS)"
+  value       = local.lb_tls_enabled ? local.lb_certificate_arn : null
+}
+
+output "alb_url" {
+  description = "URL de acesso ao ALB (https quando TLS está habilitado)"
+  value       = var.enable_lb ? "${local.lb_tls_enabled ? "https" : "http"}://${aws_lb.app_alb[0].dns_name}" : null
+}
+
+output "dns_record_fqdn" {
+  description = "FQDN do alias Route53 do ALB (null sem dns ou sem LB)"
+  value       = local.dns_alb_record ? aws_route53_record.alb[0].fqdn : null
+}
+
+output "dns_instance_fqdns" {
+  description = "FQDNs dos registros Route53 por instância app (sem LB)"
+  value       = aws_route53_record.app_instance[*].fqdn
+}
+
+output "alb_waf_web_acl_arn" {
+  description = "ARN do web ACL WAFv2 associado ao ALB (null sem WAF)"
+  value       = local.waf_enabled ? local.waf_web_acl_arn : null
+}
@@ -58,7 +58,7 @@ variable "endpoint_subnet_ids" {
 }
 
 variable "allowed_rdp_cidr" {
-  description = "CIDR permitido para RDP nas EC2 (apenas no SG da APP por enquanto)"
+  description = "CIDR permitido para RDP na APP quando app_os é Windows (apenas no SG da APP por enquanto)"
   type        = string
 
   validation {
@@ -67,10 +67,32 @@ variable "allowed_rdp_cidr" {
   }
 }
 
+variable "allowed_ssh_cidr" {
+  description = "CIDR permitido para SSH na APP quando app_os é Linux (quando vazio, usa vpc_cidr)"
+  type        = string
+  default     = ""
+
+  validation {
+    condition     = var.allowed_ssh_cidr != "0.0.0.0/0"
+    error_message = "allowed_ssh_cidr cannot be 0.0.0.0/0"
+  }
+}
+
 # ----------------------------
 # Compute - APP
 # ----------------------------
 
+variable "app_os" {
+  description = "SO da APP: windows-2022 | amazon-linux-2023 | ubuntu-22.04 (define AMI padrão, user data e CloudWatch Agent)"
+  type        = string
+  default     = "windows-2022"
+
+  validation {
+    condition     = contains(["windows-2022", "amazon-linux-2023", "ubuntu-22.04"], var.app_os)
+    error_message = "app_os must be one of: windows-2022, amazon-linux-2023, ubuntu-22.04"
+  }
+}
+
 variable "imds_v2_required" {
   description = "Exige IMDSv2 (http_tokens=required) nas instâncias da aplicação"
   type        = bool
@@ -96,7 +118,7 @@ variable "app_extra_ingress_rules" {
 
 
 variable "app_ami_id" {
-  description = "AMI custom para APP (opcional). Quando vazio, usa Windows Server 2022 padrão"
+  description = "AMI custom para APP (opcional). Quando vazio, usa a AMI mais recente de app_os"
   type        = string
   default     = ""
 }
@@ -167,8 +189,19 @@ variable "db_extra_ingress_rules" {
 }
 
 
+variable "db_os" {
+  description = "SO do DB em modo ec2: windows-2022 | amazon-linux-2023 | ubuntu-22.04"
+  type        = string
+  default     = "windows-2022"
+
+  validation {
+    condition     = contains(["windows-2022", "amazon-linux-2023", "ubuntu-22.04"], var.db_os)
+    error_message = "db_os must be one of: windows-2022, amazon-linux-2023, ubuntu-22.04"
+  }
+}
+
 variable "db_ami_id" {
-  description = "AMI custom para DB (opcional). Quando vazio, usa Windows Server 2022 padrão"
+  description = "AMI custom para DB (opcional). Quando vazio, usa a AMI mais recente de db_os"
   type        = string
   default     = ""
 }
@@ -292,11 +325,142 @@ variable "lb_subnet_ids" {
 }
 
 variable "lb_listener_port" {
-  description = "Porta do listener do ALB (HTTP). Ex: 80"
+  description = "Porta do listener principal do ALB: 80 (HTTP) ou 443 (HTTPS, requer TLS)"
   type        = number
   default     = 80
 }
 
+variable "lb_certificate_arn" {
+  description = "ARN de certificado ACM existente para o listener HTTPS (exclusivo com lb_tls_domain)"
+  type        = string
+  default     = ""
+}
+
+variable "lb_tls_domain" {
+  description = "Domínio para solicitar certificado ACM com validação DNS (exclusivo com lb_certificate_arn)"
+  type        = string
+  default     = ""
+}
+
+variable "lb_tls_subject_alternative_names" {
+  description = "SANs adicionais do certificado ACM solicitado via lb_tls_domain"
+  type        = list(string)
+  default     = []
+}
+
+variable "lb_tls_hosted_zone_id" {
+  description = "Hosted zone Route53 onde os registros de validação DNS do ACM são criados"
+  type        = string
+  default     = ""
+}
+
+variable "lb_ssl_policy" {
+  description = "Política TLS do listener HTTPS"
+  type        = string
+  default     = "ELBSecurityPolicy-TLS13-1-2-2021-06"
+}
+
+variable "lb_redirect_http" {
+  description = "Com TLS, cria listener na porta 80 que redireciona (301) para HTTPS"
+  type        = bool
+  default     = true
+}
+
+variable "lb_target_protocol" {
+  description = "Protocolo ALB -> instâncias no target group: HTTP|HTTPS"
+  type        = string
+  default     = "HTTP"
+
+  validation {
+    condition     = contains(["HTTP", "HTTPS"], var.lb_target_protocol)
+    error_message = "lb_target_protocol must be HTTP or HTTPS"
+  }
+}
+
+variable "lb_health_check_path" {
+  description = "Path do health check dos target groups"
+  type        = string
+  default     = "/"
+}
+
+variable "lb_health_check_port" {
+  description = "Porta do health check; 0 usa a porta de tráfego de cada target group"
+  type        = number
+  default     = 0
+}
+
+variable "lb_health_check_matcher" {
+  description = "Códigos HTTP considerados saudáveis (ex: 200, 200-399)"
+  type        = string
+  default     = "200-399"
+}
+
+variable "lb_health_check_interval" {
+  description = "Intervalo do health check em segundos"
+  type        = number
+  default     = 30
+}
+
+variable "lb_health_check_timeout" {
+  description = "Timeout do health check em segundos (menor que o intervalo)"
+  type        = number
+  default     = 5
+}
+
+variable "lb_health_check_healthy_threshold" {
+  description = "Checks consecutivos com sucesso para marcar o target como healthy"
+  type        = number
+  default     = 2
+}
+
+variable "lb_health_check_unhealthy_threshold" {
+  description = "Checks consecutivos com falha para marcar o target como unhealthy"
+  type        = number
+  default     = 2
+}
+
+variable "lb_deregistration_delay" {
+  description = "Tempo (s) de draining antes de remover um target do target group"
+  type        = number
+  default     = 300
+}
+
+variable "lb_stickiness_enabled" {
+  description = "Habilita afinidade de sessão nos target groups"
+  type        = bool
+  default     = false
+}
+
+variable "lb_stickiness_type" {
+  description = "Tipo de stickiness: lb_cookie | app_cookie"
+  type        = string
+  default     = "lb_cookie"
+}
+
+variable "lb_stickiness_cookie_name" {
+  description = "Cookie da aplicação usado quando lb_stickiness_type=app_cookie"
+  type        = string
+  default     = ""
+}
+
+variable "lb_stickiness_duration" {
+  description = "Duração (s) da afinidade de sessão"
+  type        = number
+  default     = 86400
+}
+
+variable "lb_routes" {
+  description = "Rotas por path no listener, cada uma com target group próprio numa porta das instâncias app"
+  type = list(object({
+    name              = string
+    path_patterns     = list(string)
+    target_port       = number
+    priority          = number
+    health_check_path = string
+  }))
+  default = []
+}
+
 variable "app_port" {
   description = "Porta da aplicação no target group (porta do tráfego ALB -> EC2 APP)"
   type        = number
@@ -368,6 +532,149 @@ variable "app_asg_cpu_target" {
   default     = 60
 }
 
+variable "app_asg_request_count_target" {
+  description = "Target de requests por instância (ALBRequestCountPerTarget); 0 desativa"
+  type        = number
+  default     = 0
+}
+
+variable "app_asg_schedules" {
+  description = "Ações agendadas do ASG (cron); -1 mantém o tamanho atual"
+  type = list(object({
+    name             = string
+    recurrence       = string
+    time_zone        = string
+    min_size         = number
+    max_size         = number
+    desired_capacity = number
+  }))
+  default = []
+}
+
+variable "app_asg_step_policies" {
+  description = "Step scaling policies disparadas por alarmes CloudWatch customizados"
+  type = list(object({
+    name                = string
+    metric_name         = string
+    namespace           = string
+    statistic           = string
+    dimensions          = map(string)
+    comparison_operator = string
+    threshold           = number
+    period              = number
+    evaluation_periods  = number
+    adjustment_type     = string
+    steps = list(object({
+      lower_bound = number
+      upper_bound = number
+      adjustment  = number
+    }))
+  }))
+  default = []
+}
+
+variable "app_asg_warm_pool_enabled" {
+  description = "Mantém um warm pool de instâncias pré-inicializadas no ASG"
+  type        = bool
+  default     = false
+}
+
+variable "app_asg_warm_pool_min_size" {
+  description = "Mínimo de instâncias no warm pool"
+  type        = number
+  default     = 0
+}
+
+variable "app_asg_warm_pool_max_prepared_capacity" {
+  description = "Máximo de instâncias preparadas (ASG + warm pool); null usa max_size"
+  type        = number
+  default     = null
+}
+
+variable "app_asg_warm_pool_state" {
+  description = "Estado das instâncias no warm pool (Stopped ou Running)"
+  type        = string
+  default     = "Stopped"
+}
+
+variable "app_asg_warm_pool_reuse_on_scale_in" {
+  description = "Devolve instâncias ao warm pool no scale-in em vez de terminá-las"
+  type        = bool
+  default     = false
+}
+
+variable "app_asg_instance_types" {
+  description = "Tipos de instância da mixed instances policy (em ordem de prioridade); vazio usa só instance_type"
+  type        = list(string)
+  default     = []
+}
+
+variable "app_asg_on_demand_base_capacity" {
+  description = "Instâncias on-demand mínimas antes da divisão on-demand/Spot"
+  type        = number
+  default     = 0
+}
+
+variable "app_asg_on_demand_percentage" {
+  description = "Percentual on-demand acima da base (o restante é Spot)"
+  type        = number
+  default     = 100
+}
+
+variable "app_asg_spot_allocation_strategy" {
+  description = "Estratégia de alocação Spot do ASG"
+  type        = string
+  default     = "price-capacity-optimized"
+}
+
+variable "app_asg_capacity_rebalance" {
+  description = "Substitui proativamente instâncias Spot com risco elevado de interrupção"
+  type        = bool
+  default     = false
+}
+
+variable "app_asg_rollout_enabled" {
+  description = "Substitui a frota do ASG via instance refresh quando o launch template muda"
+  type        = bool
+  default     = false
+}
+
+variable "app_asg_rollout_min_healthy_percentage" {
+  description = "Percentual mínimo da capacidade que permanece healthy durante o refresh"
+  type        = number
+  default     = 90
+}
+
+variable "app_asg_rollout_instance_warmup" {
+  description = "Segundos até uma instância nova contar como healthy no refresh"
+  type        = number
+  default     = 300
+}
+
+variable "app_asg_rollout_checkpoint_percentages" {
+  description = "Percentuais em que o refresh pausa (checkpoints); vazio = sem pausas"
+  type        = list(number)
+  default     = []
+}
+
+variable "app_asg_rollout_checkpoint_delay" {
+  description = "Pausa (s) em cada checkpoint do refresh"
+  type        = number
+  default     = 3600
+}
+
+variable "app_asg_rollout_auto_rollback" {
+  description = "Reverte para o launch template anterior se o refresh falhar ou um alarme disparar"
+  type        = bool
+  default     = true
+}
+
+variable "app_asg_rollout_alarm_names" {
+  description = "Alarmes CloudWatch extras que interrompem o refresh quando entram em ALARM"
+  type        = list(string)
+  default     = []
+}
+
 # ----------------------------
 # Observability (Sprint 1)
 # ----------------------------
@@ -463,3 +770,86 @@ variable "recovery_drill_register_to_target_group" {
   type        = bool
   default     = false
 }
+
+variable "default_tags" {
+  description = "Tags aplicadas a todos os recursos via default_tags do provider (contrato + tags gerenciadas pelo brainctl)"
+  type        = map(string)
+  default     = {}
+}
+
+variable "lb_waf_enabled" {
+  description = "Associa um web ACL WAFv2 ao ALB"
+  type        = bool
+  default     = false
+}
+
+variable "lb_waf_web_acl_arn" {
+  description = "ARN de web ACL regional existente; vazio cria um web ACL gerenciado pelo módulo"
+  type        = string
+  default     = ""
+}
+
+variable "lb_waf_managed_rules" {
+  description = "Rule groups gerenciados da AWS no web ACL criado: core | known_bad_inputs | ip_reputation"
+  type        = list(string)
+  default     = ["core", "known_bad_inputs", "ip_reputation"]
+
+  validation {
+    condition     = alltrue([for r in var.lb_waf_managed_rules : contains(["core", "known_bad_inputs", "ip_reputation"], r)])
+    error_message = "lb_waf_managed_rules accepts core, known_bad_inputs and ip_reputation"
+  }
+}
+
+variable "lb_waf_rate_limit" {
+  description = "Limite de requisições por IP a cada 5 minutos (0 desabilita)"
+  type        = number
+  default     = 0
+}
+
+variable "lb_waf_blocked_requests_alarm" {
+  description = "Requisições bloqueadas pelo WAF em 5 minutos que disparam o alarme sev2"
+  type        = number
+  default     = 100
+}
+
+variable "enable_dns" {
+  description = "Cria registros Route53 para o ALB (alias) ou para as instâncias app"
+  type        = bool
+  default     = false
+}
+
+variable "dns_zone_id" {
+  description = "ID da hosted zone Route53 (exclusivo com dns_zone_name)"
+  type        = string
+  default     = ""
+}
+
+variable "dns_zone_name" {
+  description = "Nome da hosted zone Route53, resolvido via data source quando dns_zone_id é vazio"
+  type        = string
+  default     = ""
+}
+
+variable "dns_private_zone" {
+  description = "Indica se dns_zone_name é uma hosted zone privada"
+  type        = bool
+  default     = false
+}
+
+variable "dns_record_name" {
+  description = "Nome do registro (relativo à zona ou FQDN)"
+  type        = string
+  default     = ""
+}
+
+variable "dns_ttl" {
+  description = "TTL dos registros A por instância"
+  type        = number
+  default     = 60
+}
+
+variable "dns_instance_records" {
+  description = "Sem LB, cria um registro A por instância app"
+  type        = bool
+  default     = false
+}
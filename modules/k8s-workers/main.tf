@@ -10,6 +10,14 @@ terraform {
   backend "s3" {}
 }
 
+provider "aws" {
+  region = var.region
+
+  default_tags {
+    tags = var.default_tags
+  }
+}
+
 data "aws_ami" "ubuntu" {
   most_recent = true
   owners      = ["099720109477"]
@@ -49,6 +57,22 @@ locals {
   use_existing_private_route_table = trimspace(var.private_route_table_id) != ""
   nat_public_subnet_cidr           = trimspace(var.public_subnet_cidr) != "" ? var.public_subnet_cidr : "10.0.254.0/24"
   effective_egress_cidrs           = length(var.allowed_egress_cidrs) > 0 ? var.allowed_egress_cidrs : [var.vpc_cidr]
+
+  # HA: control-planes com etcd empilhado distribuídos pelas subnets, atrás de um NLB interno.
+  control_plane_ha         = var.control_plane_count > 1
+  control_plane_subnet_ids = local.control_plane_ha ? local.endpoint_subnet_ids : [var.subnet_id]
+  control_plane_endpoint   = local.control_plane_ha ? aws_lb.control_plane[0].dns_name : ""
+
+  # Workers usam as mesmas subnets dos VPC endpoints: com infrastructure.subnet_ids
+  # os pools se espalham entre AZs; sem ele ficam em subnet_id.
+  worker_subnet_ids = local.endpoint_subnet_ids
+
+  # Comandos de join publicados pelo control-plane no SSM (SecureString) e renovados
+  # antes de o token expirar.
+  join_parameter_prefix = "/brainctl/${local.cluster_name}/k8s"
+
+  node_pools      = { for p in var.node_pools : p.name => p }
+  node_pool_mixed = { for name, p in local.node_pools : name => length(p.instance_types) > 1 || p.spot }
 }
 
 resource "aws_subnet" "nat_public" {
@@ -123,6 +147,15 @@ resource "aws_route_table_association" "private_nat" {
   route_table_id = aws_route_table.private_nat[0].id
 }
 
+# Control-planes HA e workers fora de subnet_id também precisam de egresso (apt,
+# pkgs.k8s.io, snap) para o bootstrap.
+resource "aws_route_table_association" "node_subnets_nat" {
+  for_each = local.create_nat_gateway && !local.use_existing_private_route_table ? setsubtract(toset(concat(local.control_plane_subnet_ids, local.worker_subnet_ids)), [var.subnet_id]) : toset([])
+
+  subnet_id      = each.value
+  route_table_id = aws_route_table.private_nat[0].id
+}
+
 resource "aws_route" "private_internet_via_nat" {
   count = local.create_nat_gateway ? 1 : 0
 
@@ -130,7 +163,7 @@ resource "aws_route" "private_internet_via_nat" {
   destination_cidr_block = "0.0.0.0/0"
   nat_gateway_id         = aws_nat_gateway.cluster[0].id
 
-  depends_on = [aws_route_table_association.private_nat]
+  depends_on = [aws_route_table_association.private_nat, aws_route_table_association.node_subnets_nat]
 }
 
 resource "aws_security_group" "cluster" {
@@ -154,6 +187,19 @@ resource "aws_security_group" "cluster" {
     self        = true
   }
 
+  # O NLB não preserva o IP do cliente (evita hairpin control-plane -> NLB -> si mesmo),
+  # então API e health checks chegam com IPs do NLB dentro da VPC.
+  dynamic "ingress" {
+    for_each = local.control_plane_ha ? [1] : []
+    content {
+      description = "kube-apiserver via NLB"
+      from_port   = 6443
+      to_port     = 6443
+      protocol    = "tcp"
+      cidr_blocks = [var.vpc_cidr]
+    }
+  }
+
   dynamic "ingress" {
     for_each = local.ssh_enabled ? [1] : []
     content {
@@ -248,6 +294,41 @@ resource "aws_vpc_endpoint" "ec2messages" {
   }
 }
 
+resource "aws_ssm_parameter" "worker_join" {
+  name        = "${local.join_parameter_prefix}/worker-join"
+  description = "kubeadm join dos workers (token com TTL curto, renovado pelo control-plane)"
+  type        = "SecureString"
+  value       = "pending"
+
+  # O valor é escrito pelo control-plane; o Terraform só cria o parâmetro.
+  lifecycle {
+    ignore_changes = [value]
+  }
+}
+
+# TTL do token lido pelo publicador em tempo de execução: mudar o TTL não altera o
+# user data dos control-planes.
+resource "aws_ssm_parameter" "join_token_ttl" {
+  name        = "${local.join_parameter_prefix}/join-token-ttl-minutes"
+  description = "TTL (min) dos tokens kubeadm publicados nos parâmetros de join"
+  type        = "String"
+  value       = tostring(var.join_token_ttl_minutes)
+}
+
+resource "aws_ssm_parameter" "control_plane_join" {
+  count = local.control_plane_ha ? 1 : 0
+
+  name        = "${local.join_parameter_prefix}/control-plane-join"
+  description = "kubeadm join dos control-planes adicionais (token + certificate key)"
+  type        = "SecureString"
+  value       = "pending"
+
+  lifecycle {
+    ignore_changes = [value]
+  }
+}
+
+# Role dos workers: só lê o join de worker (sem acesso ao certificate key do cluster).
 resource "aws_iam_role" "instance" {
   name = "${local.cluster_name}-k8s-role"
 
@@ -267,23 +348,151 @@ resource "aws_iam_role_policy_attachment" "ssm" {
   policy_arn = "arn:aws:iam::aws:policy/AmazonSSMManagedInstanceCore"
 }
 
+resource "aws_iam_role_policy" "worker_join" {
+  name = "${local.cluster_name}-k8s-worker-join"
+  role = aws_iam_role.instance.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Effect   = "Allow"
+      Action   = ["ssm:GetParameter"]
+      Resource = [aws_ssm_parameter.worker_join.arn]
+    }]
+  })
+}
+
 resource "aws_iam_instance_profile" "instance" {
   name = "${local.cluster_name}-k8s-profile"
   role = aws_iam_role.instance.name
 }
 
+resource "aws_iam_role" "control_plane" {
+  name = "${local.cluster_name}-k8s-cp-role"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action    = "sts:AssumeRole"
+      Effect    = "Allow"
+      Principal = { Service = "ec2.amazonaws.com" }
+    }]
+  })
+}
+
+resource "aws_iam_role_policy_attachment" "control_plane_ssm" {
+  count      = var.enable_ssm ? 1 : 0
+  role       = aws_iam_role.control_plane.name
+  policy_arn = "arn:aws:iam::aws:policy/AmazonSSMManagedInstanceCore"
+}
+
+resource "aws_iam_role_policy" "control_plane_join" {
+  name = "${local.cluster_name}-k8s-cp-join"
+  role = aws_iam_role.control_plane.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect   = "Allow"
+        Action   = ["ssm:GetParameter", "ssm:PutParameter"]
+        Resource = concat([aws_ssm_parameter.worker_join.arn], aws_ssm_parameter.control_plane_join[*].arn)
+      },
+      {
+        Effect   = "Allow"
+        Action   = ["ssm:GetParameter"]
+        Resource = [aws_ssm_parameter.join_token_ttl.arn]
+      },
+    ]
+  })
+}
+
+resource "aws_iam_instance_profile" "control_plane" {
+  name = "${local.cluster_name}-k8s-cp-profile"
+  role = aws_iam_role.control_plane.name
+}
+
+resource "aws_lb" "control_plane" {
+  count = local.control_plane_ha ? 1 : 0
+
+  name                             = substr("${local.cluster_name}-cp", 0, 32)
+  internal                         = true
+  load_balancer_type               = "network"
+  subnets                          = local.control_plane_subnet_ids
+  enable_cross_zone_load_balancing = true
+
+  tags = {
+    Name = "${local.cluster_name}-cp-nlb"
+  }
+}
+
+resource "aws_lb_target_group" "control_plane" {
+  count = local.control_plane_ha ? 1 : 0
+
+  name               = substr("${local.cluster_name}-cp-api", 0, 32)
+  port               = 6443
+  protocol           = "TCP"
+  vpc_id             = var.vpc_id
+  target_type        = "instance"
+  preserve_client_ip = "false"
+
+  health_check {
+    protocol            = "TCP"
+    port                = "6443"
+    interval            = 10
+    healthy_threshold   = 2
+    unhealthy_threshold = 2
+  }
+}
+
+resource "aws_lb_listener" "control_plane" {
+  count = local.control_plane_ha ? 1 : 0
+
+  load_balancer_arn = aws_lb.control_plane[0].arn
+  port              = 6443
+  protocol          = "TCP"
+
+  default_action {
+    type             = "forward"
+    target_group_arn = aws_lb_target_group.control_plane[0].arn
+  }
+}
+
+resource "aws_lb_target_group_attachment" "control_plane" {
+  count = local.control_plane_ha ? var.control_plane_count : 0
+
+  target_group_arn = aws_lb_target_group.control_plane[0].arn
+  target_id        = aws_instance.control_plane[count.index].id
+  port             = 6443
+}
+
+moved {
+  from = aws_instance.control_plane
+  to   = aws_instance.control_plane[0]
+}
+
 resource "aws_instance" "control_plane" {
+  count                  = var.control_plane_count
   ami                    = local.control_plane_ami
   instance_type          = var.control_plane_type
-  subnet_id              = var.subnet_id
+  subnet_id              = local.control_plane_subnet_ids[count.index % length(local.control_plane_subnet_ids)]
   vpc_security_group_ids = [aws_security_group.cluster.id]
-  iam_instance_profile   = aws_iam_instance_profile.instance.name
+  iam_instance_profile   = aws_iam_instance_profile.control_plane.name
   key_name               = trimspace(var.key_name) == "" ? null : var.key_name
   monitoring             = var.enable_detailed_monitoring
 
+  # O primeiro nó roda kubeadm init; os demais entram como control-plane pelo SSM.
+  # Não recriamos o nó quando o user data muda: o nó 0 rodaria kubeadm init de novo
+  # e criaria outro cluster. O script é idempotente e pode ser reexecutado (seção 9.1).
   user_data = templatefile("${path.module}/templates/control-plane.sh.tftpl", {
-    kubernetes_version = var.kubernetes_version
-    pod_cidr           = var.pod_cidr
+    kubernetes_version           = var.kubernetes_version
+    pod_cidr                     = var.pod_cidr
+    region                       = var.region
+    bootstrap_node               = count.index == 0
+    control_plane_endpoint       = local.control_plane_endpoint
+    worker_join_parameter        = aws_ssm_parameter.worker_join.name
+    control_plane_join_parameter = local.control_plane_ha ? aws_ssm_parameter.control_plane_join[0].name : ""
+    join_token_ttl_parameter     = aws_ssm_parameter.join_token_ttl.name
   })
 
   metadata_options {
@@ -301,36 +510,140 @@ resource "aws_instance" "control_plane" {
     aws_vpc_endpoint.ssm,
     aws_vpc_endpoint.ssmmessages,
     aws_vpc_endpoint.ec2messages,
+    aws_iam_role_policy.control_plane_join,
   ]
 
   tags = {
-    Name = "${local.cluster_name}-cp"
+    Name = var.control_plane_count > 1 ? "${local.cluster_name}-cp-${count.index + 1}" : "${local.cluster_name}-cp"
     Role = "control-plane"
   }
 }
 
-resource "aws_instance" "workers" {
-  count                  = var.worker_count
-  ami                    = local.worker_ami
-  instance_type          = var.worker_type
-  subnet_id              = var.subnet_id
-  vpc_security_group_ids = [aws_security_group.cluster.id]
-  iam_instance_profile   = aws_iam_instance_profile.instance.name
+# Raiz do worker_ami: o launch template precisa do device name para manter o volume cifrado.
+data "aws_ami" "worker" {
+  filter {
+    name   = "image-id"
+    values = [local.worker_ami]
+  }
+}
+
+resource "aws_launch_template" "workers" {
+  for_each = local.node_pools
+
+  name_prefix            = "${local.cluster_name}-${each.key}-"
+  image_id               = local.worker_ami
+  instance_type          = each.value.instance_types[0]
   key_name               = trimspace(var.key_name) == "" ? null : var.key_name
-  monitoring             = var.enable_detailed_monitoring
+  vpc_security_group_ids = [aws_security_group.cluster.id]
 
-  user_data = templatefile("${path.module}/templates/worker.sh.tftpl", {
-    kubernetes_version       = var.kubernetes_version
-    control_plane_private_ip = aws_instance.control_plane.private_ip
-  })
+  iam_instance_profile {
+    name = aws_iam_instance_profile.instance.name
+  }
+
+  monitoring {
+    enabled = var.enable_detailed_monitoring
+  }
+
+  # Labels (sempre com o nome do pool) e taints entram no kubelet antes do kubeadm join.
+  user_data = base64encode(templatefile("${path.module}/templates/worker.sh.tftpl", {
+    kubernetes_version    = var.kubernetes_version
+    region                = var.region
+    worker_join_parameter = aws_ssm_parameter.worker_join.name
+    node_labels = join(",", [
+      for k, v in merge(each.value.labels, { "brainctl.io/node-pool" = each.key }) : "${k}=${v}"
+    ])
+    node_taints = join(",", [
+      for t in each.value.taints : t.value != "" ? "${t.key}=${t.value}:${t.effect}" : "${t.key}:${t.effect}"
+    ])
+  }))
 
   metadata_options {
     http_endpoint = "enabled"
     http_tokens   = "required"
   }
 
-  root_block_device {
-    encrypted = true
+  block_device_mappings {
+    device_name = data.aws_ami.worker.root_device_name
+    ebs {
+      encrypted   = true
+      volume_type = "gp3"
+    }
+  }
+
+  tag_specifications {
+    resource_type = "instance"
+    tags = merge(var.default_tags, {
+      Name     = "${local.cluster_name}-${each.key}"
+      Role     = "worker"
+      Cluster  = local.cluster_name
+      NodePool = each.key
+    })
+  }
+}
+
+resource "aws_autoscaling_group" "workers" {
+  for_each = local.node_pools
+
+  name                = "${local.cluster_name}-${each.key}"
+  min_size            = each.value.min_size
+  max_size            = each.value.max_size
+  desired_capacity    = each.value.desired_capacity
+  vpc_zone_identifier = local.worker_subnet_ids
+  health_check_type   = "EC2"
+  capacity_rebalance  = each.value.spot
+
+  dynamic "launch_template" {
+    for_each = local.node_pool_mixed[each.key] ? [] : [1]
+    content {
+      id      = aws_launch_template.workers[each.key].id
+      version = "$Latest"
+    }
+  }
+
+  # Vários tipos ou Spot: mixed instances policy (Spot = 0% on-demand).
+  dynamic "mixed_instances_policy" {
+    for_each = local.node_pool_mixed[each.key] ? [1] : []
+    content {
+      instances_distribution {
+        on_demand_base_capacity                  = 0
+        on_demand_percentage_above_base_capacity = each.value.spot ? 0 : 100
+        on_demand_allocation_strategy            = "prioritized"
+        spot_allocation_strategy                 = "price-capacity-optimized"
+      }
+
+      launch_template {
+        launch_template_specification {
+          launch_template_id = aws_launch_template.workers[each.key].id
+          version            = "$Latest"
+        }
+
+        dynamic "override" {
+          for_each = each.value.instance_types
+          content {
+            instance_type = override.value
+          }
+        }
+      }
+    }
+  }
+
+  tag {
+    key                 = "Name"
+    value               = "${local.cluster_name}-${each.key}"
+    propagate_at_launch = false
+  }
+
+  # Descoberta pelo cluster-autoscaler, caso seja instalado no cluster.
+  tag {
+    key                 = "k8s.io/cluster-autoscaler/enabled"
+    value               = "true"
+    propagate_at_launch = false
+  }
+
+  tag {
+    key                 = "k8s.io/cluster-autoscaler/${local.cluster_name}"
+    value               = "owned"
+    propagate_at_launch = false
   }
 
   depends_on = [
@@ -340,10 +653,17 @@ resource "aws_instance" "workers" {
     aws_vpc_endpoint.ssm,
     aws_vpc_endpoint.ssmmessages,
     aws_vpc_endpoint.ec2messages,
+    aws_iam_role_policy.worker_join,
   ]
+}
 
-  tags = {
-    Name = "${local.cluster_name}-worker-${count.index + 1}"
-    Role = "worker"
+# Instâncias atuais dos pools, para status/health/export (lidas após o ASG atingir a capacidade).
+data "aws_instances" "workers" {
+  instance_tags = {
+    Cluster = local.cluster_name
+    Role    = "worker"
   }
+  instance_state_names = ["pending", "running"]
+
+  depends_on = [aws_autoscaling_group.workers]
 }
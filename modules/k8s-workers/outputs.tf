@@ -1,27 +1,51 @@
 output "control_plane_instance_id" {
-  value = aws_instance.control_plane.id
+  value = aws_instance.control_plane[0].id
 }
 
 output "control_plane_private_ip" {
-  value = aws_instance.control_plane.private_ip
+  value = aws_instance.control_plane[0].private_ip
 }
 
 output "control_plane_public_ip" {
-  value = aws_instance.control_plane.public_ip
+  value = aws_instance.control_plane[0].public_ip
 }
 
 output "control_plane_public_dns" {
-  value = aws_instance.control_plane.public_dns
+  value = aws_instance.control_plane[0].public_dns
+}
+
+output "control_plane_instance_ids" {
+  value = aws_instance.control_plane[*].id
+}
+
+output "control_plane_private_ips" {
+  value = aws_instance.control_plane[*].private_ip
+}
+
+output "control_plane_endpoint" {
+  value = local.control_plane_ha ? "${aws_lb.control_plane[0].dns_name}:6443" : "${aws_instance.control_plane[0].private_ip}:6443"
+}
+
+output "join_parameter_names" {
+  value = concat([aws_ssm_parameter.worker_join.name], aws_ssm_parameter.control_plane_join[*].name)
 }
 
 output "worker_instance_ids" {
-  value = aws_instance.workers[*].id
+  value = data.aws_instances.workers.ids
+}
+
+output "worker_private_ips" {
+  value = data.aws_instances.workers.private_ips
+}
+
+output "worker_asg_names" {
+  value = { for name, asg in aws_autoscaling_group.workers : name => asg.name }
 }
 
 output "kubeconfig_retrieve_instructions" {
-  value = "scp -o StrictHostKeyChecking=no -i <key.pem> ubuntu@${aws_instance.control_plane.public_dns}:/home/ubuntu/.kube/config ./kubeconfig && KUBECONFIG=./kubeconfig kubectl get nodes"
+  value = "scp -o StrictHostKeyChecking=no -i <key.pem> ubuntu@${aws_instance.control_plane[0].public_dns}:/home/ubuntu/.kube/config ./kubeconfig && KUBECONFIG=./kubeconfig kubectl get nodes"
 }
 
 output "validation_command" {
-  value = "ssh -o StrictHostKeyChecking=no -i <key.pem> ubuntu@${aws_instance.control_plane.public_dns} 'kubectl get nodes -o wide'"
+  value = "ssh -o StrictHostKeyChecking=no -i <key.pem> ubuntu@${aws_instance.control_plane[0].public_dns} 'kubectl get nodes -o wide'"
 }
@@ -41,12 +41,43 @@ variable "control_plane_type" {
   type = string
 }
 
-variable "worker_type" {
-  type = string
+variable "control_plane_count" {
+  type    = number
+  default = 1
+
+  validation {
+    condition     = var.control_plane_count >= 1 && var.control_plane_count <= 7 && var.control_plane_count % 2 == 1
+    error_message = "control_plane_count must be odd (1, 3, 5 or 7) to keep etcd quorum"
+  }
 }
 
-variable "worker_count" {
-  type = number
+variable "join_token_ttl_minutes" {
+  description = "TTL do token kubeadm publicado no SSM; o control-plane renova o parâmetro antes de expirar"
+  type        = number
+  default     = 60
+}
+
+variable "node_pools" {
+  description = "Pools de workers (um launch template + ASG por pool)"
+  type = list(object({
+    name             = string
+    instance_types   = list(string)
+    min_size         = number
+    max_size         = number
+    desired_capacity = number
+    labels           = map(string)
+    taints = list(object({
+      key    = string
+      value  = string
+      effect = string
+    }))
+    spot = bool
+  }))
+
+  validation {
+    condition     = length(var.node_pools) > 0
+    error_message = "node_pools must have at least one pool"
+  }
 }
 
 variable "kubernetes_version" {
@@ -126,3 +157,9 @@ variable "enable_ssm_vpc_endpoints" {
   type    = bool
   default = true
 }
+
+variable "default_tags" {
+  description = "Tags aplicadas a todos os recursos via default_tags do provider (contrato + tags gerenciadas pelo brainctl)"
+  type        = map(string)
+  default     = {}
+}